@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -27,9 +28,12 @@ import (
 	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/header"
+	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -137,6 +141,7 @@ func init() {
 	addToExampleValues(txResponse)
 	addToExampleValues(samplingStats)
 	addToExampleValues(extendedHeader)
+	addToExampleValues(extendedHeader.Time())
 	addToExampleValues(resourceMngrStats)
 
 	mathInt, _ := math.NewIntFromString("42")
@@ -158,6 +163,40 @@ func init() {
 	}
 	addToExampleValues(addrInfo)
 
+	addToExampleValues(map[string][]peers.PeerScore{
+		"full": {{
+			ID:            peerID,
+			Score:         0.18,
+			Latency:       750 * time.Millisecond,
+			Throughput:    1 << 21,
+			SuccessRate:   0.92,
+			NotFoundRatio: 0.04,
+		}},
+	})
+
+	addToExampleValues([]peers.BlacklistEntry{{
+		ID:     peerID,
+		Reason: "misbehave",
+		Time:   extendedHeader.Time(),
+		Expiry: extendedHeader.Time().Add(24 * time.Hour),
+	}})
+	addToExampleValues(24 * time.Hour)
+
+	addToExampleValues(&modheader.Checkpoint{
+		Height: 42,
+		Hash:   extendedHeader.Hash(),
+		Signer: peerID.String(),
+	})
+
+	addToExampleValues(&modfraud.HaltRecord{
+		ProofType:       byzantine.BadEncoding,
+		Height:          42,
+		HeaderHash:      extendedHeader.Hash().String(),
+		Reporter:        peerID,
+		StoppedServices: []string{"das.DASer", "sync.Syncer"},
+		Time:            extendedHeader.Time(),
+	})
+
 	commitment, err := base64.StdEncoding.DecodeString("aHlbp+J9yub6hw/uhK6dP8hBLR2mFy78XNRRdLf2794=")
 	if err != nil {
 		panic(err)
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/core"
+)
+
+func init() {
+	coreCmd.AddCommand(coreRecord)
+}
+
+var coreCmd = &cobra.Command{
+	Use:   "core [subcommand]",
+	Short: "Collection of core related utilities",
+}
+
+var coreRecord = &cobra.Command{
+	Use: "record [ip] [rpc-port] [from-height] [to-height] [output-file]",
+	Short: `Records the given inclusive range of blocks, together with their commits and validator sets,
+from a Core node into a file, which can be replayed with core.ReplayClient.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if len(args) != 5 {
+			return errors.New("not enough arguments")
+		}
+
+		from, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid from-height: %w", err)
+		}
+		to, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid to-height: %w", err)
+		}
+		if from < 1 || to < from {
+			return fmt.Errorf("invalid height range [%d:%d]", from, to)
+		}
+
+		client, err := core.NewRemote(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(args[4])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, f.Close())
+		}()
+
+		recorder := core.NewBlockRecorder(f)
+		for height := from; height <= to; height++ {
+			block, err := client.SignedBlock(cmd.Context(), &height)
+			if err != nil {
+				return fmt.Errorf("fetching block at height %d: %w", height, err)
+			}
+			if err = recorder.Record(block); err != nil {
+				return err
+			}
+			if height%100 == 0 {
+				fmt.Printf("recorded up to height %d\n", height)
+			}
+		}
+		fmt.Printf("recorded blocks [%d:%d] into %s\n", from, to, args[4])
+		return f.Sync()
+	},
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+func init() {
+	edsStoreCmd.AddCommand(edsStoreCheck)
+}
+
+var edsStoreCheck = &cobra.Command{
+	Use: "check [node-store-path]",
+	Short: `Recovers interrupted writes and checks consistency of the node's eds.Store between the index
+repository, the inverted index and the blocks directory. Requires the node being stopped.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if len(args) != 1 {
+			return errors.New("not enough arguments")
+		}
+
+		nodestore, err := nodebuilder.OpenStore(args[0], nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, nodestore.Close())
+		}()
+
+		cfg, err := nodestore.Config()
+		if err != nil {
+			return err
+		}
+		ds, err := nodestore.Datastore()
+		if err != nil {
+			return err
+		}
+
+		store, err := eds.NewStore(cfg.Share.EDSStoreParams, nodestore.Path(), ds)
+		if err != nil {
+			return err
+		}
+		// starting the store recovers writes interrupted by a crash
+		if err = store.Start(cmd.Context()); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, store.Stop(cmd.Context()))
+		}()
+
+		report, err := store.CheckConsistency(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		printHashes("registered shards without CAR file", report.MissingFiles)
+		printHashes("CAR files without registered shard", report.OrphanedFiles)
+		printHashes("registered shards without index", report.MissingIndices)
+		printHashes("indices without registered shard", report.OrphanedIndices)
+		printHashes("registered shards missing in inverted index", report.MissingInvertedIndex)
+		printHashes("unrecovered interrupted writes", report.PendingPuts)
+		if !report.IsConsistent() {
+			return errors.New("eds store is inconsistent")
+		}
+		fmt.Println("eds store is consistent")
+		return nil
+	},
+}
+
+func printHashes(title string, hashes []share.DataHash) {
+	if len(hashes) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", title, len(hashes))
+	for _, hash := range hashes {
+		fmt.Printf("\t%s\n", hash.String())
+	}
+}
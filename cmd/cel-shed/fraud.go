@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+)
+
+func init() {
+	fraudCmd.AddCommand(fraudHaltRecord)
+}
+
+var fraudCmd = &cobra.Command{
+	Use:   "fraud [subcommand]",
+	Short: "Collection of fraud module related utilities",
+}
+
+var fraudHaltRecord = &cobra.Command{
+	Use: "halt-record [node-store-path]",
+	Short: `Prints the record of the fraud proof that halted the node, which refuses to start once halted.
+Requires the node being stopped.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("not enough arguments")
+		}
+
+		s, err := nodebuilder.OpenStore(args[0], nil)
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		ds, err := s.Datastore()
+		if err != nil {
+			return err
+		}
+
+		record, err := modfraud.LoadHaltRecord(cmd.Context(), ds)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			fmt.Println("the node has not halted")
+			return nil
+		}
+
+		out, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	},
+}
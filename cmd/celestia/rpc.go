@@ -4,6 +4,7 @@ import (
 	"github.com/celestiaorg/celestia-node/cmd"
 	blob "github.com/celestiaorg/celestia-node/nodebuilder/blob/cmd"
 	das "github.com/celestiaorg/celestia-node/nodebuilder/das/cmd"
+	fraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud/cmd"
 	header "github.com/celestiaorg/celestia-node/nodebuilder/header/cmd"
 	node "github.com/celestiaorg/celestia-node/nodebuilder/node/cmd"
 	p2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p/cmd"
@@ -14,6 +15,7 @@ import (
 func init() {
 	blob.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
 	das.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
+	fraud.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
 	header.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
 	p2p.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
 	share.Cmd.PersistentFlags().AddFlagSet(cmd.RPCFlags())
@@ -23,6 +25,7 @@ func init() {
 	rootCmd.AddCommand(
 		blob.Cmd,
 		das.Cmd,
+		fraud.Cmd,
 		header.Cmd,
 		p2p.Cmd,
 		share.Cmd,
@@ -0,0 +1,403 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/nmt"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/pruner"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+const (
+	defaultBackfillWorkers = 16
+	// blockMetasBatch is the maximum amount of block metas Core returns at once
+	blockMetasBatch = 20
+
+	backfillRetryDelay     = time.Minute
+	backfillReportInterval = 30 * time.Second
+)
+
+var (
+	backfillKey   = datastore.NewKey("core-backfill")
+	checkpointKey = datastore.NewKey("checkpoint")
+	errNoBackfill = errors.New("backfill: nothing to backfill")
+)
+
+// BackfillProgress describes the progress of the running backfill.
+type BackfillProgress struct {
+	// From and To are the inclusive range of heights being backfilled.
+	From, To uint64
+	// Done is the amount of heights in the range that are already processed.
+	Done uint64
+	// Stored is the amount of processed heights, which EDSes were missing and got stored.
+	Stored uint64
+}
+
+// Backfiller walks the heights within the availability window that are missing in the eds.Store,
+// e.g. because the node was down, and stores their EDSes, fetching blocks from Core in parallel.
+// The highest height up to which all the heights are known to be stored is persisted, so the
+// following runs only walk the heights produced since.
+type Backfiller struct {
+	fetcher   *BlockFetcher
+	store     *eds.Store
+	construct header.ConstructFn
+	ds        datastore.Datastore
+
+	availabilityWindow pruner.AvailabilityWindow
+	workers            int
+	metrics            *backfillMetrics
+
+	progressLk sync.Mutex
+	progress   BackfillProgress
+	// done holds processed heights above the contiguously processed ones
+	done       map[uint64]struct{}
+	contiguous uint64
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// NewBackfiller creates a new Backfiller.
+func NewBackfiller(
+	fetcher *BlockFetcher,
+	store *eds.Store,
+	construct header.ConstructFn,
+	ds datastore.Datastore,
+	opts ...Option,
+) (*Backfiller, error) {
+	p := defaultParams()
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	var (
+		metrics *backfillMetrics
+		err     error
+	)
+	if p.metrics {
+		metrics, err = newBackfillMetrics()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b := &Backfiller{
+		fetcher:            fetcher,
+		store:              store,
+		construct:          construct,
+		ds:                 namespace.Wrap(ds, backfillKey),
+		availabilityWindow: p.availabilityWindow,
+		workers:            p.backfillWorkers,
+		metrics:            metrics,
+	}
+	metrics.register(b)
+	return b, nil
+}
+
+// Start runs the backfill in the background, retrying until it succeeds.
+func (b *Backfiller) Start(context.Context) error {
+	if b.cancel != nil {
+		return fmt.Errorf("backfill: already started")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.doneCh = make(chan struct{})
+	go b.run(ctx)
+	return nil
+}
+
+// Stop stops the running backfill. Progress made so far is persisted.
+func (b *Backfiller) Stop(ctx context.Context) error {
+	if b.cancel != nil {
+		b.cancel()
+		select {
+		case <-b.doneCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return b.metrics.close()
+}
+
+// Progress returns the progress of the running or the last finished backfill.
+func (b *Backfiller) Progress() BackfillProgress {
+	b.progressLk.Lock()
+	defer b.progressLk.Unlock()
+	return b.progress
+}
+
+func (b *Backfiller) run(ctx context.Context) {
+	defer close(b.doneCh)
+	for {
+		err := b.Backfill(ctx)
+		switch {
+		case err == nil:
+			return
+		case errors.Is(err, errNoBackfill):
+			log.Debug("backfill: eds store is up to date")
+			return
+		case ctx.Err() != nil:
+			return
+		}
+
+		log.Errorw("backfill: failed, retrying", "retry_in", backfillRetryDelay, "err", err)
+		select {
+		case <-time.After(backfillRetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Backfill stores EDSes of all the heights missing in the eds.Store, from the persisted checkpoint
+// or the start of the availability window up to the latest height known to Core.
+func (b *Backfiller) Backfill(ctx context.Context) error {
+	latest, err := b.fetcher.LatestHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("backfill: getting latest height: %w", err)
+	}
+	from, err := b.startHeight(ctx, latest)
+	if err != nil {
+		return err
+	}
+	if from > latest {
+		return errNoBackfill
+	}
+	log.Infow("backfill: started", "from", from, "to", latest)
+	b.resetProgress(uint64(from), uint64(latest))
+
+	reportCtx, cancelReport := context.WithCancel(ctx)
+	reportDone := make(chan struct{})
+	go b.report(reportCtx, reportDone)
+	defer func() {
+		cancelReport()
+		<-reportDone
+	}()
+
+	errGroup, gctx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(b.workers)
+	for batchFrom := from; batchFrom <= latest; {
+		batchTo := min(batchFrom+blockMetasBatch-1, latest)
+		metas, err := b.fetcher.GetBlockMetas(gctx, batchFrom, batchTo)
+		if err != nil {
+			_ = errGroup.Wait()
+			return fmt.Errorf("backfill: getting block metas from %d to %d: %w", batchFrom, batchTo, err)
+		}
+		if len(metas) == 0 {
+			_ = errGroup.Wait()
+			return fmt.Errorf("backfill: no block metas from %d to %d", batchFrom, batchTo)
+		}
+		// metas are returned in descending order and may be truncated from below
+		sort.Slice(metas, func(i, j int) bool {
+			return metas[i].Header.Height < metas[j].Header.Height
+		})
+		// Core clamps the range to its earliest block, so the heights below it can't be backfilled
+		// from it and must not be covered by the checkpoint
+		if first := metas[0].Header.Height; first > batchFrom {
+			_ = errGroup.Wait()
+			return fmt.Errorf("backfill: core is missing blocks from %d to %d", batchFrom, first-1)
+		}
+
+		for _, meta := range metas {
+			height, dataHash := meta.Header.Height, share.DataHash(meta.Header.DataHash)
+			if dataHash.IsEmptyRoot() || !pruner.IsWithinAvailabilityWindow(meta.Header.Time, b.availabilityWindow) {
+				b.markDone(uint64(height), false)
+				continue
+			}
+
+			errGroup.Go(func() error {
+				stored, err := b.backfillHeight(gctx, height, dataHash)
+				if err != nil {
+					return fmt.Errorf("backfill: height %d: %w", height, err)
+				}
+				b.markDone(uint64(height), stored)
+				return nil
+			})
+		}
+		batchFrom = metas[len(metas)-1].Header.Height + 1
+	}
+	if err := errGroup.Wait(); err != nil {
+		return err
+	}
+
+	if err := b.saveCheckpoint(ctx, uint64(latest)); err != nil {
+		return err
+	}
+	progress := b.Progress()
+	log.Infow("backfill: finished", "from", progress.From, "to", progress.To, "stored", progress.Stored)
+	return nil
+}
+
+// backfillHeight stores the EDS of the given height if missing and reports whether it was stored.
+func (b *Backfiller) backfillHeight(ctx context.Context, height int64, dataHash share.DataHash) (bool, error) {
+	has, err := b.store.Has(ctx, dataHash)
+	if err != nil {
+		return false, fmt.Errorf("checking eds store: %w", err)
+	}
+	if has {
+		// the square could have been stored for another height with the same data
+		return false, b.store.ReferenceAtHeight(ctx, dataHash, uint64(height))
+	}
+
+	blk, err := b.fetcher.GetSignedBlock(ctx, &height)
+	if err != nil {
+		return false, fmt.Errorf("fetching signed block: %w", err)
+	}
+
+	adder := ipld.NewProofsAdder(int(blk.Data.SquareSize))
+	defer adder.Purge()
+
+	eds, err := extendBlock(blk.Data, blk.Header.Version.App, nmt.NodeVisitor(adder.VisitFn()))
+	if err != nil {
+		return false, fmt.Errorf("extending block data: %w", err)
+	}
+	eh, err := b.construct(&blk.Header, &blk.Commit, &blk.ValidatorSet, eds)
+	if err != nil {
+		panic(fmt.Errorf("constructing extended header for height %d: %w", height, err))
+	}
+
+	err = storeEDS(ctx, eh, eds, adder, b.store, b.availabilityWindow)
+	if err != nil {
+		return false, err
+	}
+	b.metrics.observeStored(ctx)
+	return true, nil
+}
+
+// startHeight returns the first height to backfill: the one following the persisted checkpoint or,
+// if there is none, the first height within the availability window that Core still stores.
+func (b *Backfiller) startHeight(ctx context.Context, latest int64) (int64, error) {
+	checkpoint, err := b.checkpoint(ctx)
+	switch {
+	case err == nil:
+		return int64(checkpoint) + 1, nil
+	case !errors.Is(err, datastore.ErrNotFound):
+		return 0, err
+	}
+
+	// Core may have pruned the blocks below its earliest one, so nothing below can be backfilled
+	earliest, err := b.fetcher.EarliestHeight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("backfill: getting earliest height: %w", err)
+	}
+	earliest = max(earliest, 1)
+	if b.availabilityWindow.Duration() == 0 || earliest > latest {
+		return earliest, nil
+	}
+	// block time is monotonic, so search for the first height within the window
+	var searchErr error
+	first := sort.Search(int(latest-earliest+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		height := earliest + int64(i)
+		metas, err := b.fetcher.GetBlockMetas(ctx, height, height)
+		if err != nil || len(metas) == 0 {
+			searchErr = fmt.Errorf("backfill: getting block meta at height %d: %w", height, err)
+			return true
+		}
+		return pruner.IsWithinAvailabilityWindow(metas[0].Header.Time, b.availabilityWindow)
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return earliest + int64(first), nil
+}
+
+func (b *Backfiller) checkpoint(ctx context.Context) (uint64, error) {
+	val, err := b.ds.Get(ctx, checkpointKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(val) != 8 {
+		return 0, fmt.Errorf("backfill: invalid checkpoint of %d bytes", len(val))
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func (b *Backfiller) saveCheckpoint(ctx context.Context, height uint64) error {
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, height)
+	if err := b.ds.Put(ctx, checkpointKey, val); err != nil {
+		return fmt.Errorf("backfill: saving checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (b *Backfiller) resetProgress(from, to uint64) {
+	b.progressLk.Lock()
+	defer b.progressLk.Unlock()
+	b.progress = BackfillProgress{From: from, To: to}
+	b.done = make(map[uint64]struct{})
+	b.contiguous = from - 1
+}
+
+// markDone records the height as processed.
+func (b *Backfiller) markDone(height uint64, stored bool) {
+	b.progressLk.Lock()
+	defer b.progressLk.Unlock()
+	b.progress.Done++
+	if stored {
+		b.progress.Stored++
+	}
+
+	b.done[height] = struct{}{}
+	for {
+		if _, ok := b.done[b.contiguous+1]; !ok {
+			break
+		}
+		delete(b.done, b.contiguous+1)
+		b.contiguous++
+	}
+}
+
+// report periodically logs the progress and persists the contiguously processed heights as the
+// checkpoint, so an interrupted backfill resumes from there.
+func (b *Backfiller) report(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(backfillReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			b.persistContiguous()
+			return
+		case <-ticker.C:
+			progress := b.Progress()
+			log.Infow("backfill: in progress",
+				"from", progress.From, "to", progress.To,
+				"done", progress.Done, "stored", progress.Stored)
+			b.persistContiguous()
+		}
+	}
+}
+
+func (b *Backfiller) persistContiguous() {
+	b.progressLk.Lock()
+	contiguous, from := b.contiguous, b.progress.From
+	b.progressLk.Unlock()
+	if contiguous < from {
+		return
+	}
+
+	// use a detached context, so the progress is persisted when the backfill is being stopped
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := b.saveCheckpoint(ctx, contiguous); err != nil {
+		log.Warnw("backfill: persisting progress", "err", err)
+	}
+}
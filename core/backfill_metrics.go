@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+)
+
+type backfillMetrics struct {
+	storedInst metric.Int64Counter
+
+	remainingInst metric.Int64ObservableGauge
+	remainingReg  metric.Registration
+}
+
+func newBackfillMetrics() (*backfillMetrics, error) {
+	m := new(backfillMetrics)
+
+	var err error
+	m.storedInst, err = meter.Int64Counter(
+		"core_backfill_stored_count",
+		metric.WithDescription("number of missing EDSes stored by core backfill"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.remainingInst, err = meter.Int64ObservableGauge(
+		"core_backfill_remaining_heights",
+		metric.WithDescription("number of heights remaining to be processed by core backfill"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// register starts observing the progress of the given Backfiller.
+func (m *backfillMetrics) register(b *Backfiller) {
+	if m == nil {
+		return
+	}
+
+	var err error
+	m.remainingReg, err = meter.RegisterCallback(
+		func(_ context.Context, obs metric.Observer) error {
+			progress := b.Progress()
+			var remaining int64
+			if progress.To >= progress.From {
+				remaining = int64(progress.To-progress.From+1) - int64(progress.Done)
+			}
+			obs.ObserveInt64(m.remainingInst, remaining)
+			return nil
+		},
+		m.remainingInst,
+	)
+	if err != nil {
+		log.Errorw("backfill: registering metrics callback", "err", err)
+	}
+}
+
+func (m *backfillMetrics) observeStored(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	ctx = utils.ResetContextOnError(ctx)
+	m.storedInst.Add(ctx, 1)
+}
+
+func (m *backfillMetrics) close() error {
+	if m == nil || m.remainingReg == nil {
+		return nil
+	}
+	return m.remainingReg.Unregister()
+}
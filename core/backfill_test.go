@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestBackfiller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	cfg := DefaultTestConfig()
+	fetcher, cctx := createCoreFetcher(t, cfg)
+	// blocks are produced while nothing stores them, as if the node was down
+	dataRoots := generateNonEmptyBlocks(t, ctx, fetcher, cfg, cctx)
+
+	store := createStore(t)
+	datastore := ds_sync.MutexWrap(ds.NewMapDatastore())
+	backfiller, err := NewBackfiller(fetcher, store, header.MakeExtendedHeader, datastore, WithBackfillWorkers(4))
+	require.NoError(t, err)
+
+	require.NoError(t, backfiller.Backfill(ctx))
+	for _, hash := range dataRoots {
+		has, err := store.Has(ctx, hash)
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	progress := backfiller.Progress()
+	require.EqualValues(t, 1, progress.From)
+	require.Equal(t, progress.To-progress.From+1, progress.Done)
+	require.GreaterOrEqual(t, progress.Stored, uint64(len(dataRoots)))
+
+	// the following run continues from the persisted checkpoint
+	checkpoint, err := backfiller.checkpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, progress.To, checkpoint)
+	from, err := backfiller.startHeight(ctx, int64(progress.To))
+	require.NoError(t, err)
+	require.EqualValues(t, progress.To+1, from)
+}
+
+func TestBackfiller_PrunedCore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	cfg := DefaultTestConfig()
+	fetcher, cctx := createCoreFetcher(t, cfg)
+	generateNonEmptyBlocks(t, ctx, fetcher, cfg, cctx)
+	latest, err := fetcher.LatestHeight(ctx)
+	require.NoError(t, err)
+
+	// the recording starts above the first height, like the blocks of a pruned Core
+	const earliest = 3
+	path := filepath.Join(t.TempDir(), "blocks")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	recorder := NewBlockRecorder(f)
+	for height := int64(earliest); height <= latest; height++ {
+		b, err := fetcher.GetSignedBlock(ctx, &height)
+		require.NoError(t, err)
+		require.NoError(t, recorder.Record(b))
+	}
+	require.NoError(t, f.Close())
+
+	client, err := NewReplayClient(path, WithReplayInterval(0))
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+	})
+	pruned := NewBlockFetcher(client)
+	require.Eventually(t, func() bool {
+		height, err := pruned.LatestHeight(ctx)
+		return err == nil && height == latest
+	}, 10*time.Second, 10*time.Millisecond)
+
+	store := createStore(t)
+	datastore := ds_sync.MutexWrap(ds.NewMapDatastore())
+	backfiller, err := NewBackfiller(pruned, store, header.MakeExtendedHeader, datastore, WithBackfillWorkers(4))
+	require.NoError(t, err)
+
+	// the backfill starts from the earliest block of Core
+	require.NoError(t, backfiller.Backfill(ctx))
+	progress := backfiller.Progress()
+	require.EqualValues(t, earliest, progress.From)
+	require.EqualValues(t, latest, progress.To)
+
+	// the heights missing in Core fail the backfill instead of being covered by the checkpoint
+	require.NoError(t, backfiller.saveCheckpoint(ctx, 1))
+	require.Error(t, backfiller.Backfill(ctx))
+	checkpoint, err := backfiller.checkpoint(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, checkpoint)
+}
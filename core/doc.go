@@ -0,0 +1,13 @@
+// Package core implements the connection of a bridge node to Celestia Core (a consensus node). It
+// fetches new blocks from Core, extends them into EDSes and constructs ExtendedHeaders out of them.
+//
+// New blocks are received through the Tendermint RPC websocket subscription by default. As an
+// alternative, the BlockFetcher can pull blocks by height over the RPC client every configured
+// interval (see WithBlockPolling). Polling resumes from the last delivered height, so no block is
+// skipped when a request fails or the subscription is restarted. Core does not expose a gRPC
+// block streaming service, so there is no gRPC streaming client, and polling is the way to get
+// gap-free block delivery.
+//
+// The BlockFetcher also supports failing over between multiple Core endpoints, based on periodic
+// health checks of their sync status and height.
+package core
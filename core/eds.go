@@ -73,7 +73,7 @@ func storeEDS(
 
 	ctx = ipld.CtxWithProofsAdder(ctx, adder)
 
-	err := store.Put(ctx, share.DataHash(eh.DataHash), eds)
+	err := store.PutAtHeight(ctx, share.DataHash(eh.DataHash), eh.Height(), eds)
 	if errors.Is(err, dagstore.ErrShardExists) {
 		// block with given root already exists, return nil
 		return nil
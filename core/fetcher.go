@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -20,19 +23,54 @@ var (
 )
 
 type BlockFetcher struct {
-	client Client
-
-	doneCh chan struct{}
-	cancel context.CancelFunc
+	// clients are ordered by priority, with the primary one first
+	clients []Client
+	active  atomic.Int32
+
+	healthCheckInterval time.Duration
+	maxHeightLag        uint64
+	metricsEnabled      bool
+	pollInterval        time.Duration
+	metrics             *fetcherMetrics
+	healthCancel        context.CancelFunc
+	healthDone          chan struct{}
+
+	// onFailover are notified about every failover
+	failoverLk sync.Mutex
+	onFailover []func(endpoint int)
+
+	// subLk protects the state of the new blocks subscription
+	subLk     sync.Mutex
+	subClient Client
+	doneCh    chan struct{}
+	cancel    context.CancelFunc
+	// lastHeight is the height of the last block delivered to a subscriber, kept across
+	// resubscriptions
+	lastHeight atomic.Int64
 }
 
-// NewBlockFetcher returns a new `BlockFetcher`.
-func NewBlockFetcher(client Client) *BlockFetcher {
+// NewBlockFetcher returns a new `BlockFetcher`. Clients passed with WithFailoverClients are used
+// when the given primary client becomes unhealthy.
+func NewBlockFetcher(client Client, opts ...Option) *BlockFetcher {
+	p := defaultParams()
+	for _, opt := range opts {
+		opt(&p)
+	}
+
 	return &BlockFetcher{
-		client: client,
+		clients:             append([]Client{client}, p.failoverClients...),
+		healthCheckInterval: p.healthCheckInterval,
+		maxHeightLag:        p.maxHeightLag,
+		metricsEnabled:      p.metrics,
+		pollInterval:        p.pollInterval,
 	}
 }
 
+// client returns the currently active Client.
+func (f *BlockFetcher) client() Client {
+	return f.clients[f.active.Load()]
+}
+
 // GetBlockInfo queries Core for additional block information, like Commit and ValidatorSet.
 func (f *BlockFetcher) GetBlockInfo(ctx context.Context, height *int64) (*types.Commit, *types.ValidatorSet, error) {
 	commit, err := f.Commit(ctx, height)
@@ -55,7 +93,7 @@ func (f *BlockFetcher) GetBlockInfo(ctx context.Context, height *int64) (*types.
 
 // GetBlock queries Core for a `Block` at the given height.
 func (f *BlockFetcher) GetBlock(ctx context.Context, height *int64) (*types.Block, error) {
-	res, err := f.client.Block(ctx, height)
+	res, err := f.client().Block(ctx, height)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +106,7 @@ func (f *BlockFetcher) GetBlock(ctx context.Context, height *int64) (*types.Bloc
 }
 
 func (f *BlockFetcher) GetBlockByHash(ctx context.Context, hash libhead.Hash) (*types.Block, error) {
-	res, err := f.client.BlockByHash(ctx, hash)
+	res, err := f.client().BlockByHash(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -82,13 +120,43 @@ func (f *BlockFetcher) GetBlockByHash(ctx context.Context, hash libhead.Hash) (*
 
 // GetSignedBlock queries Core for a `Block` at the given height.
 func (f *BlockFetcher) GetSignedBlock(ctx context.Context, height *int64) (*coretypes.ResultSignedBlock, error) {
-	return f.client.SignedBlock(ctx, height)
+	return f.client().SignedBlock(ctx, height)
+}
+
+// GetBlockMetas queries Core for metadata of blocks in the given inclusive range of heights, in
+// descending order. Core limits the amount of returned metas, so fewer blocks than requested may be
+// returned.
+func (f *BlockFetcher) GetBlockMetas(ctx context.Context, minHeight, maxHeight int64) ([]*types.BlockMeta, error) {
+	res, err := f.client().BlockchainInfo(ctx, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	return res.BlockMetas, nil
+}
+
+// LatestHeight queries Core for the height of the latest block.
+func (f *BlockFetcher) LatestHeight(ctx context.Context) (int64, error) {
+	resp, err := f.client().Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.SyncInfo.LatestBlockHeight, nil
+}
+
+// EarliestHeight queries Core for the height of the earliest block it stores, which is above 1 if
+// Core prunes blocks.
+func (f *BlockFetcher) EarliestHeight(ctx context.Context) (int64, error) {
+	resp, err := f.client().Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.SyncInfo.EarliestBlockHeight, nil
 }
 
 // Commit queries Core for a `Commit` from the block at
 // the given height.
 func (f *BlockFetcher) Commit(ctx context.Context, height *int64) (*types.Commit, error) {
-	res, err := f.client.Commit(ctx, height)
+	res, err := f.client().Commit(ctx, height)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +175,7 @@ func (f *BlockFetcher) ValidatorSet(ctx context.Context, height *int64) (*types.
 
 	vals, total := make([]*types.Validator, 0), -1
 	for page := 1; len(vals) != total; page++ {
-		res, err := f.client.Validators(ctx, height, &page, &perPage)
+		res, err := f.client().Validators(ctx, height, &page, &perPage)
 		if err != nil {
 			return nil, err
 		}
@@ -124,25 +192,41 @@ func (f *BlockFetcher) ValidatorSet(ctx context.Context, height *int64) (*types.
 }
 
 // SubscribeNewBlockEvent subscribes to new block events from Core, returning
-// a new block event channel on success.
+// a new block event channel on success. Blocks are delivered strictly in order of heights and
+// without gaps, resuming from the last delivered height after resubscription.
 func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan types.EventDataSignedBlock, error) {
+	f.subLk.Lock()
+	defer f.subLk.Unlock()
+
+	client := f.client()
 	// start the client if not started yet
-	if !f.client.IsRunning() {
+	if !client.IsRunning() {
 		return nil, errors.New("client not running")
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	f.cancel = cancel
 	f.doneCh = make(chan struct{})
+	f.subClient = client
+
+	signedBlockCh := make(chan types.EventDataSignedBlock)
+	doneCh := f.doneCh
+	if f.pollInterval > 0 {
+		go func() {
+			defer close(doneCh)
+			defer close(signedBlockCh)
+			f.pullBlocks(ctx, signedBlockCh)
+		}()
+		return signedBlockCh, nil
+	}
 
-	eventChan, err := f.client.Subscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
+	eventChan, err := client.Subscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	signedBlockCh := make(chan types.EventDataSignedBlock)
 	go func() {
-		defer close(f.doneCh)
+		defer close(doneCh)
 		defer close(signedBlockCh)
 		for {
 			select {
@@ -154,9 +238,12 @@ func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan types
 					return
 				}
 				signedBlock := newEvent.Data.(types.EventDataSignedBlock)
-				select {
-				case signedBlockCh <- signedBlock:
-				case <-ctx.Done():
+				// events may be dropped by Core, e.g. for a slow consumer or between resubscriptions
+				if err := f.fillGap(ctx, signedBlockCh, signedBlock.Header.Height); err != nil {
+					log.Errorw("fetcher: filling the gap in new blocks subscription", "err", err)
+					return
+				}
+				if !f.deliver(ctx, signedBlockCh, signedBlock) {
 					return
 				}
 			}
@@ -168,20 +255,31 @@ func (f *BlockFetcher) SubscribeNewBlockEvent(ctx context.Context) (<-chan types
 
 // UnsubscribeNewBlockEvent stops the subscription to new block events from Core.
 func (f *BlockFetcher) UnsubscribeNewBlockEvent(ctx context.Context) error {
+	f.subLk.Lock()
+	defer f.subLk.Unlock()
+
 	f.cancel()
 	select {
 	case <-f.doneCh:
 	case <-ctx.Done():
 		return fmt.Errorf("fetcher: unsubscribe from new block events: %w", ctx.Err())
 	}
-	return f.client.Unsubscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
+	if f.pollInterval > 0 {
+		// there is no subscription on Core's side
+		return nil
+	}
+	if f.subClient != f.client() {
+		// the fetcher failed over from the client, which is likely unresponsive, so don't wait for it
+		return nil
+	}
+	return f.subClient.Unsubscribe(ctx, newBlockSubscriber, newDataSignedBlockQuery)
 }
 
 // IsSyncing returns the sync status of the Core connection: true for
 // syncing, and false for already caught up. It can also return an error
 // in the case of a failed status request.
 func (f *BlockFetcher) IsSyncing(ctx context.Context) (bool, error) {
-	resp, err := f.client.Status(ctx)
+	resp, err := f.client().Status(ctx)
 	if err != nil {
 		return false, err
 	}
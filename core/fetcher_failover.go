@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultMaxHeightLag        = 5
+
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Start starts the failover Clients and, if there are any, periodic health checks that switch the
+// BlockFetcher to the next healthy Client once the active one becomes unhealthy.
+func (f *BlockFetcher) Start(context.Context) error {
+	if f.healthCancel != nil {
+		return fmt.Errorf("fetcher: already started")
+	}
+
+	for _, client := range f.clients[1:] {
+		if err := client.Start(); err != nil {
+			return fmt.Errorf("fetcher: starting failover client: %w", err)
+		}
+	}
+
+	if f.metricsEnabled {
+		metrics, err := newFetcherMetrics(f)
+		if err != nil {
+			return err
+		}
+		f.metrics = metrics
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f.healthCancel = cancel
+	f.healthDone = make(chan struct{})
+	if len(f.clients) == 1 {
+		// nothing to fail over to
+		close(f.healthDone)
+		return nil
+	}
+	go f.healthLoop(ctx)
+	return nil
+}
+
+// Stop stops health checks and the failover Clients.
+func (f *BlockFetcher) Stop(ctx context.Context) error {
+	if f.healthCancel == nil {
+		return nil
+	}
+	f.healthCancel()
+	select {
+	case <-f.healthDone:
+	case <-ctx.Done():
+		return fmt.Errorf("fetcher: stopping health checks: %w", ctx.Err())
+	}
+
+	var err error
+	for _, client := range f.clients[1:] {
+		err = errors.Join(err, client.Stop())
+	}
+	return errors.Join(err, f.metrics.close())
+}
+
+func (f *BlockFetcher) healthLoop(ctx context.Context) {
+	defer close(f.healthDone)
+	ticker := time.NewTicker(f.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.checkHealth(ctx)
+		}
+	}
+}
+
+// checkHealth queries the status of every Client and fails over to the healthy Client with the
+// highest priority, if the active one is unhealthy. A Client is healthy if it responds, is not
+// catching up and doesn't lag more than maxHeightLag behind the highest known height.
+func (f *BlockFetcher) checkHealth(ctx context.Context) {
+	heights := make([]int64, len(f.clients))
+	healthy := make([]bool, len(f.clients))
+	var highest int64
+	for i, client := range f.clients {
+		statusCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		status, err := client.Status(statusCtx)
+		cancel()
+		if err != nil {
+			log.Debugw("fetcher: core endpoint is unreachable", "endpoint", i, "err", err)
+			continue
+		}
+		if status.SyncInfo.CatchingUp {
+			log.Debugw("fetcher: core endpoint is syncing", "endpoint", i)
+			continue
+		}
+		heights[i], healthy[i] = status.SyncInfo.LatestBlockHeight, true
+		highest = max(highest, heights[i])
+	}
+
+	next := -1
+	for i := range f.clients {
+		if healthy[i] && uint64(highest-heights[i]) > f.maxHeightLag {
+			log.Debugw("fetcher: core endpoint is lagging", "endpoint", i,
+				"height", heights[i], "highest", highest)
+			healthy[i] = false
+		}
+		if healthy[i] && next == -1 {
+			next = i
+		}
+	}
+
+	active := int(f.active.Load())
+	switch {
+	case healthy[active]:
+		// stick to the active client as long as it is healthy to avoid flapping
+	case next == -1:
+		log.Warnw("fetcher: no healthy core endpoints, keeping the active one", "endpoint", active)
+	default:
+		f.failover(ctx, active, next)
+	}
+}
+
+// ActiveEndpoint returns the index of the active Client, with 0 being the primary one.
+func (f *BlockFetcher) ActiveEndpoint() int {
+	return int(f.active.Load())
+}
+
+// OnFailover registers a callback that is called with the index of the Client the BlockFetcher
+// failed over to. It lets other connections to the same Core endpoints follow the health checks.
+func (f *BlockFetcher) OnFailover(fn func(endpoint int)) {
+	f.failoverLk.Lock()
+	defer f.failoverLk.Unlock()
+	f.onFailover = append(f.onFailover, fn)
+}
+
+// failover switches the BlockFetcher to the Client with the given index. The active subscription
+// is closed, so that the subscriber resubscribes to the new Client.
+func (f *BlockFetcher) failover(ctx context.Context, from, to int) {
+	log.Warnw("fetcher: failing over to another core endpoint", "from", from, "to", to)
+	f.active.Store(int32(to))
+	f.metrics.observeFailover(ctx, from, to)
+
+	f.failoverLk.Lock()
+	for _, fn := range f.onFailover {
+		fn(to)
+	}
+	f.failoverLk.Unlock()
+
+	f.subLk.Lock()
+	defer f.subLk.Unlock()
+	if f.cancel != nil && f.subClient != f.clients[to] {
+		f.cancel()
+	}
+}
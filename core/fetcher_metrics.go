@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+)
+
+type fetcherMetrics struct {
+	failoverInst metric.Int64Counter
+	gapInst      metric.Int64Counter
+
+	activeEndpointInst metric.Int64ObservableGauge
+	activeEndpointReg  metric.Registration
+}
+
+func newFetcherMetrics(f *BlockFetcher) (*fetcherMetrics, error) {
+	m := new(fetcherMetrics)
+
+	var err error
+	m.failoverInst, err = meter.Int64Counter(
+		"core_fetcher_failover_count",
+		metric.WithDescription("number of times core fetcher failed over to another core endpoint"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.gapInst, err = meter.Int64Counter(
+		"core_fetcher_refilled_blocks_count",
+		metric.WithDescription("number of blocks missed by core subscription and refilled by core fetcher"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.activeEndpointInst, err = meter.Int64ObservableGauge(
+		"core_fetcher_active_endpoint",
+		metric.WithDescription("index of the core endpoint used by core fetcher, with 0 being the primary one"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.activeEndpointReg, err = meter.RegisterCallback(
+		func(_ context.Context, obs metric.Observer) error {
+			obs.ObserveInt64(m.activeEndpointInst, int64(f.active.Load()))
+			return nil
+		},
+		m.activeEndpointInst,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *fetcherMetrics) observeFailover(ctx context.Context, from, to int) {
+	if m == nil {
+		return
+	}
+	ctx = utils.ResetContextOnError(ctx)
+	m.failoverInst.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("from", strconv.Itoa(from)),
+		attribute.String("to", strconv.Itoa(to)),
+	))
+}
+
+func (m *fetcherMetrics) observeGap(ctx context.Context, missing int64) {
+	if m == nil {
+		return
+	}
+	ctx = utils.ResetContextOnError(ctx)
+	m.gapInst.Add(ctx, missing)
+}
+
+func (m *fetcherMetrics) close() error {
+	if m == nil {
+		return nil
+	}
+	return m.activeEndpointReg.Unregister()
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// pullBlocks delivers blocks to out by pulling them from Core height by height. It starts from the
+// height following the last delivered one or, if none was delivered yet, the one following the
+// latest height. A failing request is retried on the next poll from the same height, so no block
+// is skipped.
+func (f *BlockFetcher) pullBlocks(ctx context.Context, out chan<- types.EventDataSignedBlock) {
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+	for {
+		latest, err := f.LatestHeight(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Warnw("fetcher: polling latest height", "err", err)
+		}
+		if err == nil {
+			f.lastHeight.CompareAndSwap(0, latest)
+			for height := f.lastHeight.Load() + 1; height <= latest; height++ {
+				block, err := f.getSignedBlockEvent(ctx, height)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Warnw("fetcher: polling block", "height", height, "err", err)
+					}
+					break
+				}
+				if !f.deliver(ctx, out, block) {
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fillGap delivers blocks Core did not send an event for, between the last delivered height and
+// the given one.
+func (f *BlockFetcher) fillGap(ctx context.Context, out chan<- types.EventDataSignedBlock, height int64) error {
+	last := f.lastHeight.Load()
+	if last == 0 || height <= last+1 {
+		return nil
+	}
+
+	log.Warnw("fetcher: detected gap in new blocks, refilling", "from", last+1, "to", height-1)
+	f.metrics.observeGap(ctx, height-last-1)
+	for missing := last + 1; missing < height; missing++ {
+		block, err := f.getSignedBlockEvent(ctx, missing)
+		if err != nil {
+			return fmt.Errorf("fetching missing block at height %d: %w", missing, err)
+		}
+		if !f.deliver(ctx, out, block) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// deliver sends the block to out, unless it was delivered already, and reports whether the
+// subscription is still active.
+func (f *BlockFetcher) deliver(
+	ctx context.Context,
+	out chan<- types.EventDataSignedBlock,
+	block types.EventDataSignedBlock,
+) bool {
+	if block.Header.Height <= f.lastHeight.Load() {
+		// duplicate, e.g. the block was already fetched while filling a gap
+		return true
+	}
+
+	select {
+	case out <- block:
+		f.lastHeight.Store(block.Header.Height)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (f *BlockFetcher) getSignedBlockEvent(ctx context.Context, height int64) (types.EventDataSignedBlock, error) {
+	res, err := f.GetSignedBlock(ctx, &height)
+	if err != nil {
+		return types.EventDataSignedBlock{}, err
+	}
+	return types.EventDataSignedBlock{
+		Header:       res.Header,
+		Commit:       res.Commit,
+		ValidatorSet: res.ValidatorSet,
+		Data:         res.Data,
+	}, nil
+}
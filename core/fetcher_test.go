@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -37,3 +38,77 @@ func TestBlockFetcher_GetBlock_and_SubscribeNewBlockEvent(t *testing.T) {
 	}
 	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
 }
+
+func TestBlockFetcher_Failover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	t.Cleanup(cancel)
+
+	cfg := DefaultTestConfig()
+	StartTestNodeWithConfig(t, cfg)
+	ip, port, err := getEndpoint(cfg.TmConfig)
+	require.NoError(t, err)
+	failover, err := NewRemote(ip, port)
+	require.NoError(t, err)
+	// nothing listens on the primary endpoint
+	primary, err := NewRemote("127.0.0.1", "1")
+	require.NoError(t, err)
+
+	fetcher := NewBlockFetcher(primary,
+		WithFailoverClients(failover),
+		WithHealthCheck(100*time.Millisecond, defaultMaxHeightLag),
+	)
+	var notified atomic.Int32
+	fetcher.OnFailover(func(endpoint int) {
+		notified.Store(int32(endpoint))
+	})
+	require.NoError(t, fetcher.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, fetcher.Stop(ctx))
+	})
+
+	require.Eventually(t, func() bool {
+		return fetcher.active.Load() == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Equal(t, 1, fetcher.ActiveEndpoint())
+	require.EqualValues(t, 1, notified.Load())
+
+	sub, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	select {
+	case b := <-sub:
+		_, err = fetcher.GetSignedBlock(ctx, &b.Header.Height)
+		require.NoError(t, err)
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err())
+	}
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+}
+
+func TestBlockFetcher_BlockPolling(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	t.Cleanup(cancel)
+
+	client := StartTestNode(t).Client
+	fetcher := NewBlockFetcher(client, WithBlockPolling(50*time.Millisecond))
+
+	sub, err := fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	first := <-sub
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+
+	// let a few blocks pass while unsubscribed
+	time.Sleep(time.Second)
+
+	// the subscription resumes from the last delivered height, so no block is skipped
+	sub, err = fetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+	for expected := first.Header.Height + 1; expected < first.Header.Height+4; expected++ {
+		select {
+		case b := <-sub:
+			require.Equal(t, expected, b.Header.Height)
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+	}
+	require.NoError(t, fetcher.UnsubscribeNewBlockEvent(ctx))
+}
@@ -50,6 +50,7 @@ type Listener struct {
 	chainID string
 
 	listenerTimeout time.Duration
+	pipelineDepth   int
 	cancel          context.CancelFunc
 }
 
@@ -86,6 +87,7 @@ func NewListener(
 		store:              store,
 		availabilityWindow: p.availabilityWindow,
 		listenerTimeout:    5 * blocktime,
+		pipelineDepth:      p.pipelineDepth,
 		metrics:            metrics,
 		chainID:            p.chainID,
 	}, nil
@@ -170,6 +172,11 @@ func (cl *Listener) resubscribe(ctx context.Context) <-chan types.EventDataSigne
 // gossipsub network.
 func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSignedBlock) error {
 	defer log.Info("listener: listening stopped")
+	// blocks already received are broadcasted before listen returns, so that headers are broadcasted
+	// in order even after resubscription
+	pipe := cl.startPipeline(ctx)
+	defer pipe.close()
+
 	timeout := time.NewTimer(cl.listenerTimeout)
 	defer timeout.Stop()
 	for {
@@ -187,12 +194,8 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSigned
 
 			log.Debugw("listener: new block from core", "height", b.Header.Height)
 
-			err := cl.handleNewSignedBlock(ctx, b)
-			if err != nil {
-				log.Errorw("listener: handling new block msg",
-					"height", b.Header.Height,
-					"hash", b.Header.Hash().String(),
-					"err", err)
+			if err := pipe.push(ctx, b); err != nil {
+				return err
 			}
 
 			if !timeout.Stop() {
@@ -208,7 +211,12 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSigned
 	}
 }
 
-func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataSignedBlock) error {
+// processBlock extends the block, constructs the ExtendedHeader out of it and stores the EDS. It
+// also returns the filter of namespaces present in the block to be announced with the data hash.
+func (cl *Listener) processBlock(
+	ctx context.Context,
+	b types.EventDataSignedBlock,
+) (*header.ExtendedHeader, shrexsub.NamespaceFilter, error) {
 	ctx, span := tracer.Start(ctx, "handle-new-signed-block")
 	defer span.End()
 	span.SetAttributes(
@@ -218,10 +226,12 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 	adder := ipld.NewProofsAdder(int(b.Data.SquareSize))
 	defer adder.Purge()
 
+	tnow := time.Now()
 	eds, err := extendBlock(b.Data, b.Header.Version.App, nmt.NodeVisitor(adder.VisitFn()))
 	if err != nil {
-		return fmt.Errorf("extending block data: %w", err)
+		return nil, nil, fmt.Errorf("extending block data: %w", err)
 	}
+	cl.metrics.observeStage(ctx, stageExtend, time.Since(tnow))
 
 	// generate extended header
 	eh, err := cl.construct(&b.Header, &b.Commit, &b.ValidatorSet, eds)
@@ -229,10 +239,31 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 		panic(fmt.Errorf("making extended header: %w", err))
 	}
 
+	tnow = time.Now()
 	err = storeEDS(ctx, eh, eds, adder, cl.store, cl.availabilityWindow)
 	if err != nil {
-		return fmt.Errorf("storing EDS: %w", err)
+		return nil, nil, fmt.Errorf("storing EDS: %w", err)
 	}
+	cl.metrics.observeStage(ctx, stageStore, time.Since(tnow))
+	return eh, shrexsub.NewNamespaceFilterFromEDS(eds), nil
+}
+
+// broadcastHeader notifies the network about the new EDS and broadcasts the ExtendedHeader.
+func (cl *Listener) broadcastHeader(
+	ctx context.Context,
+	eh *header.ExtendedHeader,
+	namespaces shrexsub.NamespaceFilter,
+) error {
+	ctx, span := tracer.Start(ctx, "broadcast-new-header")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int64("height", int64(eh.Height())),
+	)
+
+	tnow := time.Now()
+	defer func() {
+		cl.metrics.observeStage(ctx, stageBroadcast, time.Since(tnow))
+	}()
 
 	syncing, err := cl.fetcher.IsSyncing(ctx)
 	if err != nil {
@@ -242,13 +273,14 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 	// notify network of new EDS hash only if core is already synced
 	if !syncing {
 		err = cl.hashBroadcaster(ctx, shrexsub.Notification{
-			DataHash: eh.DataHash.Bytes(),
-			Height:   eh.Height(),
+			DataHash:   eh.DataHash.Bytes(),
+			Height:     eh.Height(),
+			Namespaces: namespaces,
 		})
 		if err != nil && !errors.Is(err, context.Canceled) {
 			log.Errorw("listener: broadcasting data hash",
-				"height", b.Header.Height,
-				"hash", b.Header.Hash(), "err", err) // TODO: hash or datahash?
+				"height", eh.Height(),
+				"hash", eh.Hash(), "err", err) // TODO: hash or datahash?
 		}
 	}
 
@@ -256,7 +288,7 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 	err = cl.headerBroadcaster.Broadcast(ctx, eh, pubsub.WithLocalPublication(syncing))
 	if err != nil && !errors.Is(err, context.Canceled) {
 		log.Errorw("listener: broadcasting next header",
-			"height", b.Header.Height,
+			"height", eh.Height(),
 			"err", err)
 	}
 	return nil
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
 	"github.com/celestiaorg/celestia-node/libs/utils"
@@ -12,12 +13,23 @@ import (
 
 var meter = otel.Meter("core")
 
+const (
+	stageKey = "stage"
+
+	stageExtend    = "extend"
+	stageStore     = "store"
+	stageBroadcast = "broadcast"
+)
+
 type listenerMetrics struct {
 	lastTimeSubscriptionStuck     time.Time
 	lastTimeSubscriptionStuckInst metric.Int64ObservableGauge
 	lastTimeSubscriptionStuckReg  metric.Registration
 
 	subscriptionStuckInst metric.Int64Counter
+
+	stageTimeInst     metric.Float64Histogram
+	pipelineDepthInst metric.Int64Histogram
 }
 
 func newListenerMetrics() (*listenerMetrics, error) {
@@ -32,6 +44,22 @@ func newListenerMetrics() (*listenerMetrics, error) {
 		return nil, err
 	}
 
+	m.stageTimeInst, err = meter.Float64Histogram(
+		"core_listener_stage_time_histogram",
+		metric.WithDescription("time taken by each stage of new block handling in core listener"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.pipelineDepthInst, err = meter.Int64Histogram(
+		"core_listener_pipeline_depth_histogram",
+		metric.WithDescription("amount of blocks in core listener pipeline awaiting broadcast"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	m.lastTimeSubscriptionStuckInst, err = meter.Int64ObservableGauge(
 		"core_listener_last_time_subscription_stuck_timestamp",
 		metric.WithDescription("last time the listener subscription was stuck"),
@@ -67,6 +95,19 @@ func (m *listenerMetrics) subscriptionStuck(ctx context.Context) {
 	})
 }
 
+func (m *listenerMetrics) observeStage(ctx context.Context, stage string, dur time.Duration) {
+	m.observe(ctx, func(ctx context.Context) {
+		m.stageTimeInst.Record(ctx, dur.Seconds(), metric.WithAttributes(
+			attribute.String(stageKey, stage)))
+	})
+}
+
+func (m *listenerMetrics) observePipelineDepth(ctx context.Context, depth int) {
+	m.observe(ctx, func(ctx context.Context) {
+		m.pipelineDepthInst.Record(ctx, int64(depth))
+	})
+}
+
 func (m *listenerMetrics) observeLastTimeStuckCallback(_ context.Context, obs metric.Observer) error {
 	obs.ObserveInt64(m.lastTimeSubscriptionStuckInst, m.lastTimeSubscriptionStuck.Unix())
 	return nil
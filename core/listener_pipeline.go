@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
+)
+
+// defaultPipelineDepth allows extending and storing the next block while the previous one is
+// being broadcasted.
+const defaultPipelineDepth = 2
+
+// pipeline extends and stores blocks received from Core concurrently, while broadcasting their
+// headers and data hashes strictly in the order the blocks were received.
+type pipeline struct {
+	cl *Listener
+	// queue holds results of blocks being processed in the order they were pushed. Its capacity
+	// bounds the amount of blocks processed ahead of the broadcasted one.
+	queue chan chan processedBlock
+	done  chan struct{}
+}
+
+type processedBlock struct {
+	block      types.EventDataSignedBlock
+	eh         *header.ExtendedHeader
+	namespaces shrexsub.NamespaceFilter
+	err        error
+}
+
+// startPipeline creates a new pipeline and starts its broadcasting loop.
+func (cl *Listener) startPipeline(ctx context.Context) *pipeline {
+	p := &pipeline{
+		cl:    cl,
+		queue: make(chan chan processedBlock, cl.pipelineDepth),
+		done:  make(chan struct{}),
+	}
+	go p.broadcastLoop(ctx)
+	return p
+}
+
+// push starts processing of the block. It blocks while the pipeline is full.
+func (p *pipeline) push(ctx context.Context, b types.EventDataSignedBlock) error {
+	res := make(chan processedBlock, 1)
+	select {
+	case p.queue <- res:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	p.cl.metrics.observePipelineDepth(ctx, len(p.queue))
+
+	go func() {
+		eh, namespaces, err := p.cl.processBlock(ctx, b)
+		res <- processedBlock{block: b, eh: eh, namespaces: namespaces, err: err}
+	}()
+	return nil
+}
+
+// close waits until all the pushed blocks are broadcasted, or the context is done.
+func (p *pipeline) close() {
+	close(p.queue)
+	<-p.done
+}
+
+func (p *pipeline) broadcastLoop(ctx context.Context) {
+	defer close(p.done)
+	for res := range p.queue {
+		var pb processedBlock
+		select {
+		case pb = <-res:
+		case <-ctx.Done():
+			return
+		}
+
+		if pb.err == nil {
+			pb.err = p.cl.broadcastHeader(ctx, pb.eh, pb.namespaces)
+		}
+		if pb.err != nil {
+			log.Errorw("listener: handling new block msg",
+				"height", pb.block.Header.Height,
+				"hash", pb.block.Header.Hash().String(),
+				"err", pb.err)
+		}
+	}
+}
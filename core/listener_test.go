@@ -71,6 +71,50 @@ func TestListener(t *testing.T) {
 	require.Nil(t, cl.cancel)
 }
 
+// TestListener_PipelineOrder ensures headers are broadcasted in order of heights, even though
+// blocks are processed concurrently.
+func TestListener_PipelineOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	ps0, ps1 := createMocknetWithTwoPubsubEndpoints(ctx, t)
+	subscriber, err := p2p.NewSubscriber[*header.ExtendedHeader](
+		ps1,
+		header.MsgID,
+		p2p.WithSubscriberNetworkID(testChainID),
+	)
+	require.NoError(t, err)
+	err = subscriber.SetVerifier(func(context.Context, *header.ExtendedHeader) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, subscriber.Start(ctx))
+	subs, err := subscriber.Subscribe()
+	require.NoError(t, err)
+	t.Cleanup(subs.Cancel)
+
+	cfg := DefaultTestConfig()
+	cfg.ChainID = testChainID
+	fetcher, _ := createCoreFetcher(t, cfg)
+	eds := createEdsPubSub(ctx, t)
+
+	cl := createListener(ctx, t, fetcher, ps0, eds, createStore(t), testChainID, WithPipelineDepth(8))
+	require.NoError(t, cl.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, cl.Stop(ctx))
+	})
+
+	var prev uint64
+	for i := 0; i < 5; i++ {
+		h, err := subs.NextHeader(ctx)
+		require.NoError(t, err)
+		if prev != 0 {
+			require.Equal(t, prev+1, h.Height())
+		}
+		prev = h.Height()
+	}
+}
+
 func TestListenerWithWrongChainRPC(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	t.Cleanup(cancel)
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/pruner"
 	"github.com/celestiaorg/celestia-node/pruner/archival"
@@ -12,11 +14,25 @@ type params struct {
 	metrics            bool
 	chainID            string
 	availabilityWindow pruner.AvailabilityWindow
+	pipelineDepth      int
+
+	failoverClients     []Client
+	healthCheckInterval time.Duration
+	maxHeightLag        uint64
+	pollInterval        time.Duration
+
+	backfillWorkers int
 }
 
 func defaultParams() params {
 	return params{
 		availabilityWindow: archival.Window,
+		pipelineDepth:      defaultPipelineDepth,
+
+		healthCheckInterval: defaultHealthCheckInterval,
+		maxHeightLag:        defaultMaxHeightLag,
+
+		backfillWorkers: defaultBackfillWorkers,
 	}
 }
 
@@ -39,3 +55,51 @@ func WithAvailabilityWindow(window pruner.AvailabilityWindow) Option {
 		p.availabilityWindow = window
 	}
 }
+
+// WithPipelineDepth sets the amount of blocks the Listener extends and stores ahead of the block
+// being broadcasted. Values below 1 are ignored.
+func WithPipelineDepth(depth int) Option {
+	return func(p *params) {
+		if depth > 0 {
+			p.pipelineDepth = depth
+		}
+	}
+}
+
+// WithFailoverClients sets the Clients the BlockFetcher fails over to, in the order of priority,
+// once the primary Client becomes unhealthy. The BlockFetcher manages the lifecycle of the given
+// Clients.
+func WithFailoverClients(clients ...Client) Option {
+	return func(p *params) {
+		p.failoverClients = clients
+	}
+}
+
+// WithHealthCheck configures how often the BlockFetcher checks the health of its Clients and how
+// many blocks a Client may lag behind the others while still considered healthy.
+func WithHealthCheck(interval time.Duration, maxHeightLag uint64) Option {
+	return func(p *params) {
+		if interval > 0 {
+			p.healthCheckInterval = interval
+		}
+		p.maxHeightLag = maxHeightLag
+	}
+}
+
+// WithBackfillWorkers sets the amount of blocks the Backfiller fetches and stores concurrently.
+// Values below 1 are ignored.
+func WithBackfillWorkers(workers int) Option {
+	return func(p *params) {
+		if workers > 0 {
+			p.backfillWorkers = workers
+		}
+	}
+}
+
+// WithBlockPolling makes the BlockFetcher pull new blocks by height every given interval instead
+// of subscribing to new block events pushed by Core. Zero interval keeps the event subscription.
+func WithBlockPolling(interval time.Duration) Option {
+	return func(p *params) {
+		p.pollInterval = interval
+	}
+}
@@ -0,0 +1,497 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/protoio"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// maxRecordedMsgSize limits the size of a single recorded message, with block data being the
+	// largest one.
+	maxRecordedMsgSize = 128 << 20
+	// defaultReplayCapacity is the default capacity of new block event subscriptions.
+	defaultReplayCapacity = 16
+)
+
+var (
+	errNotReplayed = errors.New("core/replay: height is not replayed yet")
+	// errReplayUnsupported is returned by the Client methods a recording has no data for.
+	errReplayUnsupported = errors.New("core/replay: not supported by the replay client")
+)
+
+var _ Client = (*ReplayClient)(nil)
+
+// BlockRecorder writes signed blocks into a recording, which can be replayed by ReplayClient.
+// A recording is a sequence of length-delimited protobuf messages, holding the header, the data,
+// the commit and the validator set of every block in order of heights.
+type BlockRecorder struct {
+	w protoio.WriteCloser
+}
+
+// NewBlockRecorder creates a new BlockRecorder writing into the given Writer.
+func NewBlockRecorder(w io.Writer) *BlockRecorder {
+	return &BlockRecorder{w: protoio.NewDelimitedWriter(w)}
+}
+
+// Record appends the signed block to the recording.
+func (r *BlockRecorder) Record(b *coretypes.ResultSignedBlock) error {
+	header := b.Header.ToProto()
+	data := b.Data.ToProto()
+	valSet, err := b.ValidatorSet.ToProto()
+	if err != nil {
+		return fmt.Errorf("core/replay: encoding validator set: %w", err)
+	}
+	for _, msg := range []proto.Message{header, &data, b.Commit.ToProto(), valSet} {
+		if _, err := r.w.WriteMsg(msg); err != nil {
+			return fmt.Errorf("core/replay: writing block at height %d: %w", b.Header.Height, err)
+		}
+	}
+	return nil
+}
+
+// recordedBlock locates a block in the recording.
+type recordedBlock struct {
+	header types.Header
+	// offset and size of the block's messages in the recording
+	offset, size int64
+}
+
+// ReplayClient is a Client replaying blocks from a recording made with BlockRecorder, as if they
+// were produced by a Core node. Blocks become available one by one, with the timing configured by
+// ReplayOptions, and are published to new block event subscribers.
+//
+// The methods the recording has no data for, e.g. transactions and ABCI queries, return an error.
+type ReplayClient struct {
+	file   *os.File
+	blocks []recordedBlock
+	// heights maps block hashes to indices in blocks
+	heights map[string]int
+
+	speed    float64
+	interval time.Duration
+
+	lk sync.Mutex
+	// head is the index of the latest replayed block
+	head        int
+	subscribers map[replaySubscription]chan coretypes.ResultEvent
+	running     bool
+	cancel      context.CancelFunc
+	done        chan struct{}
+	// quit is closed once the client is stopped
+	quit chan struct{}
+}
+
+// replaySubscription identifies a new block event subscription.
+type replaySubscription struct {
+	subscriber, query string
+}
+
+// ReplayOption configures the timing of ReplayClient.
+type ReplayOption func(*ReplayClient)
+
+// WithReplaySpeed replays blocks with the recorded block times, sped up by the given factor.
+func WithReplaySpeed(factor float64) ReplayOption {
+	return func(c *ReplayClient) {
+		c.speed, c.interval = factor, -1
+	}
+}
+
+// WithReplayInterval replays a new block every given interval, ignoring the recorded block times.
+// Zero interval replays blocks as fast as they are consumed.
+func WithReplayInterval(interval time.Duration) ReplayOption {
+	return func(c *ReplayClient) {
+		c.interval = interval
+	}
+}
+
+// NewReplayClient opens the recording at the given path. By default, blocks are replayed with the
+// recorded block times.
+func NewReplayClient(path string, opts ...ReplayOption) (*ReplayClient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("core/replay: opening recording: %w", err)
+	}
+
+	c := &ReplayClient{
+		file:        f,
+		heights:     make(map[string]int),
+		speed:       1,
+		interval:    -1,
+		subscribers: make(map[replaySubscription]chan coretypes.ResultEvent),
+		quit:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.speed <= 0 {
+		return nil, errors.Join(fmt.Errorf("core/replay: invalid replay speed %f", c.speed), f.Close())
+	}
+
+	if err := c.index(); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	if len(c.blocks) == 0 {
+		return nil, errors.Join(errors.New("core/replay: empty recording"), f.Close())
+	}
+	return c, nil
+}
+
+// index scans the recording for block positions and headers.
+func (c *ReplayClient) index() error {
+	r := protoio.NewDelimitedReader(c.file, maxRecordedMsgSize)
+	var offset int64
+	for {
+		var header tmproto.Header
+		n, err := r.ReadMsg(&header)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("core/replay: reading header at offset %d: %w", offset, err)
+		}
+		size := int64(n)
+		// skip the rest of the block's messages
+		for _, msg := range []interface {
+			Reset()
+			String() string
+			ProtoMessage()
+		}{&tmproto.Data{}, &tmproto.Commit{}, &tmproto.ValidatorSet{}} {
+			n, err := r.ReadMsg(msg)
+			if err != nil {
+				return fmt.Errorf("core/replay: reading block at height %d: %w", header.Height, err)
+			}
+			size += int64(n)
+		}
+
+		h, err := types.HeaderFromProto(&header)
+		if err != nil {
+			return fmt.Errorf("core/replay: decoding header at height %d: %w", header.Height, err)
+		}
+		if len(c.blocks) > 0 && h.Height != c.blocks[len(c.blocks)-1].header.Height+1 {
+			return fmt.Errorf("core/replay: recording is not contiguous at height %d", h.Height)
+		}
+		c.heights[string(h.Hash())] = len(c.blocks)
+		c.blocks = append(c.blocks, recordedBlock{header: h, offset: offset, size: size})
+		offset += size
+	}
+}
+
+// Start starts replaying blocks, with the first recorded block being the head.
+func (c *ReplayClient) Start() error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if c.running {
+		return errors.New("core/replay: already started")
+	}
+	select {
+	case <-c.quit:
+		return errors.New("core/replay: already stopped")
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.running, c.cancel, c.done = true, cancel, make(chan struct{})
+	go c.replay(ctx)
+	return nil
+}
+
+// Stop stops replaying blocks and closes the recording.
+func (c *ReplayClient) Stop() error {
+	c.lk.Lock()
+	if !c.running {
+		c.lk.Unlock()
+		return errors.New("core/replay: not started")
+	}
+	c.running = false
+	c.cancel()
+	close(c.quit)
+	c.lk.Unlock()
+
+	<-c.done
+	return c.file.Close()
+}
+
+func (c *ReplayClient) IsRunning() bool {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.running
+}
+
+// replay advances the head until the last recorded block.
+func (c *ReplayClient) replay(ctx context.Context) {
+	defer close(c.done)
+	for next := 1; next < len(c.blocks); next++ {
+		delay := c.interval
+		if delay < 0 {
+			recorded := c.blocks[next].header.Time.Sub(c.blocks[next-1].header.Time)
+			delay = time.Duration(float64(recorded) / c.speed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		block, err := c.signedBlock(next)
+		if err != nil {
+			log.Errorw("core/replay: reading block, stopping replay", "err", err)
+			return
+		}
+
+		c.lk.Lock()
+		c.head = next
+		subscribers := make([]chan coretypes.ResultEvent, 0, len(c.subscribers))
+		for _, sub := range c.subscribers {
+			subscribers = append(subscribers, sub)
+		}
+		c.lk.Unlock()
+
+		event := coretypes.ResultEvent{
+			Query: newDataSignedBlockQuery,
+			Data: types.EventDataSignedBlock{
+				Header:       block.Header,
+				Commit:       block.Commit,
+				ValidatorSet: block.ValidatorSet,
+				Data:         block.Data,
+			},
+		}
+		for _, sub := range subscribers {
+			// like Core, drop events for slow subscribers
+			select {
+			case sub <- event:
+			default:
+				log.Warnw("core/replay: dropping new block event for slow subscriber", "height", block.Header.Height)
+			}
+		}
+	}
+	log.Info("core/replay: replayed all the recorded blocks")
+}
+
+// signedBlock reads the block at the given index from the recording.
+func (c *ReplayClient) signedBlock(idx int) (*coretypes.ResultSignedBlock, error) {
+	rec := c.blocks[idx]
+	r := protoio.NewDelimitedReader(io.NewSectionReader(c.file, rec.offset, rec.size), maxRecordedMsgSize)
+
+	var (
+		header tmproto.Header
+		data   tmproto.Data
+		commit tmproto.Commit
+		valSet tmproto.ValidatorSet
+	)
+	for _, msg := range []proto.Message{&header, &data, &commit, &valSet} {
+		if _, err := r.ReadMsg(msg); err != nil {
+			return nil, fmt.Errorf("reading block at height %d: %w", rec.header.Height, err)
+		}
+	}
+
+	d, err := types.DataFromProto(&data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding data at height %d: %w", rec.header.Height, err)
+	}
+	comm, err := types.CommitFromProto(&commit)
+	if err != nil {
+		return nil, fmt.Errorf("decoding commit at height %d: %w", rec.header.Height, err)
+	}
+	vals, err := types.ValidatorSetFromProto(&valSet)
+	if err != nil {
+		return nil, fmt.Errorf("decoding validator set at height %d: %w", rec.header.Height, err)
+	}
+	return &coretypes.ResultSignedBlock{
+		Header:       rec.header,
+		Commit:       *comm,
+		Data:         d,
+		ValidatorSet: *vals,
+	}, nil
+}
+
+// resolve returns the index of the block at the given height, or of the head if height is nil.
+func (c *ReplayClient) resolve(height *int64) (int, error) {
+	c.lk.Lock()
+	head := c.head
+	c.lk.Unlock()
+	if height == nil {
+		return head, nil
+	}
+
+	idx := int(*height - c.blocks[0].header.Height)
+	switch {
+	case idx < 0:
+		return 0, fmt.Errorf("core/replay: height %d is below the first recorded height %d",
+			*height, c.blocks[0].header.Height)
+	case idx > head:
+		return 0, fmt.Errorf("%w: %d", errNotReplayed, *height)
+	}
+	return idx, nil
+}
+
+func (c *ReplayClient) SignedBlock(_ context.Context, height *int64) (*coretypes.ResultSignedBlock, error) {
+	idx, err := c.resolve(height)
+	if err != nil {
+		return nil, err
+	}
+	return c.signedBlock(idx)
+}
+
+func (c *ReplayClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	idx, err := c.resolve(height)
+	if err != nil {
+		return nil, err
+	}
+	return c.block(idx)
+}
+
+func (c *ReplayClient) BlockByHash(_ context.Context, hash []byte) (*coretypes.ResultBlock, error) {
+	idx, ok := c.heights[string(hash)]
+	if !ok {
+		return nil, fmt.Errorf("core/replay: block %X is not recorded", hash)
+	}
+	height := c.blocks[idx].header.Height
+	if _, err := c.resolve(&height); err != nil {
+		return nil, err
+	}
+	return c.block(idx)
+}
+
+func (c *ReplayClient) block(idx int) (*coretypes.ResultBlock, error) {
+	b, err := c.signedBlock(idx)
+	if err != nil {
+		return nil, err
+	}
+	// the commit for the previous height is known only if it is recorded as well
+	lastCommit := &types.Commit{}
+	if idx > 0 {
+		prev, err := c.signedBlock(idx - 1)
+		if err != nil {
+			return nil, err
+		}
+		lastCommit = &prev.Commit
+	}
+
+	block := &types.Block{Header: b.Header, Data: b.Data, LastCommit: lastCommit}
+	return &coretypes.ResultBlock{
+		BlockID: types.BlockID{Hash: block.Hash()},
+		Block:   block,
+	}, nil
+}
+
+func (c *ReplayClient) Commit(_ context.Context, height *int64) (*coretypes.ResultCommit, error) {
+	idx, err := c.resolve(height)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.signedBlock(idx)
+	if err != nil {
+		return nil, err
+	}
+	return coretypes.NewResultCommit(&b.Header, &b.Commit, true), nil
+}
+
+// Validators returns the whole validator set in a single page.
+func (c *ReplayClient) Validators(
+	_ context.Context,
+	height *int64,
+	_, _ *int,
+) (*coretypes.ResultValidators, error) {
+	idx, err := c.resolve(height)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.signedBlock(idx)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultValidators{
+		BlockHeight: b.Header.Height,
+		Validators:  b.ValidatorSet.Validators,
+		Count:       len(b.ValidatorSet.Validators),
+		Total:       len(b.ValidatorSet.Validators),
+	}, nil
+}
+
+func (c *ReplayClient) BlockchainInfo(
+	_ context.Context,
+	minHeight, maxHeight int64,
+) (*coretypes.ResultBlockchainInfo, error) {
+	head, err := c.resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	first := c.blocks[0].header.Height
+	minHeight = max(minHeight, first)
+	maxHeight = min(maxHeight, first+int64(head), minHeight+blockMetasBatch-1)
+
+	metas := make([]*types.BlockMeta, 0, max(maxHeight-minHeight+1, 0))
+	for height := maxHeight; height >= minHeight; height-- {
+		header := c.blocks[height-first].header
+		metas = append(metas, &types.BlockMeta{
+			BlockID: types.BlockID{Hash: header.Hash()},
+			Header:  header,
+		})
+	}
+	return &coretypes.ResultBlockchainInfo{
+		LastHeight: first + int64(head),
+		BlockMetas: metas,
+	}, nil
+}
+
+func (c *ReplayClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	head, err := c.resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	first, latest := c.blocks[0].header, c.blocks[head].header
+	return &coretypes.ResultStatus{
+		SyncInfo: coretypes.SyncInfo{
+			LatestBlockHash:     bytes.HexBytes(latest.Hash()),
+			LatestAppHash:       latest.AppHash,
+			LatestBlockHeight:   latest.Height,
+			LatestBlockTime:     latest.Time,
+			EarliestBlockHash:   bytes.HexBytes(first.Hash()),
+			EarliestAppHash:     first.AppHash,
+			EarliestBlockHeight: first.Height,
+			EarliestBlockTime:   first.Time,
+		},
+	}, nil
+}
+
+// Subscribe subscribes to new block events. Any query is treated as one for new signed blocks.
+func (c *ReplayClient) Subscribe(
+	_ context.Context,
+	subscriber, query string,
+	outCapacity ...int,
+) (<-chan coretypes.ResultEvent, error) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	key := replaySubscription{subscriber: subscriber, query: query}
+	if _, ok := c.subscribers[key]; ok {
+		return nil, fmt.Errorf("core/replay: %s already subscribed to %s", subscriber, query)
+	}
+	capacity := defaultReplayCapacity
+	if len(outCapacity) > 0 {
+		capacity = outCapacity[0]
+	}
+	sub := make(chan coretypes.ResultEvent, capacity)
+	c.subscribers[key] = sub
+	return sub, nil
+}
+
+func (c *ReplayClient) Unsubscribe(_ context.Context, subscriber, query string) error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	delete(c.subscribers, replaySubscription{subscriber: subscriber, query: query})
+	return nil
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestReplayClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	cfg := DefaultTestConfig()
+	fetcher, cctx := createCoreFetcher(t, cfg)
+	generateNonEmptyBlocks(t, ctx, fetcher, cfg, cctx)
+
+	latest, err := fetcher.LatestHeight(ctx)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "blocks")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	recorder := NewBlockRecorder(f)
+	for height := int64(1); height <= latest; height++ {
+		b, err := fetcher.GetSignedBlock(ctx, &height)
+		require.NoError(t, err)
+		require.NoError(t, recorder.Record(b))
+	}
+	require.NoError(t, f.Close())
+
+	client, err := NewReplayClient(path, WithReplayInterval(200*time.Millisecond))
+	require.NoError(t, err)
+	replayFetcher := NewBlockFetcher(client)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+	})
+
+	sub, err := replayFetcher.SubscribeNewBlockEvent(ctx)
+	require.NoError(t, err)
+
+	// replayed blocks produce the same headers as the recorded ones
+	for height := int64(2); height <= latest; height++ {
+		var replayed types.EventDataSignedBlock
+		select {
+		case replayed = <-sub:
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+		require.Equal(t, height, replayed.Header.Height)
+
+		comm, vals, err := replayFetcher.GetBlockInfo(ctx, &height)
+		require.NoError(t, err)
+		eds, err := extendBlock(replayed.Data, replayed.Header.Version.App)
+		require.NoError(t, err)
+		replayedEH, err := header.MakeExtendedHeader(&replayed.Header, comm, vals, eds)
+		require.NoError(t, err)
+
+		recorded, err := fetcher.GetSignedBlock(ctx, &height)
+		require.NoError(t, err)
+		require.Equal(t, recorded.Header.Hash(), replayedEH.RawHeader.Hash())
+		require.NoError(t, replayedEH.Validate())
+	}
+	require.NoError(t, replayFetcher.UnsubscribeNewBlockEvent(ctx))
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// This file completes the Client interface of ReplayClient with the methods that are either
+// trivial or that a recording has no data for.
+
+func (c *ReplayClient) OnStart() error { return nil }
+
+func (c *ReplayClient) OnStop() {}
+
+func (c *ReplayClient) Reset() error {
+	return errors.New("core/replay: can't reset")
+}
+
+func (c *ReplayClient) OnReset() error {
+	return errors.New("core/replay: can't reset")
+}
+
+// Quit returns a channel, which is closed once the client is stopped.
+func (c *ReplayClient) Quit() <-chan struct{} {
+	return c.quit
+}
+
+func (c *ReplayClient) String() string {
+	return fmt.Sprintf("ReplayClient(%s)", c.file.Name())
+}
+
+func (c *ReplayClient) SetLogger(tmlog.Logger) {}
+
+func (c *ReplayClient) Header(_ context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	idx, err := c.resolve(height)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultHeader{Header: &c.blocks[idx].header}, nil
+}
+
+func (c *ReplayClient) HeaderByHash(_ context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error) {
+	idx, ok := c.heights[string(hash)]
+	if !ok {
+		return nil, fmt.Errorf("core/replay: block %X is not recorded", hash)
+	}
+	height := c.blocks[idx].header.Height
+	if _, err := c.resolve(&height); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultHeader{Header: &c.blocks[idx].header}, nil
+}
+
+func (c *ReplayClient) UnsubscribeAll(_ context.Context, subscriber string) error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	for key := range c.subscribers {
+		if key.subscriber == subscriber {
+			delete(c.subscribers, key)
+		}
+	}
+	return nil
+}
+
+func (c *ReplayClient) Health(context.Context) (*coretypes.ResultHealth, error) {
+	return &coretypes.ResultHealth{}, nil
+}
+
+func (c *ReplayClient) ABCIInfo(context.Context) (*coretypes.ResultABCIInfo, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) ABCIQuery(context.Context, string, bytes.HexBytes) (*coretypes.ResultABCIQuery, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) ABCIQueryWithOptions(
+	context.Context,
+	string,
+	bytes.HexBytes,
+	client.ABCIQueryOptions,
+) (*coretypes.ResultABCIQuery, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BroadcastTxCommit(context.Context, types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BroadcastTxAsync(context.Context, types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BroadcastTxSync(context.Context, types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BlockResults(context.Context, *int64) (*coretypes.ResultBlockResults, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) DataCommitment(context.Context, uint64, uint64) (*coretypes.ResultDataCommitment, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) DataRootInclusionProof(
+	context.Context,
+	uint64,
+	uint64,
+	uint64,
+) (*coretypes.ResultDataRootInclusionProof, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) Tx(context.Context, []byte, bool) (*coretypes.ResultTx, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) ProveShares(context.Context, uint64, uint64, uint64) (types.ShareProof, error) {
+	return types.ShareProof{}, errReplayUnsupported
+}
+
+func (c *ReplayClient) ProveSharesV2(context.Context, uint64, uint64, uint64) (*coretypes.ResultShareProof, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) TxSearch(
+	context.Context,
+	string,
+	bool,
+	*int, *int,
+	string,
+) (*coretypes.ResultTxSearch, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BlockSearch(
+	context.Context,
+	string,
+	*int, *int,
+	string,
+) (*coretypes.ResultBlockSearch, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) Genesis(context.Context) (*coretypes.ResultGenesis, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) GenesisChunked(context.Context, uint) (*coretypes.ResultGenesisChunk, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) NetInfo(context.Context) (*coretypes.ResultNetInfo, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) DumpConsensusState(context.Context) (*coretypes.ResultDumpConsensusState, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) ConsensusState(context.Context) (*coretypes.ResultConsensusState, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) ConsensusParams(context.Context, *int64) (*coretypes.ResultConsensusParams, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) BroadcastEvidence(context.Context, types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) UnconfirmedTxs(context.Context, *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) NumUnconfirmedTxs(context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *ReplayClient) CheckTx(context.Context, types.Tx) (*coretypes.ResultCheckTx, error) {
+	return nil, errReplayUnsupported
+}
@@ -0,0 +1,178 @@
+package header
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	header_pb "github.com/celestiaorg/celestia-node/header/pb"
+)
+
+// The compact encoding is the protobuf encoding of the ExtendedHeader prefixed with a zero byte
+// and the encoding version. A zero byte can't start the legacy protobuf encoding, as zero is not a
+// valid field tag, so both encodings are told apart by the first byte.
+//
+// Version 1 allows omitting the ValidatorSet, which is then referenced by the ValidatorsHash of
+// the RawHeader only. Validator sets rarely change between heights, so that saves repeating them
+// for every header.
+const (
+	versionedPrefix   = 0x00
+	compactEncodingV1 = 0x01
+)
+
+// ErrUnknownValidatorSet is returned when an ExtendedHeader in the compact encoding references a
+// validator set that can't be resolved.
+var ErrUnknownValidatorSet = errors.New("header: unknown validator set")
+
+// ValidatorSetResolver returns the validator set with the given hash.
+type ValidatorSetResolver func(hash []byte) (*core.ValidatorSet, error)
+
+// Compact returns a shallow copy of the ExtendedHeader, which MarshalBinary encodes in the compact
+// encoding, omitting the ValidatorSet. Only decoders given a ValidatorSetResolver can decode it.
+func (eh *ExtendedHeader) Compact() *ExtendedHeader {
+	cp := *eh
+	cp.omitValidatorSet = true
+	return &cp
+}
+
+// MarshalCompact serializes the given ExtendedHeader to bytes in the compact encoding, omitting
+// the ValidatorSet if requested. Paired with UnmarshalExtendedHeaderWith.
+func MarshalCompact(in *ExtendedHeader, omitValidatorSet bool) ([]byte, error) {
+	out := &header_pb.ExtendedHeader{
+		Header: in.RawHeader.ToProto(),
+		Commit: in.Commit.ToProto(),
+	}
+
+	var err error
+	if !omitValidatorSet {
+		out.ValidatorSet, err = in.ValidatorSet.ToProto()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out.Dah, err = in.DAH.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 2+out.Size())
+	data[0], data[1] = versionedPrefix, compactEncodingV1
+	_, err = out.MarshalToSizedBuffer(data[2:])
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// IsCompact reports whether the given serialized ExtendedHeader is in the compact encoding.
+func IsCompact(data []byte) bool {
+	return len(data) > 0 && data[0] == versionedPrefix
+}
+
+// UnmarshalExtendedHeaderWith deserializes the given data in any encoding into a new
+// ExtendedHeader, resolving the omitted ValidatorSet with the given resolver. A nil resolver
+// resolves no validator sets.
+func UnmarshalExtendedHeaderWith(data []byte, resolve ValidatorSetResolver) (*ExtendedHeader, error) {
+	if !IsCompact(data) {
+		return unmarshalLegacy(data)
+	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("header: truncated compact encoding")
+	}
+	if data[1] != compactEncodingV1 {
+		return nil, fmt.Errorf("header: unsupported encoding version %d", data[1])
+	}
+
+	in := &header_pb.ExtendedHeader{}
+	err := in.Unmarshal(data[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ExtendedHeader{}
+	out.RawHeader, err = core.HeaderFromProto(in.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Commit, err = core.CommitFromProto(in.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case in.ValidatorSet != nil:
+		out.ValidatorSet, err = core.ValidatorSetFromProto(in.ValidatorSet)
+	case resolve != nil:
+		out.ValidatorSet, err = resolve(out.ValidatorsHash)
+	default:
+		err = fmt.Errorf("%w: %X", ErrUnknownValidatorSet, out.ValidatorsHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out.DAH, err = da.DataAvailabilityHeaderFromProto(in.Dah)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// validatorSetField is the protobuf key of the ValidatorSet field of the ExtendedHeader, i.e. the
+// field number 3 with the length-delimited wire type.
+const validatorSetField = 3<<3 | 2
+
+// SplitValidatorSet converts the given serialized ExtendedHeader in any encoding into the compact
+// encoding without the ValidatorSet. The ValidatorSet is returned serialized separately, along
+// with its hash, unless it was omitted already.
+func SplitValidatorSet(data []byte) (compact, valsHash, valset []byte, err error) {
+	in := &header_pb.ExtendedHeader{}
+	if IsCompact(data) {
+		if len(data) < 2 || data[1] != compactEncodingV1 {
+			return nil, nil, nil, fmt.Errorf("header: unsupported compact encoding")
+		}
+		err = in.Unmarshal(data[2:])
+	} else {
+		err = in.Unmarshal(data)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if in.Header == nil {
+		return nil, nil, nil, fmt.Errorf("header: missing raw header")
+	}
+
+	if in.ValidatorSet != nil {
+		valset, err = in.ValidatorSet.Marshal()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		in.ValidatorSet = nil
+	}
+
+	compact = make([]byte, 2+in.Size())
+	compact[0], compact[1] = versionedPrefix, compactEncodingV1
+	_, err = in.MarshalToSizedBuffer(compact[2:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return compact, in.Header.ValidatorsHash, valset, nil
+}
+
+// JoinValidatorSet adds the serialized ValidatorSet returned by SplitValidatorSet back to the
+// ExtendedHeader in the compact encoding, so it can be decoded without resolving the ValidatorSet.
+func JoinValidatorSet(compact, valset []byte) []byte {
+	// protobuf fields can come in any order, so the ValidatorSet is simply appended
+	out := make([]byte, 0, len(compact)+binary.MaxVarintLen64+1+len(valset))
+	out = append(out, compact...)
+	out = append(out, validatorSetField)
+	out = binary.AppendUvarint(out, uint64(len(valset)))
+	return append(out, valset...)
+}
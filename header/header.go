@@ -38,6 +38,9 @@ type ExtendedHeader struct {
 	Commit       *core.Commit               `json:"commit"`
 	ValidatorSet *core.ValidatorSet         `json:"validator_set"`
 	DAH          *da.DataAvailabilityHeader `json:"dah"`
+
+	// omitValidatorSet makes MarshalBinary omit the ValidatorSet, see Compact.
+	omitValidatorSet bool
 }
 
 // MakeExtendedHeader assembles new ExtendedHeader.
@@ -208,6 +211,9 @@ func (eh *ExtendedHeader) Verify(untrst *ExtendedHeader) error {
 
 // MarshalBinary marshals ExtendedHeader to binary.
 func (eh *ExtendedHeader) MarshalBinary() ([]byte, error) {
+	if eh.omitValidatorSet {
+		return MarshalCompact(eh, true)
+	}
 	return MarshalExtendedHeader(eh)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/celestia-node/header"
 )
@@ -39,3 +40,53 @@ func equalExtendedHeader(t *testing.T, in, out *header.ExtendedHeader) {
 	assert.NotZero(t, out.RawHeader)
 	assert.NotNil(t, out.Commit)
 }
+
+func TestMarshalUnmarshalCompact(t *testing.T) {
+	suite := NewTestSuite(t, 3, 0)
+	headers := suite.GenExtendedHeaders(2)
+
+	// the omitted validator set is resolved only by the given resolver
+	full, err := headers[0].MarshalBinary()
+	require.NoError(t, err)
+	require.False(t, header.IsCompact(full))
+	compact, err := headers[1].Compact().MarshalBinary()
+	require.NoError(t, err)
+	require.True(t, header.IsCompact(compact))
+	require.Less(t, len(compact), len(full))
+
+	out := &header.ExtendedHeader{}
+	require.ErrorIs(t, out.UnmarshalBinary(compact), header.ErrUnknownValidatorSet)
+	_, err = header.UnmarshalExtendedHeaderWith(compact, func([]byte) (*types.ValidatorSet, error) {
+		return nil, header.ErrUnknownValidatorSet
+	})
+	require.ErrorIs(t, err, header.ErrUnknownValidatorSet)
+
+	out, err = header.UnmarshalExtendedHeaderWith(compact, func(hash []byte) (*types.ValidatorSet, error) {
+		require.EqualValues(t, headers[0].ValidatorSet.Hash(), hash)
+		return headers[0].ValidatorSet, nil
+	})
+	require.NoError(t, err)
+	equalExtendedHeader(t, headers[1], out)
+	require.NoError(t, out.Validate())
+	require.Equal(t, headers[1].Hash(), out.Hash())
+
+	// the validator set is split off and joined back
+	split, valsHash, valset, err := header.SplitValidatorSet(full)
+	require.NoError(t, err)
+	require.EqualValues(t, headers[0].ValidatorsHash, valsHash)
+	require.Equal(t, compact[:2], split[:2])
+	out, err = header.UnmarshalExtendedHeaderWith(header.JoinValidatorSet(split, valset), nil)
+	require.NoError(t, err)
+	equalExtendedHeader(t, headers[0], out)
+
+	// the compact encoding can carry the validator set as well
+	data, err := header.MarshalCompact(headers[0], false)
+	require.NoError(t, err)
+	out, err = header.UnmarshalExtendedHeaderWith(data, nil)
+	require.NoError(t, err)
+	equalExtendedHeader(t, headers[0], out)
+
+	data[1] = 0x02
+	_, err = header.UnmarshalExtendedHeaderWith(data, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	libhead "github.com/celestiaorg/go-header"
+)
+
+// FindByTime returns the last header at or before the given time among the headers in the
+// [from:to] height range of the getter. Headers are ordered by time, so it takes a logarithmic
+// number of lookups. Headers missing in the getter, e.g. pruned ones, are considered older than
+// the present ones.
+//
+// It returns libhead.ErrNotFound if there is no such header.
+func FindByTime(
+	ctx context.Context,
+	getter libhead.Getter[*ExtendedHeader],
+	from, to uint64,
+	t time.Time,
+) (*ExtendedHeader, error) {
+	if from == 0 || from > to {
+		return nil, fmt.Errorf("header: invalid height range [%d:%d]", from, to)
+	}
+
+	var err error
+	// the first header after the given time is found with binary search
+	i := sort.Search(int(to-from+1), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var h *ExtendedHeader
+		h, err = getter.GetByHeight(ctx, from+uint64(i))
+		if errors.Is(err, libhead.ErrNotFound) {
+			err = nil
+			return false
+		}
+		return err == nil && h.Time().After(t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if i == 0 {
+		return nil, libhead.ErrNotFound
+	}
+	return getter.GetByHeight(ctx, from+uint64(i-1))
+}
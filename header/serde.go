@@ -33,7 +33,15 @@ func MarshalExtendedHeader(in *ExtendedHeader) (_ []byte, err error) {
 
 // UnmarshalExtendedHeader deserializes given data into a new ExtendedHeader using protobuf.
 // Paired with MarshalExtendedHeader.
+//
+// Both the legacy and the compact encoding are supported. Headers in the compact encoding have to
+// carry their validator set, see UnmarshalExtendedHeaderWith otherwise.
 func UnmarshalExtendedHeader(data []byte) (*ExtendedHeader, error) {
+	return UnmarshalExtendedHeaderWith(data, nil)
+}
+
+// unmarshalLegacy deserializes the given data in the legacy encoding into a new ExtendedHeader.
+func unmarshalLegacy(data []byte) (*ExtendedHeader, error) {
 	in := &header_pb.ExtendedHeader{}
 	err := in.Unmarshal(data)
 	if err != nil {
@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
@@ -19,43 +20,92 @@ type Config struct {
 	IP       string
 	RPCPort  string
 	GRPCPort string
+	// FailoverEndpoints are Core nodes to fail over to, in the order of priority, once the primary
+	// one above becomes unhealthy. Only bridge nodes support them.
+	FailoverEndpoints []EndpointConfig
+	// HealthCheckInterval is how often the health of the Core endpoints is checked.
+	HealthCheckInterval time.Duration
+	// MaxHeightLag is the amount of blocks an endpoint may lag behind the others while still
+	// considered healthy.
+	MaxHeightLag uint64
+	// BlockPollInterval makes the node pull new blocks from Core by height every given interval,
+	// instead of relying on new block events pushed by Core. Zero keeps the event subscription.
+	BlockPollInterval time.Duration
+	// ReplayFile makes a bridge node replay blocks recorded with `cel-shed core record` from the
+	// given file instead of fetching them from a Core node. State access still requires IP.
+	ReplayFile string
+	// ReplayInterval is the interval between replayed blocks. Zero replays blocks with the recorded
+	// block times.
+	ReplayInterval time.Duration
+	// BackfillWorkers is the amount of blocks fetched and stored concurrently when backfilling EDSes
+	// missing within the availability window, e.g. after downtime. Zero disables the backfill.
+	BackfillWorkers int
+	// PipelineDepth is the amount of blocks a bridge node extends and stores ahead of the block
+	// being broadcasted. Zero keeps the default depth.
+	PipelineDepth int
+}
+
+// EndpointConfig describes a single Core endpoint.
+type EndpointConfig struct {
+	IP       string
+	RPCPort  string
+	GRPCPort string
 }
 
 // DefaultConfig returns default configuration for managing the
 // node's connection to a Celestia-Core endpoint.
 func DefaultConfig() Config {
 	return Config{
-		IP:       "",
-		RPCPort:  DefaultRPCPort,
-		GRPCPort: DefaultGRPCPort,
+		IP:                  "",
+		RPCPort:             DefaultRPCPort,
+		GRPCPort:            DefaultGRPCPort,
+		HealthCheckInterval: 10 * time.Second,
+		MaxHeightLag:        5,
+		BackfillWorkers:     16,
+		PipelineDepth:       2,
 	}
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
-	if !cfg.IsEndpointConfigured() {
-		return nil
+	if cfg.ReplayInterval < 0 {
+		return fmt.Errorf("nodebuilder/core: replay interval must not be negative")
 	}
-
-	if cfg.RPCPort == "" {
-		return fmt.Errorf("nodebuilder/core: rpc port is not set")
+	if cfg.IsReplaying() && len(cfg.FailoverEndpoints) != 0 {
+		return fmt.Errorf("nodebuilder/core: failover endpoints can't be used with a replay file")
 	}
-	if cfg.GRPCPort == "" {
-		return fmt.Errorf("nodebuilder/core: grpc port is not set")
+	if !cfg.IsEndpointConfigured() {
+		if len(cfg.FailoverEndpoints) != 0 {
+			return fmt.Errorf("nodebuilder/core: failover endpoints require the primary endpoint to be set")
+		}
+		return nil
 	}
 
-	ip, err := utils.ValidateAddr(cfg.IP)
+	ip, err := validateEndpoint(cfg.IP, cfg.RPCPort, cfg.GRPCPort)
 	if err != nil {
 		return err
 	}
 	cfg.IP = ip
-	_, err = strconv.Atoi(cfg.RPCPort)
-	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
+
+	for i := range cfg.FailoverEndpoints {
+		endpoint := &cfg.FailoverEndpoints[i]
+		ip, err := validateEndpoint(endpoint.IP, endpoint.RPCPort, endpoint.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("nodebuilder/core: failover endpoint %d: %w", i, err)
+		}
+		endpoint.IP = ip
 	}
-	_, err = strconv.Atoi(cfg.GRPCPort)
-	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+	if cfg.BlockPollInterval < 0 {
+		return fmt.Errorf("nodebuilder/core: block poll interval must not be negative")
+	}
+	if cfg.BackfillWorkers < 0 {
+		return fmt.Errorf("nodebuilder/core: backfill workers must not be negative")
+	}
+	if cfg.PipelineDepth < 0 {
+		return fmt.Errorf("nodebuilder/core: pipeline depth must not be negative")
+	}
+	if len(cfg.FailoverEndpoints) != 0 && cfg.HealthCheckInterval <= 0 {
+		return fmt.Errorf("nodebuilder/core: health check interval must be positive")
 	}
 	return nil
 }
@@ -65,3 +115,40 @@ func (cfg *Config) Validate() error {
 func (cfg *Config) IsEndpointConfigured() bool {
 	return cfg.IP != ""
 }
+
+// IsReplaying returns whether blocks are replayed from a recording instead of a Core node.
+func (cfg *Config) IsReplaying() bool {
+	return cfg.ReplayFile != ""
+}
+
+// FailoverGRPCEndpoints returns the gRPC addresses of the failover endpoints.
+func (cfg *Config) FailoverGRPCEndpoints() []string {
+	endpoints := make([]string, 0, len(cfg.FailoverEndpoints))
+	for _, endpoint := range cfg.FailoverEndpoints {
+		endpoints = append(endpoints, endpoint.IP+":"+endpoint.GRPCPort)
+	}
+	return endpoints
+}
+
+func validateEndpoint(ip, rpcPort, grpcPort string) (string, error) {
+	if rpcPort == "" {
+		return "", fmt.Errorf("nodebuilder/core: rpc port is not set")
+	}
+	if grpcPort == "" {
+		return "", fmt.Errorf("nodebuilder/core: grpc port is not set")
+	}
+
+	ip, err := utils.ValidateAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	_, err = strconv.Atoi(rpcPort)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
+	}
+	_, err = strconv.Atoi(grpcPort)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+	}
+	return ip, nil
+}
@@ -2,8 +2,12 @@ package core
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
 func TestValidate(t *testing.T) {
@@ -69,6 +73,75 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "valid failover endpoint",
+			cfg: Config{
+				IP:       "127.0.0.1",
+				RPCPort:  DefaultRPCPort,
+				GRPCPort: DefaultGRPCPort,
+				FailoverEndpoints: []EndpointConfig{
+					{IP: "127.0.0.2", RPCPort: DefaultRPCPort, GRPCPort: DefaultGRPCPort},
+				},
+				HealthCheckInterval: time.Second,
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid failover endpoint",
+			cfg: Config{
+				IP:       "127.0.0.1",
+				RPCPort:  DefaultRPCPort,
+				GRPCPort: DefaultGRPCPort,
+				FailoverEndpoints: []EndpointConfig{
+					{IP: "127.0.0.2", RPCPort: "invalid-port", GRPCPort: DefaultGRPCPort},
+				},
+				HealthCheckInterval: time.Second,
+			},
+			expectErr: true,
+		},
+		{
+			name: "failover endpoint without primary",
+			cfg: Config{
+				FailoverEndpoints: []EndpointConfig{
+					{IP: "127.0.0.2", RPCPort: DefaultRPCPort, GRPCPort: DefaultGRPCPort},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "replay without endpoint",
+			cfg:       Config{ReplayFile: "blocks", ReplayInterval: time.Second},
+			expectErr: false,
+		},
+		{
+			name:      "negative replay interval",
+			cfg:       Config{ReplayFile: "blocks", ReplayInterval: -time.Second},
+			expectErr: true,
+		},
+		{
+			name: "negative pipeline depth",
+			cfg: Config{
+				IP:            "127.0.0.1",
+				RPCPort:       DefaultRPCPort,
+				GRPCPort:      DefaultGRPCPort,
+				PipelineDepth: -1,
+			},
+			expectErr: true,
+		},
+		{
+			name: "replay with failover endpoints",
+			cfg: Config{
+				IP:                  "127.0.0.1",
+				RPCPort:             DefaultRPCPort,
+				GRPCPort:            DefaultGRPCPort,
+				ReplayFile:          "blocks",
+				HealthCheckInterval: time.Second,
+				FailoverEndpoints: []EndpointConfig{
+					{IP: "127.0.0.2", RPCPort: DefaultRPCPort, GRPCPort: DefaultGRPCPort},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -82,3 +155,21 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructModule_FailoverEndpoints(t *testing.T) {
+	cfg := Config{
+		IP:                  "127.0.0.1",
+		RPCPort:             DefaultRPCPort,
+		GRPCPort:            DefaultGRPCPort,
+		HealthCheckInterval: time.Second,
+		FailoverEndpoints: []EndpointConfig{
+			{IP: "127.0.0.2", RPCPort: DefaultRPCPort, GRPCPort: DefaultGRPCPort},
+		},
+	}
+
+	// only bridge nodes health check the endpoints to fail over
+	for _, tp := range []node.Type{node.Light, node.Full} {
+		err := fx.New(ConstructModule(tp, &cfg)).Err()
+		require.Error(t, err, tp.String())
+	}
+}
@@ -5,5 +5,42 @@ import (
 )
 
 func remote(cfg Config) (core.Client, error) {
+	if cfg.IsReplaying() {
+		opts := []core.ReplayOption{}
+		if cfg.ReplayInterval > 0 {
+			opts = append(opts, core.WithReplayInterval(cfg.ReplayInterval))
+		}
+		return core.NewReplayClient(cfg.ReplayFile, opts...)
+	}
 	return core.NewRemote(cfg.IP, cfg.RPCPort)
 }
+
+// failoverRemotes creates Clients for all the failover endpoints in the order of priority.
+func failoverRemotes(cfg Config) ([]core.Client, error) {
+	clients := make([]core.Client, 0, len(cfg.FailoverEndpoints))
+	for _, endpoint := range cfg.FailoverEndpoints {
+		client, err := core.NewRemote(endpoint.IP, endpoint.RPCPort)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func blockFetcher(cfg Config, client core.Client, opts []core.Option) (*core.BlockFetcher, error) {
+	failover, err := failoverRemotes(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts,
+		core.WithFailoverClients(failover...),
+		core.WithHealthCheck(cfg.HealthCheckInterval, cfg.MaxHeightLag),
+		core.WithBlockPolling(cfg.BlockPollInterval),
+	)
+	if MetricsEnabled {
+		opts = append(opts, core.WithMetrics())
+	}
+	return core.NewBlockFetcher(client, opts...), nil
+}
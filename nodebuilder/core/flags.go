@@ -11,6 +11,7 @@ var (
 	coreFlag     = "core.ip"
 	coreRPCFlag  = "core.rpc.port"
 	coreGRPCFlag = "core.grpc.port"
+	replayFlag   = "core.replay"
 )
 
 // Flags gives a set of hardcoded Core flags.
@@ -34,6 +35,12 @@ func Flags() *flag.FlagSet {
 		DefaultGRPCPort,
 		"Set a custom gRPC port for the core node connection. The --core.ip flag must also be provided.",
 	)
+	flags.String(
+		replayFlag,
+		"",
+		"Replays blocks recorded with `cel-shed core record` from the given file instead of fetching them "+
+			"from the core node. Bridge node only.",
+	)
 	return flags
 }
 
@@ -42,6 +49,10 @@ func ParseFlags(
 	cmd *cobra.Command,
 	cfg *Config,
 ) error {
+	if cmd.Flag(replayFlag).Changed {
+		cfg.ReplayFile = cmd.Flag(replayFlag).Value.String()
+	}
+
 	coreIP := cmd.Flag(coreFlag).Value.String()
 	if coreIP == "" {
 		if cmd.Flag(coreGRPCFlag).Changed || cmd.Flag(coreRPCFlag).Changed {
@@ -2,7 +2,9 @@ package core
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
 	libhead "github.com/celestiaorg/go-header"
@@ -21,6 +23,10 @@ import (
 func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
+	if cfgErr == nil && tp != node.Bridge && len(cfg.FailoverEndpoints) != 0 {
+		// the failover is driven by the health checks of the BlockFetcher, which only bridge nodes run
+		cfgErr = fmt.Errorf("nodebuilder/core: failover endpoints are only supported by bridge nodes")
+	}
 
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
@@ -34,7 +40,15 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 	case node.Bridge:
 		return fx.Module("core",
 			baseComponents,
-			fx.Provide(core.NewBlockFetcher),
+			fx.Provide(fx.Annotate(
+				blockFetcher,
+				fx.OnStart(func(ctx context.Context, fetcher *core.BlockFetcher) error {
+					return fetcher.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, fetcher *core.BlockFetcher) error {
+					return fetcher.Stop(ctx)
+				}),
+			)),
 			fxutil.ProvideAs(
 				func(
 					fetcher *core.BlockFetcher,
@@ -51,6 +65,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 				new(libhead.Exchange[*header.ExtendedHeader])),
 			fx.Invoke(fx.Annotate(
 				func(
+					cfg Config,
 					bcast libhead.Broadcaster[*header.ExtendedHeader],
 					fetcher *core.BlockFetcher,
 					pubsub *shrexsub.PubSub,
@@ -59,7 +74,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 					chainID p2p.Network,
 					opts []core.Option,
 				) (*core.Listener, error) {
-					opts = append(opts, core.WithChainID(chainID))
+					opts = append(opts, core.WithChainID(chainID), core.WithPipelineDepth(cfg.PipelineDepth))
 
 					if MetricsEnabled {
 						opts = append(opts, core.WithMetrics())
@@ -74,6 +89,33 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 					return listener.Stop(ctx)
 				}),
 			)),
+			fx.Invoke(fx.Annotate(
+				func(
+					cfg Config,
+					fetcher *core.BlockFetcher,
+					store *eds.Store,
+					construct header.ConstructFn,
+					ds datastore.Batching,
+					opts []core.Option,
+				) (*core.Backfiller, error) {
+					opts = append(opts, core.WithBackfillWorkers(cfg.BackfillWorkers))
+
+					if MetricsEnabled {
+						opts = append(opts, core.WithMetrics())
+					}
+
+					return core.NewBackfiller(fetcher, store, construct, ds, opts...)
+				},
+				fx.OnStart(func(ctx context.Context, cfg Config, backfiller *core.Backfiller) error {
+					if cfg.BackfillWorkers == 0 {
+						return nil
+					}
+					return backfiller.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, backfiller *core.Backfiller) error {
+					return backfiller.Stop(ctx)
+				}),
+			)),
 			fx.Provide(fx.Annotate(
 				remote,
 				fx.OnStart(func(_ context.Context, client core.Client) error {
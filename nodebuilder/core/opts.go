@@ -8,7 +8,8 @@ import (
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
 )
 
-// WithClient sets custom client for core process
+// WithClient sets custom client for core process, e.g. core.ReplayClient to run without a Core node.
+// The client has to be started by the caller.
 func WithClient(client core.Client) fx.Option {
 	return fxutil.ReplaceAs(client, new(core.Client))
 }
@@ -46,6 +46,7 @@ func newDASer(
 	fraudServ fraud.Service[*header.ExtendedHeader],
 	bFn shrexsub.BroadcastFn,
 	availWindow pruner.AvailabilityWindow,
+	halts *modfraud.HaltRecorder,
 	options ...das.Option,
 ) (*das.DASer, *modfraud.ServiceBreaker[*das.DASer, *header.ExtendedHeader], error) {
 	options = append(options, das.WithSamplingWindow(availWindow))
@@ -59,5 +60,6 @@ func newDASer(
 		Service:   ds,
 		FraudServ: fraudServ,
 		FraudType: byzantine.BadEncoding,
+		Halts:     halts,
 	}, nil
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/go-fraud"
+
+	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+)
+
+func init() {
+	Cmd.AddCommand(haltRecordCmd, submitCmd)
+}
+
+var Cmd = &cobra.Command{
+	Use:               "fraud [command]",
+	Short:             "Allows interaction with the Fraud Module via JSON-RPC",
+	Args:              cobra.NoArgs,
+	PersistentPreRunE: cmdnode.InitClient,
+}
+
+var haltRecordCmd = &cobra.Command{
+	Use: "halt-record",
+	Short: "Returns the record of the fraud proof that halted the node: the proof type, height, " +
+		"reporting peer and the services that were stopped.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		record, err := client.Fraud.HaltRecord(cmd.Context())
+		return cmdnode.PrintOutput(record, err, nil)
+	},
+}
+
+var submitCmd = &cobra.Command{
+	Use: "submit [proof-type] [file]",
+	Short: "Validates the binary encoded fraud proof of the given type, e.g. badencodingv0.1, from the file " +
+		"and broadcasts it to the network. A valid proof halts the node.",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		proof, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("error reading the proof: %w", err)
+		}
+
+		err = client.Fraud.Submit(cmd.Context(), fraud.ProofType(args[0]), proof)
+		return cmdnode.PrintOutput(nil, err, nil)
+	},
+}
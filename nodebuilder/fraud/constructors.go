@@ -30,6 +30,7 @@ func newFraudServiceWithSync(
 	registry fraud.ProofUnmarshaler[*header.ExtendedHeader],
 	ds datastore.Batching,
 	network p2p.Network,
+	halts *HaltRecorder,
 ) (Module, fraud.Service[*header.ExtendedHeader], error) {
 	syncerEnabled := true
 	headGetter := func(ctx context.Context) (*header.ExtendedHeader, error) {
@@ -42,7 +43,10 @@ func newFraudServiceWithSync(
 		OnStop:  pservice.Stop,
 	})
 	return &module{
-		Service: pservice,
+		Service:     pservice,
+		unmarshaler: registry,
+		getter:      hstore,
+		halts:       halts,
 	}, pservice, nil
 }
 
@@ -55,6 +59,7 @@ func newFraudServiceWithoutSync(
 	registry fraud.ProofUnmarshaler[*header.ExtendedHeader],
 	ds datastore.Batching,
 	network p2p.Network,
+	halts *HaltRecorder,
 ) (Module, fraud.Service[*header.ExtendedHeader], error) {
 	syncerEnabled := false
 	headGetter := func(ctx context.Context) (*header.ExtendedHeader, error) {
@@ -67,6 +72,9 @@ func newFraudServiceWithoutSync(
 		OnStop:  pservice.Stop,
 	})
 	return &module{
-		Service: pservice,
+		Service:     pservice,
+		unmarshaler: registry,
+		getter:      hstore,
+		halts:       halts,
 	}, pservice, nil
 }
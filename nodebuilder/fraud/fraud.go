@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
 )
@@ -21,14 +23,21 @@ type Module interface {
 	Subscribe(context.Context, fraud.ProofType) (<-chan *Proof, error)
 	// Get fetches fraud proofs from the disk by its type.
 	Get(context.Context, fraud.ProofType) ([]Proof, error)
+	// Submit validates the binary encoded fraud proof of the given type against the corresponding
+	// header and broadcasts it to the network. A valid proof halts the node as any other.
+	Submit(ctx context.Context, proofType fraud.ProofType, proof []byte) error
+	// HaltRecord returns the record of the fraud proof that halted the node, if any.
+	HaltRecord(context.Context) (*HaltRecord, error)
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
 	Internal struct {
-		Subscribe func(context.Context, fraud.ProofType) (<-chan *Proof, error) `perm:"read"`
-		Get       func(context.Context, fraud.ProofType) ([]Proof, error)       `perm:"read"`
+		Subscribe  func(context.Context, fraud.ProofType) (<-chan *Proof, error) `perm:"read"`
+		Get        func(context.Context, fraud.ProofType) ([]Proof, error)       `perm:"read"`
+		Submit     func(context.Context, fraud.ProofType, []byte) error          `perm:"admin"`
+		HaltRecord func(context.Context) (*HaltRecord, error)                    `perm:"read"`
 	}
 }
 
@@ -40,6 +49,14 @@ func (api *API) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof, er
 	return api.Internal.Get(ctx, proofType)
 }
 
+func (api *API) Submit(ctx context.Context, proofType fraud.ProofType, proof []byte) error {
+	return api.Internal.Submit(ctx, proofType, proof)
+}
+
+func (api *API) HaltRecord(ctx context.Context) (*HaltRecord, error) {
+	return api.Internal.HaltRecord(ctx)
+}
+
 var _ Module = (*module)(nil)
 
 // module is an implementation of Module that uses fraud.module as a backend. It is used to
@@ -47,6 +64,10 @@ var _ Module = (*module)(nil)
 // channel of Proofs.
 type module struct {
 	fraud.Service[*header.ExtendedHeader]
+
+	unmarshaler fraud.ProofUnmarshaler[*header.ExtendedHeader]
+	getter      libhead.Getter[*header.ExtendedHeader]
+	halts       *HaltRecorder
 }
 
 func (s *module) Subscribe(ctx context.Context, proofType fraud.ProofType) (<-chan *Proof, error) {
@@ -88,6 +109,28 @@ func (s *module) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof, e
 	return proofs, nil
 }
 
+func (s *module) Submit(ctx context.Context, proofType fraud.ProofType, data []byte) error {
+	proof, err := s.unmarshaler.Unmarshal(proofType, data)
+	if err != nil {
+		return fmt.Errorf("fraud: decoding %s proof: %w", proofType, err)
+	}
+
+	// the proof is validated by the broadcast as well, but without telling why it is invalid
+	h, err := s.getter.GetByHeight(ctx, proof.Height())
+	if err != nil {
+		return fmt.Errorf("fraud: getting header %d to validate the proof: %w", proof.Height(), err)
+	}
+	if err := proof.Validate(h); err != nil {
+		return fmt.Errorf("fraud: invalid %s proof: %w", proofType, err)
+	}
+
+	return s.Service.Broadcast(ctx, proof)
+}
+
+func (s *module) HaltRecord(ctx context.Context) (*HaltRecord, error) {
+	return s.halts.Record(ctx)
+}
+
 // Proof embeds the fraud.Proof interface type to provide a concrete type for JSON serialization.
 type Proof struct {
 	fraud.Proof[*header.ExtendedHeader]
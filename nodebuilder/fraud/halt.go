@@ -0,0 +1,164 @@
+package fraud
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/go-fraud"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var (
+	haltPrefix    = datastore.NewKey("fraud-halt")
+	haltRecordKey = datastore.NewKey("record")
+)
+
+// maxReporters bounds the amount of proof reporters remembered by the HaltRecorder.
+const maxReporters = 128
+
+// HaltRecord describes why the node halted: the fraud proof that stopped the node's services and
+// the services that were stopped.
+type HaltRecord struct {
+	ProofType fraud.ProofType `json:"proof_type"`
+	// Height and HeaderHash of the header the proof is for.
+	Height     uint64 `json:"height"`
+	HeaderHash string `json:"header_hash"`
+	// Reporter is the peer the proof was received from. It is the node itself for proofs submitted
+	// via the Submit RPC and empty if unknown, e.g. for proofs fetched on start.
+	Reporter peer.ID `json:"reporter,omitempty"`
+	// StoppedServices lists the services stopped because of the proof.
+	StoppedServices []string  `json:"stopped_services"`
+	Time            time.Time `json:"time"`
+}
+
+// HaltRecorder persists the HaltRecord when a ServiceBreaker stops a service because of a fraud
+// proof. It traces the fraud proofs delivered by the pubsub to know the peers reporting them.
+type HaltRecorder struct {
+	ds datastore.Datastore
+
+	lk        sync.Mutex
+	reporters map[string]peer.ID
+}
+
+func newHaltRecorder(ds datastore.Batching) *HaltRecorder {
+	return &HaltRecorder{
+		ds:        namespace.Wrap(ds, haltPrefix),
+		reporters: make(map[string]peer.ID),
+	}
+}
+
+// Record returns the persisted HaltRecord or nil if the node has not halted.
+func (hr *HaltRecorder) Record(ctx context.Context) (*HaltRecord, error) {
+	return loadHaltRecord(ctx, hr.ds)
+}
+
+// record adds the stopped service to the HaltRecord of the proof.
+func (hr *HaltRecorder) record(
+	ctx context.Context,
+	proofType fraud.ProofType,
+	height uint64,
+	headerHash []byte,
+	service string,
+) error {
+	hr.lk.Lock()
+	defer hr.lk.Unlock()
+
+	rec, err := loadHaltRecord(ctx, hr.ds)
+	if err != nil {
+		return err
+	}
+
+	hash := hex.EncodeToString(headerHash)
+	if rec == nil || rec.ProofType != proofType || rec.HeaderHash != hash {
+		rec = &HaltRecord{
+			ProofType:  proofType,
+			Height:     height,
+			HeaderHash: hash,
+			Reporter:   hr.reporters[hash],
+			Time:       time.Now(),
+		}
+	}
+	if !slices.Contains(rec.StoppedServices, service) {
+		rec.StoppedServices = append(rec.StoppedServices, service)
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err := hr.ds.Put(ctx, haltRecordKey, data); err != nil {
+		return fmt.Errorf("fraud: storing halt record: %w", err)
+	}
+	log.Warnw("service halted by fraud proof", "service", service, "proof_type", proofType,
+		"height", height, "reporter", rec.Reporter)
+	return nil
+}
+
+// LoadHaltRecord returns the HaltRecord persisted in the given node datastore or nil if the node
+// has not halted. It allows inspecting the halt of a node that can't be started anymore.
+func LoadHaltRecord(ctx context.Context, ds datastore.Datastore) (*HaltRecord, error) {
+	return loadHaltRecord(ctx, namespace.Wrap(ds, haltPrefix))
+}
+
+func loadHaltRecord(ctx context.Context, ds datastore.Datastore) (*HaltRecord, error) {
+	data, err := ds.Get(ctx, haltRecordKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fraud: loading halt record: %w", err)
+	}
+
+	rec := &HaltRecord{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("fraud: decoding halt record: %w", err)
+	}
+	return rec, nil
+}
+
+var _ pubsub.RawTracer = (*HaltRecorder)(nil)
+
+// DeliverMessage remembers the peer a valid fraud proof was received from.
+func (hr *HaltRecorder) DeliverMessage(msg *pubsub.Message) {
+	// fraud proof validators put the proof into ValidatorData
+	proof, ok := msg.ValidatorData.(fraud.Proof[*header.ExtendedHeader])
+	if !ok {
+		return
+	}
+
+	hr.lk.Lock()
+	defer hr.lk.Unlock()
+	if len(hr.reporters) == maxReporters {
+		// proofs are rare, so starting over is good enough
+		clear(hr.reporters)
+	}
+	hr.reporters[hex.EncodeToString(proof.HeaderHash())] = msg.ReceivedFrom
+}
+
+func (hr *HaltRecorder) AddPeer(peer.ID, protocol.ID)          {}
+func (hr *HaltRecorder) RemovePeer(peer.ID)                    {}
+func (hr *HaltRecorder) Join(string)                           {}
+func (hr *HaltRecorder) Leave(string)                          {}
+func (hr *HaltRecorder) Graft(peer.ID, string)                 {}
+func (hr *HaltRecorder) Prune(peer.ID, string)                 {}
+func (hr *HaltRecorder) ValidateMessage(*pubsub.Message)       {}
+func (hr *HaltRecorder) RejectMessage(*pubsub.Message, string) {}
+func (hr *HaltRecorder) DuplicateMessage(*pubsub.Message)      {}
+func (hr *HaltRecorder) ThrottlePeer(peer.ID)                  {}
+func (hr *HaltRecorder) RecvRPC(*pubsub.RPC)                   {}
+func (hr *HaltRecorder) SendRPC(*pubsub.RPC, peer.ID)          {}
+func (hr *HaltRecorder) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (hr *HaltRecorder) UndeliverableMessage(*pubsub.Message)  {}
@@ -0,0 +1,54 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+func TestHaltRecorder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	hr := newHaltRecorder(ds)
+
+	rec, err := hr.Record(ctx)
+	require.NoError(t, err)
+	require.Nil(t, rec)
+
+	proof := byzantine.CreateBadEncodingProof([]byte("hash"), 10, &byzantine.ErrByzantine{
+		Index:  0,
+		Axis:   rsmt2d.Axis(0),
+		Shares: []*byzantine.ShareWithProof{},
+	})
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	reporter, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	hr.DeliverMessage(&pubsub.Message{ReceivedFrom: reporter, ValidatorData: proof})
+
+	require.NoError(t, hr.record(ctx, proof.Type(), proof.Height(), proof.HeaderHash(), "das.DASer"))
+	require.NoError(t, hr.record(ctx, proof.Type(), proof.Height(), proof.HeaderHash(), "sync.Syncer"))
+	require.NoError(t, hr.record(ctx, proof.Type(), proof.Height(), proof.HeaderHash(), "das.DASer"))
+
+	// the record is persisted
+	rec, err = LoadHaltRecord(ctx, ds)
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	require.Equal(t, byzantine.BadEncoding, rec.ProofType)
+	require.EqualValues(t, 10, rec.Height)
+	require.Equal(t, reporter, rec.Reporter)
+	require.Equal(t, []string{"das.DASer", "sync.Syncer"}, rec.StoppedServices)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ipfs/go-datastore"
 
@@ -24,6 +26,8 @@ type ServiceBreaker[S service, H libhead.Header[H]] struct {
 	Service   S
 	FraudType fraud.ProofType
 	FraudServ fraud.Service[H]
+	// Halts records the halt of the service, if set.
+	Halts *HaltRecorder
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -78,7 +82,7 @@ func (breaker *ServiceBreaker[S, H]) Stop(ctx context.Context) error {
 }
 
 func (breaker *ServiceBreaker[S, H]) awaitProof() {
-	_, err := breaker.sub.Proof(breaker.ctx)
+	proof, err := breaker.sub.Proof(breaker.ctx)
 	if err != nil {
 		return
 	}
@@ -86,4 +90,23 @@ func (breaker *ServiceBreaker[S, H]) awaitProof() {
 	if err := breaker.Stop(breaker.ctx); err != nil && !errors.Is(err, context.Canceled) {
 		log.Errorw("stopping service", "err", err.Error())
 	}
+
+	if breaker.Halts == nil {
+		return
+	}
+	// the breaker's context is canceled on stop
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	err = breaker.Halts.record(ctx, proof.Type(), proof.Height(), proof.HeaderHash(), breaker.serviceName())
+	if err != nil {
+		log.Errorw("recording halt", "err", err)
+	}
+}
+
+// serviceName returns the name of the service type, e.g. "das.DASer".
+func (breaker *ServiceBreaker[S, H]) serviceName() string {
+	name := fmt.Sprintf("%T", breaker.Service)
+	// strip type parameters and the pointer
+	name, _, _ = strings.Cut(name, "[")
+	return strings.TrimPrefix(name, "*")
 }
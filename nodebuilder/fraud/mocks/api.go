@@ -51,6 +51,35 @@ func (mr *MockModuleMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockModule)(nil).Get), arg0, arg1)
 }
 
+// HaltRecord mocks base method.
+func (m *MockModule) HaltRecord(arg0 context.Context) (*fraud.HaltRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HaltRecord", arg0)
+	ret0, _ := ret[0].(*fraud.HaltRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HaltRecord indicates an expected call of HaltRecord.
+func (mr *MockModuleMockRecorder) HaltRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HaltRecord", reflect.TypeOf((*MockModule)(nil).HaltRecord), arg0)
+}
+
+// Submit mocks base method.
+func (m *MockModule) Submit(arg0 context.Context, arg1 fraud0.ProofType, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockModuleMockRecorder) Submit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockModule)(nil).Submit), arg0, arg1, arg2)
+}
+
 // Subscribe mocks base method.
 func (m *MockModule) Subscribe(arg0 context.Context, arg1 fraud0.ProofType) (<-chan *fraud.Proof, error) {
 	m.ctrl.T.Helper()
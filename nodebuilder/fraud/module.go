@@ -2,6 +2,7 @@ package fraud
 
 import (
 	logging "github.com/ipfs/go-log/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/go-fraud"
@@ -15,6 +16,13 @@ var log = logging.Logger("module/fraud")
 func ConstructModule(tp node.Type) fx.Option {
 	baseComponent := fx.Options(
 		fx.Provide(fraudUnmarshaler),
+		fx.Provide(newHaltRecorder),
+		fx.Provide(fx.Annotate(
+			func(halts *HaltRecorder) pubsub.RawTracer {
+				return halts
+			},
+			fx.ResultTags(`group:"pubsub-tracers"`),
+		)),
 		fx.Provide(func(serv fraud.Service[*header.ExtendedHeader]) fraud.Getter[*header.ExtendedHeader] {
 			return serv
 		}),
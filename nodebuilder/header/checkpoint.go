@@ -0,0 +1,347 @@
+package header
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+// ErrStaleHead is returned when the stored head is older than the trusting period and no fresh
+// checkpoint is supplied, so the node can't safely decide on the network head.
+var ErrStaleHead = errors.New("header: stored head is older than the trusting period, " +
+	"supply a fresh trusted hash or checkpoint to re-anchor the node")
+
+// Checkpoint is a weak-subjectivity checkpoint: a header trusted by the node operator, from which
+// the node re-anchors syncing when its stored head is older than the trusting period.
+type Checkpoint struct {
+	// Height of the header. Zero skips the height check.
+	Height uint64 `json:"height"`
+	// Hash of the header.
+	Hash libhead.Hash `json:"hash"`
+	// Signer is the peer ID of the checkpoint signer.
+	Signer string `json:"signer,omitempty"`
+	// Signature over the checkpoint by the Signer.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// signingBytes returns the bytes the checkpoint signature is over. The network is included, so a
+// checkpoint signed for one network is not valid for another.
+func (c *Checkpoint) signingBytes(network string) []byte {
+	return []byte(fmt.Sprintf("celestia-checkpoint/%s/%d/%s", network, c.Height, c.Hash.String()))
+}
+
+// Sign signs the checkpoint for the given network with the given key.
+func (c *Checkpoint) Sign(network string, key crypto.PrivKey) error {
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	sig, err := key.Sign(c.signingBytes(network))
+	if err != nil {
+		return err
+	}
+	c.Signer, c.Signature = id.String(), sig
+	return nil
+}
+
+// verifySignature verifies that the checkpoint is signed for the given network by one of the
+// signers.
+func (c *Checkpoint) verifySignature(network string, signers []peer.ID) error {
+	if c.Signer == "" {
+		return errors.New("checkpoint is not signed")
+	}
+	id, err := peer.Decode(c.Signer)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint signer: %w", err)
+	}
+
+	trusted := false
+	for _, signer := range signers {
+		trusted = trusted || signer == id
+	}
+	if !trusted {
+		return fmt.Errorf("checkpoint signer %s is not trusted", id)
+	}
+
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("extracting checkpoint signer key: %w", err)
+	}
+	ok, err := pubKey.Verify(c.signingBytes(network), c.Signature)
+	if err != nil {
+		return fmt.Errorf("verifying checkpoint signature: %w", err)
+	}
+	if !ok {
+		return errors.New("invalid checkpoint signature")
+	}
+	return nil
+}
+
+// readCheckpointFile reads the JSON encoded checkpoint from the file at the given path.
+func readCheckpointFile(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint file: %w", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("decoding checkpoint file: %w", err)
+	}
+	return &cp, nil
+}
+
+// weakSubjectivity keeps the node from syncing from a stored head older than the trusting
+// period. Instead of trusting the head of trusted peers as is, the node waits until it is
+// re-anchored to a fresh checkpoint.
+type weakSubjectivity[H libhead.Header[H]] struct {
+	cfg            Config
+	network        string
+	enabled        bool
+	trustingPeriod time.Duration
+	store          libhead.Store[H]
+	getter         libhead.Getter[H]
+
+	lk         sync.Mutex
+	checkpoint H
+	// anchored is closed once a fresh checkpoint is set
+	anchored chan struct{}
+
+	// runLk protects the state of the service started by startWhenAnchored
+	runLk   sync.Mutex
+	started bool
+	stopped chan struct{}
+}
+
+func newWeakSubjectivity[H libhead.Header[H]](
+	cfg Config,
+	network modp2p.Network,
+	store libhead.Store[H],
+	ex libhead.Exchange[H],
+) *weakSubjectivity[H] {
+	return &weakSubjectivity[H]{
+		cfg:            cfg,
+		network:        network.String(),
+		enabled:        cfg.WeakSubjectivity,
+		trustingPeriod: cfg.Syncer.TrustingPeriod,
+		store:          store,
+		getter:         ex,
+		anchored:       make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+}
+
+// stale reports whether the stored head is older than the trusting period.
+func (ws *weakSubjectivity[H]) stale(ctx context.Context) (H, bool, error) {
+	head, err := ws.store.Head(ctx)
+	if err != nil {
+		return head, false, err
+	}
+	return head, ws.expired(head), nil
+}
+
+func (ws *weakSubjectivity[H]) expired(h H) bool {
+	return !h.Time().Add(ws.trustingPeriod).After(time.Now())
+}
+
+// setCheckpoint verifies the checkpoint and anchors the node to it.
+func (ws *weakSubjectivity[H]) setCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	if err := ws.verify(cp); err != nil {
+		return fmt.Errorf("header: %w", err)
+	}
+	return ws.anchor(ctx, cp)
+}
+
+// verify verifies that the checkpoint is signed by one of the checkpoint signers, if any are
+// configured.
+func (ws *weakSubjectivity[H]) verify(cp *Checkpoint) error {
+	if len(ws.cfg.CheckpointSigners) == 0 {
+		return nil
+	}
+	signers, err := parseSigners(ws.cfg.CheckpointSigners)
+	if err != nil {
+		return err
+	}
+	return cp.verifySignature(ws.network, signers)
+}
+
+// anchor fetches and validates the checkpointed header and sets it as the new anchor.
+func (ws *weakSubjectivity[H]) anchor(ctx context.Context, cp *Checkpoint) error {
+	h, err := ws.getter.Get(ctx, cp.Hash)
+	if err != nil {
+		return fmt.Errorf("header: getting checkpoint header %s: %w", cp.Hash, err)
+	}
+	if cp.Height != 0 && h.Height() != cp.Height {
+		return fmt.Errorf("header: checkpoint header %s is at height %d, not %d", cp.Hash, h.Height(), cp.Height)
+	}
+	if ws.expired(h) {
+		return fmt.Errorf("header: checkpoint header at height %d from %s is older than the trusting period",
+			h.Height(), h.Time())
+	}
+
+	ws.lk.Lock()
+	defer ws.lk.Unlock()
+	if !ws.checkpoint.IsZero() && ws.checkpoint.Height() >= h.Height() {
+		return nil
+	}
+	ws.checkpoint = h
+	select {
+	case <-ws.anchored:
+	default:
+		close(ws.anchored)
+	}
+	log.Infow("re-anchored to checkpoint", "height", h.Height(), "hash", h.Hash())
+	return nil
+}
+
+// freshCheckpoint returns the checkpoint, if it is still within the trusting period.
+func (ws *weakSubjectivity[H]) freshCheckpoint() (H, bool) {
+	ws.lk.Lock()
+	defer ws.lk.Unlock()
+	if ws.checkpoint.IsZero() || ws.expired(ws.checkpoint) {
+		var zero H
+		return zero, false
+	}
+	return ws.checkpoint, true
+}
+
+// startWhenAnchored calls start right away, unless the stored head is stale and there is no fresh
+// checkpoint configured. In that case, start is deferred until a fresh checkpoint is set.
+func (ws *weakSubjectivity[H]) startWhenAnchored(ctx context.Context, start func(context.Context) error) error {
+	if !ws.enabled {
+		return ws.start(ctx, start)
+	}
+	head, stale, err := ws.stale(ctx)
+	switch {
+	case errors.Is(err, libhead.ErrNoHead), err == nil && !stale:
+		return ws.start(ctx, start)
+	case err != nil:
+		return err
+	}
+
+	cp, err := ws.cfg.initialCheckpoint()
+	if err != nil {
+		return fmt.Errorf("header: loading checkpoint: %w", err)
+	}
+	// the trusted hash is set by the operator, so only checkpoint files have to be signed
+	if ws.cfg.CheckpointFile != "" {
+		if err := ws.verify(cp); err != nil {
+			return fmt.Errorf("header: loading checkpoint: %w", err)
+		}
+	}
+	if cp != nil {
+		if err := ws.anchor(ctx, cp); err != nil {
+			log.Warnw("configured checkpoint is not usable", "err", err)
+		}
+	}
+	if _, ok := ws.freshCheckpoint(); ok {
+		return ws.start(ctx, start)
+	}
+
+	log.Errorw("syncing is paused until a fresh checkpoint is supplied via the SetCheckpoint RPC",
+		"err", ErrStaleHead, "head_height", head.Height(), "head_time", head.Time())
+	go func() {
+		select {
+		case <-ws.anchored:
+		case <-ws.stopped:
+			return
+		}
+		// the node start context is done by now, so start with a fresh one
+		if err := ws.start(context.Background(), start); err != nil {
+			log.Errorw("starting syncer after re-anchoring", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (ws *weakSubjectivity[H]) start(ctx context.Context, start func(context.Context) error) error {
+	ws.runLk.Lock()
+	defer ws.runLk.Unlock()
+	select {
+	case <-ws.stopped:
+		return nil
+	default:
+	}
+
+	if err := start(ctx); err != nil {
+		return err
+	}
+	ws.started = true
+	return nil
+}
+
+// stopIfStarted calls stop if the service was started with startWhenAnchored.
+func (ws *weakSubjectivity[H]) stopIfStarted(ctx context.Context, stop func(context.Context) error) error {
+	ws.runLk.Lock()
+	defer ws.runLk.Unlock()
+	close(ws.stopped)
+	if !ws.started {
+		return nil
+	}
+	return stop(ctx)
+}
+
+// checkpointGetter serves the checkpoint as the head of trusted peers when the stored head is
+// stale, preventing the Syncer from trusting whatever head the peers give.
+type checkpointGetter[H libhead.Header[H]] struct {
+	libhead.Exchange[H]
+	ws *weakSubjectivity[H]
+}
+
+func (cg *checkpointGetter[H]) Head(ctx context.Context, opts ...libhead.HeadOption[H]) (H, error) {
+	if !cg.ws.enabled {
+		return cg.Exchange.Head(ctx, opts...)
+	}
+	head, stale, err := cg.ws.stale(ctx)
+	if err != nil || !stale {
+		return cg.Exchange.Head(ctx, opts...)
+	}
+
+	checkpoint, ok := cg.ws.freshCheckpoint()
+	if !ok {
+		var zero H
+		return zero, fmt.Errorf("%w: head at height %d is from %s", ErrStaleHead, head.Height(), head.Time())
+	}
+	return checkpoint, nil
+}
+
+// parseSigners parses peer IDs of the trusted checkpoint signers.
+func parseSigners(signers []string) ([]peer.ID, error) {
+	ids := make([]peer.ID, len(signers))
+	for i, signer := range signers {
+		id, err := peer.Decode(signer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint signer %s: %w", signer, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// initialCheckpoint returns the checkpoint configured with the checkpoint file or, if there is
+// none, the trusted hash.
+func (cfg *Config) initialCheckpoint() (*Checkpoint, error) {
+	if cfg.CheckpointFile != "" {
+		return readCheckpointFile(cfg.CheckpointFile)
+	}
+
+	if cfg.TrustedHash != "" {
+		hash, err := hex.DecodeString(cfg.TrustedHash)
+		if err != nil {
+			return nil, err
+		}
+		return &Checkpoint{Hash: hash}, nil
+	}
+	return nil, nil
+}
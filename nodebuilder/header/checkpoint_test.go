@@ -0,0 +1,140 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/header"
+	nodeheadertest "github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestCheckpoint_Signature(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	other, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	otherID, err := peer.IDFromPrivateKey(other)
+	require.NoError(t, err)
+
+	cp := &Checkpoint{Height: 10, Hash: nodeheadertest.RandExtendedHeader(t).Hash()}
+	require.Error(t, cp.verifySignature("private", []peer.ID{id}))
+
+	require.NoError(t, cp.Sign("private", key))
+	require.NoError(t, cp.verifySignature("private", []peer.ID{otherID, id}))
+	// signed for another network
+	require.Error(t, cp.verifySignature("mocha", []peer.ID{id}))
+	// signed by an untrusted signer
+	require.Error(t, cp.verifySignature("private", []peer.ID{otherID}))
+	// tampered with after signing
+	cp.Height++
+	require.Error(t, cp.verifySignature("private", []peer.ID{id}))
+}
+
+func TestCheckpointGetter_StaleHead(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	stale := nodeheadertest.RandExtendedHeader(t)
+	stale.RawHeader.Time = time.Now().Add(-2 * time.Hour)
+	store := &staleStore{head: stale}
+	peers := headertest.NewStore[*header.ExtendedHeader](t, nodeheadertest.NewTestSuite(t, 3, 0), 5)
+
+	cfg := DefaultConfig(node.Light)
+	cfg.WeakSubjectivity = true
+	cfg.Syncer.TrustingPeriod = time.Hour
+	ws := newWeakSubjectivity[*header.ExtendedHeader](cfg, "private", store, peers)
+	getter := &checkpointGetter[*header.ExtendedHeader]{Exchange: peers, ws: ws}
+
+	_, err := getter.Head(ctx)
+	require.ErrorIs(t, err, ErrStaleHead)
+
+	started := make(chan struct{})
+	err = ws.startWhenAnchored(ctx, func(context.Context) error {
+		close(started)
+		return nil
+	})
+	require.NoError(t, err)
+	select {
+	case <-started:
+		t.Fatal("started before re-anchoring")
+	default:
+	}
+
+	checkpoint, err := peers.GetByHeight(ctx, 3)
+	require.NoError(t, err)
+	err = ws.setCheckpoint(ctx, &Checkpoint{Height: 2, Hash: checkpoint.Hash()})
+	require.Error(t, err)
+	err = ws.setCheckpoint(ctx, &Checkpoint{Height: 3, Hash: checkpoint.Hash()})
+	require.NoError(t, err)
+
+	select {
+	case <-started:
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	head, err := getter.Head(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, checkpoint.Hash(), head.Hash())
+	require.NoError(t, ws.stopIfStarted(ctx, func(context.Context) error { return nil }))
+}
+
+func TestCheckpoint_SetSigned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	other, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	stale := nodeheadertest.RandExtendedHeader(t)
+	stale.RawHeader.Time = time.Now().Add(-2 * time.Hour)
+	peers := headertest.NewStore[*header.ExtendedHeader](t, nodeheadertest.NewTestSuite(t, 3, 0), 5)
+
+	cfg := DefaultConfig(node.Light)
+	cfg.WeakSubjectivity = true
+	cfg.Syncer.TrustingPeriod = time.Hour
+	cfg.CheckpointSigners = []string{id.String()}
+	ws := newWeakSubjectivity[*header.ExtendedHeader](cfg, "private", &staleStore{head: stale}, peers)
+
+	checkpoint, err := peers.GetByHeight(ctx, 3)
+	require.NoError(t, err)
+	cp := &Checkpoint{Height: 3, Hash: checkpoint.Hash()}
+	// unsigned checkpoints are rejected
+	require.Error(t, ws.setCheckpoint(ctx, cp))
+	// as well as the ones signed by untrusted signers
+	require.NoError(t, cp.Sign("private", other))
+	require.Error(t, ws.setCheckpoint(ctx, cp))
+	_, ok := ws.freshCheckpoint()
+	require.False(t, ok)
+
+	require.NoError(t, cp.Sign("private", key))
+	require.NoError(t, ws.setCheckpoint(ctx, cp))
+	anchored, ok := ws.freshCheckpoint()
+	require.True(t, ok)
+	require.EqualValues(t, checkpoint.Hash(), anchored.Hash())
+}
+
+// staleStore is a Store with a single head.
+type staleStore struct {
+	libhead.Store[*header.ExtendedHeader]
+	head *header.ExtendedHeader
+}
+
+func (s *staleStore) Head(context.Context, ...libhead.HeadOption[*header.ExtendedHeader]) (*header.ExtendedHeader, error) {
+	return s.head, nil
+}
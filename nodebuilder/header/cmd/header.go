@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 )
 
 func init() {
@@ -16,8 +20,13 @@ func init() {
 		networkHeadCmd,
 		getByHashCmd,
 		getByHeightCmd,
+		getByTimeCmd,
+		getByDataHashCmd,
 		syncStateCmd,
+		setCheckpointCmd,
 	)
+
+	setCheckpointCmd.Flags().String("file", "", "Path to a JSON encoded checkpoint to use instead of the arguments")
 }
 
 var Cmd = &cobra.Command{
@@ -100,6 +109,47 @@ var getByHeightCmd = &cobra.Command{
 	},
 }
 
+var getByTimeCmd = &cobra.Command{
+	Use:   "get-by-time",
+	Short: "Returns the last ExtendedHeader at or before the given RFC3339 time from the node's header store.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		t, err := time.Parse(time.RFC3339, args[0])
+		if err != nil {
+			return fmt.Errorf("error parsing a time: expected RFC3339 format: %w", err)
+		}
+
+		header, err := client.Header.GetByTime(cmd.Context(), t)
+		return cmdnode.PrintOutput(header, err, nil)
+	},
+}
+
+var getByDataHashCmd = &cobra.Command{
+	Use:   "get-by-data-hash",
+	Short: "Returns the ExtendedHeader with the given DataHash from the node's header store.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		hash, err := hex.DecodeString(args[0])
+		if err != nil {
+			return fmt.Errorf("error decoding a hash: expected a hex encoded string: %w", err)
+		}
+		header, err := client.Header.GetByDataHash(cmd.Context(), hash)
+		return cmdnode.PrintOutput(header, err, nil)
+	},
+}
+
 var syncStateCmd = &cobra.Command{
 	Use:   "sync-state",
 	Short: "Returns the current state of the header Syncer.",
@@ -115,3 +165,50 @@ var syncStateCmd = &cobra.Command{
 		return cmdnode.PrintOutput(header, err, nil)
 	},
 }
+
+var setCheckpointCmd = &cobra.Command{
+	Use: "set-checkpoint [hash] [height]",
+	Short: "Re-anchors the node to a weak-subjectivity checkpoint, resuming syncing paused because " +
+		"the stored head is older than the trusting period.",
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		cp := &header.Checkpoint{}
+		if path := cmd.Flag("file").Value.String(); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("error reading a checkpoint file: %w", err)
+			}
+			if err := json.Unmarshal(data, cp); err != nil {
+				return fmt.Errorf("error decoding a checkpoint file: %w", err)
+			}
+		} else {
+			if len(args) == 0 {
+				return fmt.Errorf("either a hash or a checkpoint file is required")
+			}
+			cp.Hash, err = hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("error decoding a hash: expected a hex encoded string: %w", err)
+			}
+			if len(args) == 2 {
+				cp.Height, err = strconv.ParseUint(args[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("error parsing a height: %w", err)
+				}
+			}
+		}
+
+		err = client.Header.SetCheckpoint(cmd.Context(), cp)
+		return cmdnode.PrintOutput(err, nil, func(data interface{}) interface{} {
+			if data == nil {
+				return "checkpoint set"
+			}
+			return data
+		})
+	},
+}
@@ -0,0 +1,205 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+const valsetsCacheSize = 16
+
+var (
+	valsetsPrefix = datastore.NewKey("header-valsets")
+	// compactHeaderPrefix marks the headers stored in the compact encoding. It is followed by the
+	// hash of the omitted validator set and the compact encoding itself, so that reading a header
+	// doesn't require decoding it.
+	compactHeaderPrefix = []byte{0x00, 0xff}
+)
+
+// compactDatastore stores the headers written by the header store in the compact encoding,
+// keeping each distinct validator set only once, under its hash.
+// Headers are re-inflated with their validator sets when read, so the header store sees them
+// whole. Headers stored in the legacy encoding are read as is, so the compact writes can be turned
+// on and off at any time.
+type compactDatastore struct {
+	datastore.Batching
+	valsets datastore.Datastore
+	// compact enables writing headers in the compact encoding
+	compact bool
+
+	cacheLk sync.Mutex
+	// cache keeps the serialized validator sets read recently
+	cache map[string][]byte
+	order []string
+}
+
+func newCompactDatastore(ds datastore.Batching, compact bool) *compactDatastore {
+	return &compactDatastore{
+		Batching: ds,
+		valsets:  namespace.Wrap(ds, valsetsPrefix),
+		compact:  compact,
+		cache:    make(map[string][]byte, valsetsCacheSize),
+	}
+}
+
+func (cd *compactDatastore) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	data, err := cd.Batching.Get(ctx, key)
+	if err != nil || !bytes.HasPrefix(data, compactHeaderPrefix) || !isHeaderKey(key) {
+		return data, err
+	}
+
+	data = data[len(compactHeaderPrefix):]
+	if len(data) < tmhash.Size {
+		return nil, fmt.Errorf("header: truncated compact header %s", key)
+	}
+	valsHash, compact := data[:tmhash.Size], data[tmhash.Size:]
+	valset, err := cd.valset(ctx, valsHash)
+	if err != nil {
+		return nil, err
+	}
+	return header.JoinValidatorSet(compact, valset), nil
+}
+
+// valset returns the serialized validator set with the given hash.
+func (cd *compactDatastore) valset(ctx context.Context, hash []byte) ([]byte, error) {
+	cd.cacheLk.Lock()
+	valset, ok := cd.cache[string(hash)]
+	cd.cacheLk.Unlock()
+	if ok {
+		return valset, nil
+	}
+
+	valset, err := cd.valsets.Get(ctx, valsetKey(hash))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %X", header.ErrUnknownValidatorSet, hash)
+		}
+		return nil, err
+	}
+
+	cd.cacheLk.Lock()
+	defer cd.cacheLk.Unlock()
+	if _, ok := cd.cache[string(hash)]; !ok {
+		if len(cd.order) == valsetsCacheSize {
+			delete(cd.cache, cd.order[0])
+			cd.order = cd.order[1:]
+		}
+		cd.cache[string(hash)] = valset
+		cd.order = append(cd.order, string(hash))
+	}
+	return valset, nil
+}
+
+func (cd *compactDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	return cd.put(ctx, cd.Batching, key, value)
+}
+
+func (cd *compactDatastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	batch, err := cd.Batching.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &compactBatch{Batch: batch, cd: cd}, nil
+}
+
+// put writes the header in the compact encoding to the given writer and its validator set to the
+// validator sets namespace, unless it is there already. Any other value is written as is.
+func (cd *compactDatastore) put(ctx context.Context, w datastore.Write, key datastore.Key, value []byte) error {
+	if !cd.compact || !isHeaderKey(key) {
+		return w.Put(ctx, key, value)
+	}
+
+	compact, valsHash, valset, err := header.SplitValidatorSet(value)
+	if err != nil {
+		return fmt.Errorf("header: encoding compact header %s: %w", key, err)
+	}
+	if valset == nil || len(valsHash) != tmhash.Size {
+		// the validator set is not there to be stored separately
+		return w.Put(ctx, key, value)
+	}
+
+	vkey := valsetKey(valsHash)
+	has, err := cd.valsets.Has(ctx, vkey)
+	if err != nil {
+		return err
+	}
+	if !has {
+		// the validator set is written before the header, so it is never missing for a header
+		if err := cd.valsets.Put(ctx, vkey, valset); err != nil {
+			return err
+		}
+	}
+
+	stored := make([]byte, 0, len(compactHeaderPrefix)+len(valsHash)+len(compact))
+	stored = append(stored, compactHeaderPrefix...)
+	stored = append(stored, valsHash...)
+	return w.Put(ctx, key, append(stored, compact...))
+}
+
+// compactBatch is a datastore.Batch of the compactDatastore.
+type compactBatch struct {
+	datastore.Batch
+	cd *compactDatastore
+}
+
+func (cb *compactBatch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	return cb.cd.put(ctx, cb.Batch, key, value)
+}
+
+// isHeaderKey reports whether the key is the one of a header in the header store, keyed by its
+// hash, as opposed to the height index and the head keys.
+func isHeaderKey(key datastore.Key) bool {
+	if key.Parent() != storePrefix {
+		return false
+	}
+	name := key.Name()
+	if len(name) != hex.EncodedLen(32) {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+func valsetKey(hash []byte) datastore.Key {
+	return datastore.NewKey(libhead.Hash(hash).String())
+}
+
+// compactRangeStore serves the header ranges of the compact ExchangeServer: every header of a
+// range after the first omits its validator set if it is the same as the one of the previous
+// header. See compactStream for the decoding side.
+type compactRangeStore[H libhead.Header[H]] struct {
+	libhead.Store[H]
+}
+
+func (cs *compactRangeStore[H]) GetRange(ctx context.Context, from, to uint64) ([]H, error) {
+	headers, err := cs.Store.GetRange(ctx, from, to)
+	if err != nil {
+		return headers, err
+	}
+
+	out := make([]H, len(headers))
+	var prev *header.ExtendedHeader
+	for i, h := range headers {
+		out[i] = h
+		eh, ok := any(h).(*header.ExtendedHeader)
+		if !ok {
+			continue
+		}
+		if prev != nil && bytes.Equal(eh.ValidatorsHash, prev.ValidatorsHash) {
+			out[i] = any(eh.Compact()).(H)
+		}
+		prev = eh
+	}
+	return out, nil
+}
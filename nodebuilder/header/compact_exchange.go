@@ -0,0 +1,109 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	p2p_pb "github.com/celestiaorg/go-header/p2p/pb"
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// compactNetworkSuffix is appended to the network ID of the ExchangeServer serving compact header
+// ranges, so that the compact ranges have a protocol ID of their own and are served only to the
+// peers negotiating it.
+const compactNetworkSuffix = "/compact"
+
+// exchangeProtocolID mirrors the protocol ID the go-header exchange derives from the network ID.
+func exchangeProtocolID(networkID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/header-ex/v0.0.3", networkID))
+}
+
+// compactExchangeHost makes the go-header exchange request compact header ranges from the peers
+// serving them, falling back to the legacy protocol otherwise. The compact responses are inflated
+// back into the legacy encoding before they reach the exchange.
+type compactExchangeHost struct {
+	host.Host
+	legacy, compact protocol.ID
+}
+
+func newCompactExchangeHost(h host.Host, networkID string) *compactExchangeHost {
+	return &compactExchangeHost{
+		Host:    h,
+		legacy:  exchangeProtocolID(networkID),
+		compact: exchangeProtocolID(networkID + compactNetworkSuffix),
+	}
+}
+
+func (h *compactExchangeHost) NewStream(
+	ctx context.Context,
+	p peer.ID,
+	pids ...protocol.ID,
+) (network.Stream, error) {
+	if len(pids) != 1 || pids[0] != h.legacy {
+		return h.Host.NewStream(ctx, p, pids...)
+	}
+
+	stream, err := h.Host.NewStream(ctx, p, h.compact, h.legacy)
+	if err != nil {
+		return nil, err
+	}
+	if stream.Protocol() != h.compact {
+		return stream, nil
+	}
+	return &compactStream{Stream: stream, valsets: make(map[string][]byte)}, nil
+}
+
+// compactStream inflates the headers of the compact range responses read from the stream,
+// resolving the validator sets they omit from the ones of the previous headers of the range.
+type compactStream struct {
+	network.Stream
+
+	buf bytes.Buffer
+	// valsets keeps the serialized validator sets of the range by their hash
+	valsets map[string][]byte
+}
+
+func (cs *compactStream) Read(p []byte) (int, error) {
+	if cs.buf.Len() == 0 {
+		if err := cs.next(); err != nil {
+			return 0, err
+		}
+	}
+	return cs.buf.Read(p)
+}
+
+// next reads the next response from the stream and writes it to the buffer inflated.
+func (cs *compactStream) next() error {
+	resp := &p2p_pb.HeaderResponse{}
+	_, err := serde.Read(cs.Stream, resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == p2p_pb.StatusCode_OK {
+		compact, valsHash, valset, err := header.SplitValidatorSet(resp.Body)
+		if err != nil {
+			return err
+		}
+		if valset != nil {
+			cs.valsets[string(valsHash)] = valset
+		} else {
+			valset, ok := cs.valsets[string(valsHash)]
+			if !ok {
+				return fmt.Errorf("%w: %X", header.ErrUnknownValidatorSet, valsHash)
+			}
+			resp.Body = header.JoinValidatorSet(compact, valset)
+		}
+	}
+
+	_, err = serde.Write(&cs.buf, resp)
+	return err
+}
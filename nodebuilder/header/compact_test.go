@@ -0,0 +1,137 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/p2p"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestCompactDatastore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3, 0)
+	genesis := suite.Head()
+	headers := suite.GenExtendedHeaders(9)
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	// genesis is stored in the legacy encoding
+	s, err := store.NewStoreWithHead[*header.ExtendedHeader](ctx, ds, genesis)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	require.NoError(t, s.Stop(ctx))
+
+	s, err = store.NewStore[*header.ExtendedHeader](newCompactDatastore(ds, true))
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	require.NoError(t, s.Append(ctx, headers...))
+	require.NoError(t, s.Stop(ctx))
+
+	raw, err := namespace.Wrap(ds, storePrefix).Get(ctx, datastore.NewKey(genesis.Hash().String()))
+	require.NoError(t, err)
+	require.False(t, header.IsCompact(raw))
+	for _, h := range headers {
+		raw, err := namespace.Wrap(ds, storePrefix).Get(ctx, datastore.NewKey(h.Hash().String()))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(raw, compactHeaderPrefix))
+	}
+	// the validator set is stored once
+	res, err := namespace.Wrap(ds, valsetsPrefix).Query(ctx, dsq.Query{KeysOnly: true})
+	require.NoError(t, err)
+	entries, err := res.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// reopen the store with the compact writes disabled to drop the caches
+	s, err = store.NewStore[*header.ExtendedHeader](newCompactDatastore(ds, false))
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() {
+		_ = s.Stop(ctx)
+	})
+	_, err = s.Head(ctx)
+	require.NoError(t, err)
+
+	for _, h := range append([]*header.ExtendedHeader{genesis}, headers...) {
+		out, err := s.GetByHeight(ctx, h.Height())
+		require.NoError(t, err)
+		require.Equal(t, h.Hash(), out.Hash())
+		require.NoError(t, out.Validate())
+	}
+}
+
+func TestCompactExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3, 0)
+	genesis := suite.Head()
+	headers := suite.GenExtendedHeaders(5)
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	s, err := store.NewStoreWithHead[*header.ExtendedHeader](ctx, ds, genesis)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() {
+		_ = s.Stop(ctx)
+	})
+	require.NoError(t, s.Append(ctx, headers...))
+
+	const networkID = "private"
+	for _, compact := range []bool{true, false} {
+		net, err := mocknet.FullMeshConnected(2)
+		require.NoError(t, err)
+		client, server := net.Hosts()[0], net.Hosts()[1]
+
+		srv, err := p2p.NewExchangeServer[*header.ExtendedHeader](server, s,
+			p2p.WithNetworkID[p2p.ServerParameters](networkID))
+		require.NoError(t, err)
+		require.NoError(t, srv.Start(ctx))
+		if compact {
+			srv, err := p2p.NewExchangeServer[*header.ExtendedHeader](server,
+				&compactRangeStore[*header.ExtendedHeader]{Store: s},
+				p2p.WithNetworkID[p2p.ServerParameters](networkID+compactNetworkSuffix))
+			require.NoError(t, err)
+			require.NoError(t, srv.Start(ctx))
+		}
+
+		host := newCompactExchangeHost(client, networkID)
+		stream, err := host.NewStream(ctx, server.ID(), host.legacy)
+		require.NoError(t, err)
+		// peers not serving compact ranges are requested the legacy ones
+		_, ok := stream.(*compactStream)
+		require.Equal(t, compact, ok)
+		require.NoError(t, stream.Reset())
+
+		ex, err := p2p.NewExchange[*header.ExtendedHeader](host, []peer.ID{server.ID()}, nil,
+			p2p.WithNetworkID[p2p.ClientParameters](networkID),
+			p2p.WithChainID(genesis.ChainID()),
+		)
+		require.NoError(t, err)
+		require.NoError(t, ex.Start(ctx))
+		time.Sleep(time.Millisecond * 100) // give peerTracker time to add the connected peer
+		rng, err := ex.GetRangeByHeight(ctx, genesis, headers[len(headers)-1].Height()+1)
+		require.NoError(t, err)
+		require.Len(t, rng, len(headers))
+		for i, h := range rng {
+			require.NoError(t, h.Validate())
+			require.Equal(t, headers[i].Hash(), h.Hash())
+		}
+		require.NoError(t, ex.Stop(ctx))
+	}
+}
@@ -28,6 +28,28 @@ type Config struct {
 	// Note: The trusted does *not* imply Headers are not verified, but trusted as reliable to fetch
 	// headers at any moment.
 	TrustedPeers []string
+	// WeakSubjectivity makes the node refuse to sync from a stored head older than the trusting
+	// period of the Syncer, instead of trusting the head given by the trusted peers. Syncing resumes
+	// once the node is re-anchored to a fresh checkpoint: TrustedHash, CheckpointFile or one supplied
+	// with the SetCheckpoint RPC.
+	WeakSubjectivity bool
+	// CheckpointFile is the path to a JSON encoded checkpoint to re-anchor the node with.
+	CheckpointFile string
+	// CheckpointSigners are peer IDs of the parties trusted to sign checkpoints. If set, checkpoints
+	// of CheckpointFile and the SetCheckpoint RPC must be signed by one of them.
+	CheckpointSigners []string
+
+	// Pruning configures pruning of old headers on light nodes.
+	Pruning PruningConfig
+
+	// CompactStore makes the header store write headers in the compact encoding, storing each
+	// distinct validator set once. Headers in both encodings are always readable, so it can be
+	// disabled at any time, but versions not supporting the compact encoding can't read the
+	// headers written while it was enabled.
+	CompactStore bool
+	// CompactExchange makes the header exchange request header ranges omitting the repeated
+	// validator sets from the peers serving them, falling back to the legacy ranges otherwise.
+	CompactExchange bool
 
 	Store  store.Parameters
 	Syncer sync.Parameters
@@ -38,12 +60,16 @@ type Config struct {
 
 func DefaultConfig(tp node.Type) Config {
 	cfg := Config{
-		TrustedHash:  "",
-		TrustedPeers: make([]string, 0),
-		Store:        store.DefaultParameters(),
-		Syncer:       sync.DefaultParameters(),
-		Server:       p2p_exchange.DefaultServerParameters(),
-		Client:       p2p_exchange.DefaultClientParameters(),
+		TrustedHash:       "",
+		TrustedPeers:      make([]string, 0),
+		CheckpointSigners: make([]string, 0),
+		Pruning:           defaultPruningConfig(),
+		CompactStore:      false,
+		CompactExchange:   false,
+		Store:             store.DefaultParameters(),
+		Syncer:            sync.DefaultParameters(),
+		Server:            p2p_exchange.DefaultServerParameters(),
+		Client:            p2p_exchange.DefaultClientParameters(),
 	}
 
 	switch tp {
@@ -110,8 +136,19 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("module/header: misconfiguration of p2p exchange server: %w", err)
 	}
 
+	if _, err = parseSigners(cfg.CheckpointSigners); err != nil {
+		return fmt.Errorf("module/header: %w", err)
+	}
+
+	if cfg.Pruning.Enabled && tp != node.Light {
+		return fmt.Errorf("module/header: header pruning is only supported by light nodes")
+	}
+
 	// we do not create a client for bridge nodes
 	if tp == node.Bridge {
+		if cfg.WeakSubjectivity {
+			return fmt.Errorf("module/header: weak subjectivity is not supported by bridge nodes")
+		}
 		return nil
 	}
 
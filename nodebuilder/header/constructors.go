@@ -51,6 +51,9 @@ func newP2PExchange[H libhead.Header[H]](
 		opts = append(opts, p2p.WithMetrics[p2p.ClientParameters]())
 	}
 
+	if cfg.CompactExchange {
+		host = newCompactExchangeHost(host, network.String())
+	}
 	exchange, err := p2p.NewExchange[H](host, ids, conngater, opts...)
 	if err != nil {
 		return nil, err
@@ -69,6 +72,7 @@ func newP2PExchange[H libhead.Header[H]](
 // newSyncer constructs new Syncer for headers.
 func newSyncer[H libhead.Header[H]](
 	ex libhead.Exchange[H],
+	ws *weakSubjectivity[H],
 	store libhead.Store[H],
 	sub libhead.Subscriber[H],
 	cfg Config,
@@ -78,7 +82,8 @@ func newSyncer[H libhead.Header[H]](
 		opts = append(opts, sync.WithMetrics())
 	}
 
-	syncer, err := sync.NewSyncer[H](ex, store, sub, opts...)
+	getter := &checkpointGetter[H]{Exchange: ex, ws: ws}
+	syncer, err := sync.NewSyncer[H](getter, store, sub, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -86,15 +91,30 @@ func newSyncer[H libhead.Header[H]](
 	return syncer, nil
 }
 
+// newFraudedSyncer wraps the Syncer into ServiceBreaker. The Syncer is started only once the
+// weak subjectivity check is satisfied.
 func newFraudedSyncer[H libhead.Header[H]](
+	lc fx.Lifecycle,
+	ws *weakSubjectivity[H],
 	fservice libfraud.Service[H],
 	syncer *sync.Syncer[H],
+	halts *modfraud.HaltRecorder,
 ) *modfraud.ServiceBreaker[*sync.Syncer[H], H] {
-	return &modfraud.ServiceBreaker[*sync.Syncer[H], H]{
+	breaker := &modfraud.ServiceBreaker[*sync.Syncer[H], H]{
 		Service:   syncer,
 		FraudType: byzantine.BadEncoding,
 		FraudServ: fservice,
+		Halts:     halts,
 	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return ws.startWhenAnchored(ctx, breaker.Start)
+		},
+		OnStop: func(ctx context.Context) error {
+			return ws.stopIfStarted(ctx, breaker.Stop)
+		},
+	})
+	return breaker
 }
 
 // newInitStore constructs an initialized store
@@ -104,13 +124,14 @@ func newInitStore[H libhead.Header[H]](
 	net modp2p.Network,
 	ds datastore.Batching,
 	ex libhead.Exchange[H],
+	idx *dataHashIndex,
 ) (libhead.Store[H], error) {
 	opts := []store.Option{store.WithParams(cfg.Store)}
 	if MetricsEnabled {
 		opts = append(opts, store.WithMetrics())
 	}
 
-	s, err := store.NewStore[H](ds, opts...)
+	s, err := store.NewStore[H](newCompactDatastore(ds, cfg.CompactStore), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,9 +141,10 @@ func newInitStore[H libhead.Header[H]](
 		return nil, err
 	}
 
+	is := &indexedStore[H]{Store: s, idx: idx}
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			err = store.Init[H](ctx, s, ex, trustedHash)
+			err = store.Init[H](ctx, is, ex, trustedHash)
 			if err != nil {
 				return err
 			}
@@ -133,5 +155,5 @@ func newInitStore[H libhead.Header[H]](
 		},
 	})
 
-	return s, nil
+	return is, nil
 }
@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"time"
 
 	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/go-header/sync"
@@ -30,6 +31,13 @@ type Module interface {
 	// GetByHeight returns the ExtendedHeader at the given height if it is
 	// currently available.
 	GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error)
+	// GetByTime returns the last ExtendedHeader at or before the given time from the node's
+	// header store.
+	GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error)
+	// GetByDataHash returns the ExtendedHeader with the given DataHash, i.e. the hash of the
+	// DataAvailabilityHeader, from the node's header store. If several headers have the same
+	// DataHash, e.g. the ones of empty blocks, the highest one is returned.
+	GetByDataHash(ctx context.Context, dataHash libhead.Hash) (*header.ExtendedHeader, error)
 	// WaitForHeight blocks until the header at the given height has been processed
 	// by the store or context deadline is exceeded.
 	WaitForHeight(context.Context, uint64) (*header.ExtendedHeader, error)
@@ -43,6 +51,12 @@ type Module interface {
 
 	// Subscribe to recent ExtendedHeaders from the network.
 	Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error)
+
+	// SetCheckpoint re-anchors the node to the given weak-subjectivity checkpoint, resuming syncing
+	// paused because the stored head is older than the trusting period. The checkpointed header must
+	// be within the trusting period, and the checkpoint must be signed if checkpoint signers are
+	// configured.
+	SetCheckpoint(ctx context.Context, cp *Checkpoint) error
 }
 
 // API is a wrapper around Module for the RPC.
@@ -59,12 +73,15 @@ type API struct {
 			*header.ExtendedHeader,
 			uint64,
 		) ([]*header.ExtendedHeader, error) `perm:"read"`
-		GetByHeight   func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
-		WaitForHeight func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
-		SyncState     func(ctx context.Context) (sync.State, error)                    `perm:"read"`
-		SyncWait      func(ctx context.Context) error                                  `perm:"read"`
-		NetworkHead   func(ctx context.Context) (*header.ExtendedHeader, error)        `perm:"read"`
-		Subscribe     func(ctx context.Context) (<-chan *header.ExtendedHeader, error) `perm:"read"`
+		GetByHeight   func(context.Context, uint64) (*header.ExtendedHeader, error)       `perm:"read"`
+		GetByTime     func(context.Context, time.Time) (*header.ExtendedHeader, error)    `perm:"read"`
+		GetByDataHash func(context.Context, libhead.Hash) (*header.ExtendedHeader, error) `perm:"read"`
+		WaitForHeight func(context.Context, uint64) (*header.ExtendedHeader, error)       `perm:"read"`
+		SyncState     func(ctx context.Context) (sync.State, error)                       `perm:"read"`
+		SyncWait      func(ctx context.Context) error                                     `perm:"read"`
+		NetworkHead   func(ctx context.Context) (*header.ExtendedHeader, error)           `perm:"read"`
+		Subscribe     func(ctx context.Context) (<-chan *header.ExtendedHeader, error)    `perm:"read"`
+		SetCheckpoint func(ctx context.Context, cp *Checkpoint) error                     `perm:"admin"`
 	}
 }
 
@@ -84,6 +101,14 @@ func (api *API) GetByHeight(ctx context.Context, u uint64) (*header.ExtendedHead
 	return api.Internal.GetByHeight(ctx, u)
 }
 
+func (api *API) GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	return api.Internal.GetByTime(ctx, t)
+}
+
+func (api *API) GetByDataHash(ctx context.Context, dataHash libhead.Hash) (*header.ExtendedHeader, error) {
+	return api.Internal.GetByDataHash(ctx, dataHash)
+}
+
 func (api *API) WaitForHeight(ctx context.Context, u uint64) (*header.ExtendedHeader, error) {
 	return api.Internal.WaitForHeight(ctx, u)
 }
@@ -107,3 +132,7 @@ func (api *API) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error)
 func (api *API) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
 	return api.Internal.Subscribe(ctx)
 }
+
+func (api *API) SetCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	return api.Internal.SetCheckpoint(ctx, cp)
+}
@@ -0,0 +1,129 @@
+package header
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var dataHashIndexPrefix = datastore.NewKey("header-index/datahash")
+
+// dataHashIndex maps the DataHashes of the headers written to the header store to their heights.
+// If several headers have the same DataHash, e.g. the ones of empty blocks, the highest one is
+// indexed. Headers stored before the index existed are not indexed.
+type dataHashIndex struct {
+	ds datastore.Batching
+}
+
+func newDataHashIndex(ds datastore.Batching) *dataHashIndex {
+	return &dataHashIndex{ds: namespace.Wrap(ds, dataHashIndexPrefix)}
+}
+
+// index adds the given headers to the index.
+func (idx *dataHashIndex) index(ctx context.Context, headers ...*header.ExtendedHeader) error {
+	batch, err := idx.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range headers {
+		val := make([]byte, 8)
+		binary.BigEndian.PutUint64(val, h.Height())
+		if err := batch.Put(ctx, dataHashKey(h.DataHash), val); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// unindex removes the given headers from the index. DataHashes indexed with the heights of other
+// headers are kept.
+func (idx *dataHashIndex) unindex(ctx context.Context, headers ...*header.ExtendedHeader) error {
+	batch, err := idx.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range headers {
+		height, err := idx.height(ctx, h.DataHash)
+		if errors.Is(err, libhead.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if height != h.Height() {
+			continue
+		}
+		if err := batch.Delete(ctx, dataHashKey(h.DataHash)); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// height returns the height of the header with the given DataHash.
+func (idx *dataHashIndex) height(ctx context.Context, dataHash []byte) (uint64, error) {
+	val, err := idx.ds.Get(ctx, dataHashKey(dataHash))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return 0, libhead.ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("header: getting height of DataHash %X: %w", dataHash, err)
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func dataHashKey(dataHash []byte) datastore.Key {
+	return datastore.NewKey(libhead.Hash(dataHash).String())
+}
+
+// indexedStore is a header store indexing the headers written to it by their DataHashes.
+type indexedStore[H libhead.Header[H]] struct {
+	libhead.Store[H]
+	idx *dataHashIndex
+}
+
+func (s *indexedStore[H]) Init(ctx context.Context, initial H) error {
+	if err := s.Store.Init(ctx, initial); err != nil {
+		return err
+	}
+
+	s.index(ctx, initial)
+	return nil
+}
+
+func (s *indexedStore[H]) Append(ctx context.Context, headers ...H) error {
+	if err := s.Store.Append(ctx, headers...); err != nil {
+		return err
+	}
+
+	s.index(ctx, headers...)
+	return nil
+}
+
+// index indexes the given headers. The headers are stored already, so failing to index them is
+// only logged.
+func (s *indexedStore[H]) index(ctx context.Context, headers ...H) {
+	if err := s.idx.index(ctx, extendedHeaders(headers)...); err != nil {
+		log.Errorw("indexing DataHashes", "err", err)
+	}
+}
+
+func extendedHeaders[H libhead.Header[H]](headers []H) []*header.ExtendedHeader {
+	ehs := make([]*header.ExtendedHeader, 0, len(headers))
+	for _, h := range headers {
+		if eh, ok := any(h).(*header.ExtendedHeader); ok {
+			ehs = append(ehs, eh)
+		}
+	}
+	return ehs
+}
@@ -7,8 +7,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	header "github.com/celestiaorg/celestia-node/header"
+	header1 "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	header0 "github.com/celestiaorg/go-header"
 	sync "github.com/celestiaorg/go-header/sync"
 	gomock "github.com/golang/mock/gomock"
@@ -37,6 +39,21 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// GetByDataHash mocks base method.
+func (m *MockModule) GetByDataHash(arg0 context.Context, arg1 header0.Hash) (*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDataHash", arg0, arg1)
+	ret0, _ := ret[0].(*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDataHash indicates an expected call of GetByDataHash.
+func (mr *MockModuleMockRecorder) GetByDataHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataHash", reflect.TypeOf((*MockModule)(nil).GetByDataHash), arg0, arg1)
+}
+
 // GetByHash mocks base method.
 func (m *MockModule) GetByHash(arg0 context.Context, arg1 header0.Hash) (*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +84,21 @@ func (mr *MockModuleMockRecorder) GetByHeight(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockModule)(nil).GetByHeight), arg0, arg1)
 }
 
+// GetByTime mocks base method.
+func (m *MockModule) GetByTime(arg0 context.Context, arg1 time.Time) (*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTime", arg0, arg1)
+	ret0, _ := ret[0].(*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTime indicates an expected call of GetByTime.
+func (mr *MockModuleMockRecorder) GetByTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTime", reflect.TypeOf((*MockModule)(nil).GetByTime), arg0, arg1)
+}
+
 // GetRangeByHeight mocks base method.
 func (m *MockModule) GetRangeByHeight(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 uint64) ([]*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +144,20 @@ func (mr *MockModuleMockRecorder) NetworkHead(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkHead", reflect.TypeOf((*MockModule)(nil).NetworkHead), arg0)
 }
 
+// SetCheckpoint mocks base method.
+func (m *MockModule) SetCheckpoint(arg0 context.Context, arg1 *header1.Checkpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCheckpoint indicates an expected call of SetCheckpoint.
+func (mr *MockModuleMockRecorder) SetCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckpoint", reflect.TypeOf((*MockModule)(nil).SetCheckpoint), arg0, arg1)
+}
+
 // Subscribe mocks base method.
 func (m *MockModule) Subscribe(arg0 context.Context) (<-chan *header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -11,11 +11,10 @@ import (
 
 	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/go-header/p2p"
-	"github.com/celestiaorg/go-header/sync"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/fxutil"
 	"github.com/celestiaorg/celestia-node/libs/pidstore"
-	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
@@ -31,25 +30,13 @@ func ConstructModule[H libhead.Header[H]](tp node.Type, cfg *Config) fx.Option {
 		fx.Error(cfgErr),
 		fx.Provide(newHeaderService),
 		fx.Provide(newInitStore[H]),
+		fx.Provide(newDataHashIndex),
 		fx.Provide(func(subscriber *p2p.Subscriber[H]) libhead.Subscriber[H] {
 			return subscriber
 		}),
+		fx.Provide(newWeakSubjectivity[H]),
 		fx.Provide(newSyncer[H]),
-		fx.Provide(fx.Annotate(
-			newFraudedSyncer[H],
-			fx.OnStart(func(
-				ctx context.Context,
-				breaker *modfraud.ServiceBreaker[*sync.Syncer[H], H],
-			) error {
-				return breaker.Start(ctx)
-			}),
-			fx.OnStop(func(
-				ctx context.Context,
-				breaker *modfraud.ServiceBreaker[*sync.Syncer[H], H],
-			) error {
-				return breaker.Stop(ctx)
-			}),
-		)),
+		fx.Provide(newFraudedSyncer[H]),
 		fx.Provide(fx.Annotate(
 			func(ps *pubsub.PubSub, network modp2p.Network) (*p2p.Subscriber[H], error) {
 				opts := []p2p.SubscriberOption{p2p.WithSubscriberNetworkID(network.String())}
@@ -67,20 +54,33 @@ func ConstructModule[H libhead.Header[H]](tp node.Type, cfg *Config) fx.Option {
 		)),
 		fx.Provide(fx.Annotate(
 			func(
+				lc fx.Lifecycle,
 				cfg Config,
 				host host.Host,
 				store libhead.Store[H],
 				network modp2p.Network,
 			) (*p2p.ExchangeServer[H], error) {
-				opts := []p2p.Option[p2p.ServerParameters]{
-					p2p.WithParams(cfg.Server),
-					p2p.WithNetworkID[p2p.ServerParameters](network.String()),
-				}
-				if MetricsEnabled {
-					opts = append(opts, p2p.WithMetrics[p2p.ServerParameters]())
+				newServer := func(networkID string, store libhead.Store[H]) (*p2p.ExchangeServer[H], error) {
+					opts := []p2p.Option[p2p.ServerParameters]{
+						p2p.WithParams(cfg.Server),
+						p2p.WithNetworkID[p2p.ServerParameters](networkID),
+					}
+					if MetricsEnabled {
+						opts = append(opts, p2p.WithMetrics[p2p.ServerParameters]())
+					}
+					return p2p.NewExchangeServer[H](host, store, opts...)
 				}
 
-				return p2p.NewExchangeServer[H](host, store, opts...)
+				// compact header ranges are served under a protocol ID of their own
+				compact, err := newServer(network.String()+compactNetworkSuffix, &compactRangeStore[H]{Store: store})
+				if err != nil {
+					return nil, err
+				}
+				lc.Append(fx.Hook{
+					OnStart: compact.Start,
+					OnStop:  compact.Stop,
+				})
+				return newServer(network.String(), store)
 			},
 			fx.OnStart(func(ctx context.Context, server *p2p.ExchangeServer[H]) error {
 				return server.Start(ctx)
@@ -100,6 +100,7 @@ func ConstructModule[H libhead.Header[H]](tp node.Type, cfg *Config) fx.Option {
 			fx.Provide(func(ctx context.Context, ds datastore.Batching) (p2p.PeerIDStore, error) {
 				return pidstore.NewPeerIDStore(ctx, ds)
 			}),
+			fxutil.ProvideIf(cfg.Pruning.Enabled, newHeaderPruner[H]),
 		)
 	case node.Bridge:
 		return fx.Module(
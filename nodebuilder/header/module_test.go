@@ -47,7 +47,8 @@ func TestConstructModule_StoreParams(t *testing.T) {
 		ConstructModule[*header.ExtendedHeader](node.Light, &cfg),
 		fx.Invoke(
 			func(s libhead.Store[*header.ExtendedHeader]) {
-				ss := s.(*store.Store[*header.ExtendedHeader])
+				is := s.(*indexedStore[*header.ExtendedHeader])
+				ss := is.Store.(*store.Store[*header.ExtendedHeader])
 				headerStore = ss
 			}),
 	)
@@ -0,0 +1,213 @@
+package header
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/pruner"
+)
+
+var (
+	// storePrefix is the default prefix of the header store in the datastore, see go-header's store.
+	storePrefix = datastore.NewKey("headers")
+
+	prunerPrefix  = datastore.NewKey("header-pruner")
+	lastPrunedKey = datastore.NewKey("last_pruned")
+)
+
+// pruneBatchSize is the amount of headers deleted from the datastore in one batch.
+const pruneBatchSize = 1024
+
+// PruningConfig configures pruning of the headers outside the retention window from the header
+// store of a light node.
+type PruningConfig struct {
+	// Enabled turns on header pruning. Headers are pruned along with the data by the pruner, so it
+	// requires pruning to be enabled as well.
+	Enabled bool
+	// RetentionWindow is the period of the most recent headers kept in the header store. It must
+	// not be shorter than the availability window. Zero means the availability window.
+	RetentionWindow time.Duration
+	// CheckpointInterval is the height interval of the headers kept in the store as checkpoints
+	// regardless of the retention window. Zero keeps no checkpoints.
+	CheckpointInterval uint64
+}
+
+func defaultPruningConfig() PruningConfig {
+	return PruningConfig{
+		Enabled:            false,
+		RetentionWindow:    0,
+		CheckpointInterval: 10_000,
+	}
+}
+
+// headerPruner deletes the headers outside the retention window from the header store, except for
+// the checkpoints. It is run by pruner.Service after pruning the data.
+//
+// NOTE: go-header's store does not support deletion, so the headers are deleted from the
+// datastore directly. Headers cached by the store may still be served until evicted.
+type headerPruner[H libhead.Header[H]] struct {
+	retention          time.Duration
+	checkpointInterval uint64
+
+	store libhead.Store[H]
+	idx   *dataHashIndex
+	// ds is the datastore of the header store
+	ds datastore.Batching
+	// meta keeps the progress of the headerPruner
+	meta datastore.Datastore
+
+	lastPruned uint64
+}
+
+func newHeaderPruner[H libhead.Header[H]](
+	cfg Config,
+	window pruner.AvailabilityWindow,
+	store libhead.Store[H],
+	idx *dataHashIndex,
+	ds datastore.Batching,
+) (pruner.HeaderPruner, error) {
+	retention := cfg.Pruning.RetentionWindow
+	if retention == 0 {
+		retention = window.Duration()
+	}
+	if retention < window.Duration() {
+		return nil, fmt.Errorf("header: retention window %s is shorter than the availability window %s",
+			retention, window.Duration())
+	}
+
+	return &headerPruner[H]{
+		retention:          retention,
+		checkpointInterval: cfg.Pruning.CheckpointInterval,
+		store:              store,
+		idx:                idx,
+		ds:                 namespace.Wrap(ds, storePrefix),
+		meta:               namespace.Wrap(ds, prunerPrefix),
+	}, nil
+}
+
+// PruneHeaders deletes the headers below the given height that are outside the retention window.
+func (hp *headerPruner[H]) PruneHeaders(ctx context.Context, to uint64) error {
+	if hp.lastPruned == 0 {
+		lastPruned, err := hp.loadLastPruned(ctx)
+		if err != nil {
+			return err
+		}
+		hp.lastPruned = lastPruned
+	}
+
+	from := hp.lastPruned + 1
+	if from >= to {
+		return nil
+	}
+	to, err := hp.cutoff(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	for from < to {
+		batchTo := min(from+pruneBatchSize, to)
+		if err := hp.deleteRange(ctx, from, batchTo); err != nil {
+			return err
+		}
+		if err := hp.storeLastPruned(ctx, batchTo-1); err != nil {
+			return err
+		}
+		log.Debugw("pruned headers", "from", from, "to", batchTo-1)
+		from = batchTo
+	}
+	return nil
+}
+
+// cutoff returns the lowest height in the [from:to) range that is within the retention window, or
+// 'to' if there is none.
+func (hp *headerPruner[H]) cutoff(ctx context.Context, from, to uint64) (uint64, error) {
+	var err error
+	// heights are ordered by time, so the first header within the window is found with binary search
+	i := sort.Search(int(to-from), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var h H
+		h, err = hp.store.GetByHeight(ctx, from+uint64(i))
+		return err == nil && time.Since(h.Time()) <= hp.retention
+	})
+	if err != nil {
+		return 0, fmt.Errorf("header: finding retention window cutoff: %w", err)
+	}
+	return from + uint64(i), nil
+}
+
+// deleteRange deletes the headers in the [from:to) range, except for the checkpoints, along with
+// their DataHash index entries.
+func (hp *headerPruner[H]) deleteRange(ctx context.Context, from, to uint64) error {
+	batch, err := hp.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted := make([]H, 0, to-from)
+	for height := from; height < to; height++ {
+		if hp.checkpointInterval != 0 && height%hp.checkpointInterval == 0 {
+			continue
+		}
+
+		heightKey := datastore.NewKey(strconv.FormatUint(height, 10))
+		hash, err := hp.ds.Get(ctx, heightKey)
+		if errors.Is(err, datastore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("header: getting hash of header %d: %w", height, err)
+		}
+
+		h, err := hp.store.Get(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("header: getting header %d: %w", height, err)
+		}
+		deleted = append(deleted, h)
+
+		if err := batch.Delete(ctx, datastore.NewKey(libhead.Hash(hash).String())); err != nil {
+			return err
+		}
+		if err := batch.Delete(ctx, heightKey); err != nil {
+			return err
+		}
+	}
+	// the index entries go first, so that they are not left behind if deleting the headers fails
+	if err := hp.idx.unindex(ctx, extendedHeaders(deleted)...); err != nil {
+		return fmt.Errorf("header: unindexing headers: %w", err)
+	}
+	return batch.Commit(ctx)
+}
+
+func (hp *headerPruner[H]) loadLastPruned(ctx context.Context) (uint64, error) {
+	val, err := hp.meta.Get(ctx, lastPrunedKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		// genesis is not pruned
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("header: loading last pruned header height: %w", err)
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func (hp *headerPruner[H]) storeLastPruned(ctx context.Context, height uint64) error {
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, height)
+	if err := hp.meta.Put(ctx, lastPrunedKey, val); err != nil {
+		return fmt.Errorf("header: storing last pruned header height: %w", err)
+	}
+	hp.lastPruned = height
+	return nil
+}
@@ -0,0 +1,92 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/pruner"
+)
+
+func TestHeaderPruner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	// headers 1 to 50 are timestamped an hour apart, from 100 to 51 hours ago
+	suite := headertest.NewTestSuite(t, 3, time.Hour)
+	genesis := suite.Head()
+	genesis.RawHeader.Time = time.Now().Add(-100 * time.Hour)
+	headers := suite.GenExtendedHeaders(49)
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	s, err := store.NewStoreWithHead[*header.ExtendedHeader](ctx, ds, genesis)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	require.NoError(t, s.Append(ctx, headers...))
+	// the blocks are empty, so the DataHash is indexed with the height of the last one, 19
+	idx := newDataHashIndex(ds)
+	require.NoError(t, idx.index(ctx, headers[:18]...))
+	// flushes the headers
+	require.NoError(t, s.Stop(ctx))
+
+	s, err = store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() {
+		_ = s.Stop(ctx)
+	})
+	_, err = s.Head(ctx)
+	require.NoError(t, err)
+
+	cfg := DefaultConfig(node.Light)
+	cfg.Pruning.Enabled = true
+	cfg.Pruning.RetentionWindow = 60*time.Hour + 30*time.Minute
+	cfg.Pruning.CheckpointInterval = 10
+
+	_, err = newHeaderPruner[*header.ExtendedHeader](cfg, pruner.AvailabilityWindow(61*time.Hour), s, idx, ds)
+	require.Error(t, err)
+	hp, err := newHeaderPruner[*header.ExtendedHeader](cfg, pruner.AvailabilityWindow(48*time.Hour), s, idx, ds)
+	require.NoError(t, err)
+
+	// headers from 41 are within the retention window
+	require.NoError(t, hp.PruneHeaders(ctx, 45))
+	require.NoError(t, hp.PruneHeaders(ctx, 50))
+
+	// reopen the store to drop the caches
+	require.NoError(t, s.Stop(ctx))
+	s, err = store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	_, err = s.Head(ctx)
+	require.NoError(t, err)
+
+	for height := uint64(1); height <= 50; height++ {
+		_, err := s.GetByHeight(ctx, height)
+		kept := height == 1 || height%10 == 0 || height >= 41
+		if kept {
+			require.NoError(t, err, height)
+		} else {
+			require.ErrorIs(t, err, libhead.ErrNotFound, height)
+		}
+	}
+
+	// the index entries of the pruned headers are deleted
+	_, err = idx.height(ctx, headers[0].DataHash)
+	require.ErrorIs(t, err, libhead.ErrNotFound)
+
+	// progress is persisted
+	hp, err = newHeaderPruner[*header.ExtendedHeader](cfg, pruner.AvailabilityWindow(48*time.Hour), s, idx, ds)
+	require.NoError(t, err)
+	require.NoError(t, hp.PruneHeaders(ctx, 50))
+	require.EqualValues(t, 40, hp.(*headerPruner[*header.ExtendedHeader]).lastPruned)
+}
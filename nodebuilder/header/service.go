@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/go-header/p2p"
@@ -23,6 +24,8 @@ type Service struct {
 	sub       libhead.Subscriber[*header.ExtendedHeader]
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader]
 	store     libhead.Store[*header.ExtendedHeader]
+	ws        *weakSubjectivity[*header.ExtendedHeader]
+	idx       *dataHashIndex
 }
 
 // syncer bare minimum Syncer interface for testing
@@ -41,6 +44,8 @@ func newHeaderService(
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader],
 	ex libhead.Exchange[*header.ExtendedHeader],
 	store libhead.Store[*header.ExtendedHeader],
+	ws *weakSubjectivity[*header.ExtendedHeader],
+	idx *dataHashIndex,
 ) Module {
 	return &Service{
 		syncer:    syncer.Service,
@@ -48,6 +53,8 @@ func newHeaderService(
 		p2pServer: p2pServer,
 		ex:        ex,
 		store:     store,
+		ws:        ws,
+		idx:       idx,
 	}
 }
 
@@ -92,6 +99,25 @@ func (s *Service) GetByHeight(ctx context.Context, height uint64) (*header.Exten
 	}
 }
 
+func (s *Service) GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	head, err := s.store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !head.Time().After(t) {
+		return head, nil
+	}
+	return header.FindByTime(ctx, s.store, 1, head.Height(), t)
+}
+
+func (s *Service) GetByDataHash(ctx context.Context, dataHash libhead.Hash) (*header.ExtendedHeader, error) {
+	height, err := s.idx.height(ctx, dataHash)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetByHeight(ctx, height)
+}
+
 func (s *Service) WaitForHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
 	return s.store.GetByHeight(ctx, height)
 }
@@ -141,3 +167,7 @@ func (s *Service) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader,
 	}()
 	return headerCh, nil
 }
+
+func (s *Service) SetCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	return s.ws.setCheckpoint(ctx, cp)
+}
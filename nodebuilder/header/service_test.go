@@ -1,16 +1,23 @@
 package header
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
 	"github.com/celestiaorg/go-header/sync"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
 )
 
 func TestGetByHeightHandlesError(t *testing.T) {
@@ -39,3 +46,73 @@ func (d *errorSyncer[H]) State() sync.State {
 func (d *errorSyncer[H]) SyncWait(context.Context) error {
 	return fmt.Errorf("dummy error")
 }
+
+func TestGetByTimeAndDataHash(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	// headers 1 to 20 are timestamped an hour apart
+	suite := headertest.NewTestSuite(t, 3, time.Hour)
+	genesis := suite.Head()
+	genesis.RawHeader.Time = time.Now().Add(-100 * time.Hour)
+	headers := suite.GenExtendedHeaders(19)
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	s, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	idx := newDataHashIndex(ds)
+	is := &indexedStore[*header.ExtendedHeader]{Store: s, idx: idx}
+	require.NoError(t, is.Init(ctx, genesis))
+	height, err := idx.height(ctx, genesis.DataHash)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, height)
+	require.NoError(t, is.Append(ctx, headers...))
+	// flushes the headers
+	require.NoError(t, s.Stop(ctx))
+
+	s, err = store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() {
+		_ = s.Stop(ctx)
+	})
+	serv := Service{store: s, idx: idx}
+
+	_, err = serv.GetByTime(ctx, genesis.Time().Add(-time.Second))
+	require.ErrorIs(t, err, libhead.ErrNotFound)
+	h, err := serv.GetByTime(ctx, genesis.Time())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, h.Height())
+	h, err = serv.GetByTime(ctx, headers[8].Time())
+	require.NoError(t, err)
+	require.EqualValues(t, 10, h.Height())
+	h, err = serv.GetByTime(ctx, headers[8].Time().Add(time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 10, h.Height())
+	h, err = serv.GetByTime(ctx, time.Now())
+	require.NoError(t, err)
+	require.EqualValues(t, 20, h.Height())
+
+	// the blocks are empty, so the highest one is indexed
+	h, err = serv.GetByDataHash(ctx, libhead.Hash(headers[0].DataHash))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, h.Height())
+	other := headertest.RandExtendedHeader(t)
+	other.DataHash = bytes.Repeat([]byte{1}, 32)
+	_, err = serv.GetByDataHash(ctx, libhead.Hash(other.DataHash))
+	require.ErrorIs(t, err, libhead.ErrNotFound)
+
+	require.NoError(t, idx.index(ctx, other))
+	height, err = idx.height(ctx, other.DataHash)
+	require.NoError(t, err)
+	require.Equal(t, other.Height(), height)
+
+	// the DataHash indexed with another height is kept
+	require.NoError(t, idx.unindex(ctx, headers[0]))
+	_, err = idx.height(ctx, headers[0].DataHash)
+	require.NoError(t, err)
+	require.NoError(t, idx.unindex(ctx, other))
+	_, err = idx.height(ctx, other.DataHash)
+	require.ErrorIs(t, err, libhead.ErrNotFound)
+}
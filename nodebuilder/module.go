@@ -2,6 +2,7 @@ package nodebuilder
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/fx"
 
@@ -29,6 +30,10 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		return fx.Error(err)
 	}
 
+	if cfg.Header.Pruning.Enabled && !cfg.Pruner.EnableService {
+		return fx.Error(errors.New("header pruning requires pruning to be enabled, run with --experimental-pruning"))
+	}
+
 	baseComponents := fx.Options(
 		fx.Supply(tp),
 		fx.Supply(network),
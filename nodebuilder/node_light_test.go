@@ -54,3 +54,16 @@ func TestLight_WithStubbedCoreAccessor(t *testing.T) {
 	_, err := node.StateServ.Balance(context.Background())
 	assert.ErrorIs(t, state.ErrNoStateAccess, err)
 }
+
+func TestLight_WithHeaderPruning(t *testing.T) {
+	cfg := DefaultConfig(nodebuilder.Light)
+	cfg.Header.Pruning.Enabled = true
+	cfg.Pruner.EnableService = true
+	node := TestNodeWithConfig(t, nodebuilder.Light, cfg)
+	require.NotNil(t, node)
+
+	// header pruning requires the pruner
+	cfg.Pruner.EnableService = false
+	_, err := New(nodebuilder.Light, p2p.Private, MockStore(t, cfg))
+	require.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -34,7 +36,13 @@ func init() {
 		peerBandwidthCmd,
 		bandwidthForProtocolCmd,
 		pubsubPeersCmd,
+		peerScoresCmd,
+		blacklistCmd,
+		blacklistPeerCmd,
+		clearBlacklistCmd,
 	)
+	blacklistPeerCmd.Flags().Duration("ttl", 24*time.Hour,
+		"time the peer stays blacklisted. Zero blacklists the peer permanently")
 }
 
 var Cmd = &cobra.Command{
@@ -574,3 +582,88 @@ var pubsubPeersCmd = &cobra.Command{
 		return cmdnode.PrintOutput(peers, err, formatter)
 	},
 }
+
+var peerScoresCmd = &cobra.Command{
+	Use:   "peer-scores",
+	Short: "Lists the scores of share exchange peers used to weight peer selection",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		scores, err := client.P2P.PeerScores(cmd.Context())
+		return cmdnode.PrintOutput(scores, err, nil)
+	},
+}
+
+var blacklistCmd = &cobra.Command{
+	Use:   "blacklist",
+	Short: "Lists the peers blacklisted from share exchange",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		entries, err := client.P2P.Blacklist(cmd.Context())
+		return cmdnode.PrintOutput(entries, err, nil)
+	},
+}
+
+var blacklistPeerCmd = &cobra.Command{
+	Use:   "blacklist-peer [peer.ID] [reason]",
+	Short: "Blacklists the given peer. The peer stays blocked across restarts until the entry expires",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		pid, err := peer.Decode(args[0])
+		if err != nil {
+			return err
+		}
+
+		ttl, err := cmd.Flags().GetDuration("ttl")
+		if err != nil {
+			return err
+		}
+
+		err = client.P2P.BlacklistPeer(cmd.Context(), pid, args[1], ttl)
+		if err != nil {
+			return cmdnode.PrintOutput(nil, err, nil)
+		}
+		return blacklistCmd.RunE(cmd, nil)
+	},
+}
+
+var clearBlacklistCmd = &cobra.Command{
+	Use:   "clear-blacklist [peer.ID]",
+	Short: "Removes the given peer from the blacklist and unblocks it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		pid, err := peer.Decode(args[0])
+		if err != nil {
+			return err
+		}
+
+		err = client.P2P.ClearBlacklist(cmd.Context(), pid)
+		if err != nil {
+			return cmdnode.PrintOutput(nil, err, nil)
+		}
+		return blacklistCmd.RunE(cmd, nil)
+	},
+}
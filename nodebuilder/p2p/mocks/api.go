@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	metrics "github.com/libp2p/go-libp2p/core/metrics"
@@ -14,6 +15,8 @@ import (
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	protocol "github.com/libp2p/go-libp2p/core/protocol"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+
+	peers "github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
 // MockModule is a mock of Module interface.
@@ -84,6 +87,35 @@ func (mr *MockModuleMockRecorder) BandwidthStats(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BandwidthStats", reflect.TypeOf((*MockModule)(nil).BandwidthStats), arg0)
 }
 
+// Blacklist mocks base method.
+func (m *MockModule) Blacklist(arg0 context.Context) ([]peers.BlacklistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blacklist", arg0)
+	ret0, _ := ret[0].([]peers.BlacklistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Blacklist indicates an expected call of Blacklist.
+func (mr *MockModuleMockRecorder) Blacklist(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blacklist", reflect.TypeOf((*MockModule)(nil).Blacklist), arg0)
+}
+
+// BlacklistPeer mocks base method.
+func (m *MockModule) BlacklistPeer(arg0 context.Context, arg1 peer.ID, arg2 string, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlacklistPeer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlacklistPeer indicates an expected call of BlacklistPeer.
+func (mr *MockModuleMockRecorder) BlacklistPeer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlacklistPeer", reflect.TypeOf((*MockModule)(nil).BlacklistPeer), arg0, arg1, arg2, arg3)
+}
+
 // BlockPeer mocks base method.
 func (m *MockModule) BlockPeer(arg0 context.Context, arg1 peer.ID) error {
 	m.ctrl.T.Helper()
@@ -98,6 +130,20 @@ func (mr *MockModuleMockRecorder) BlockPeer(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockPeer", reflect.TypeOf((*MockModule)(nil).BlockPeer), arg0, arg1)
 }
 
+// ClearBlacklist mocks base method.
+func (m *MockModule) ClearBlacklist(arg0 context.Context, arg1 peer.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearBlacklist", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearBlacklist indicates an expected call of ClearBlacklist.
+func (mr *MockModuleMockRecorder) ClearBlacklist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearBlacklist", reflect.TypeOf((*MockModule)(nil).ClearBlacklist), arg0, arg1)
+}
+
 // ClosePeer mocks base method.
 func (m *MockModule) ClosePeer(arg0 context.Context, arg1 peer.ID) error {
 	m.ctrl.T.Helper()
@@ -216,6 +262,21 @@ func (mr *MockModuleMockRecorder) PeerInfo(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerInfo", reflect.TypeOf((*MockModule)(nil).PeerInfo), arg0, arg1)
 }
 
+// PeerScores mocks base method.
+func (m *MockModule) PeerScores(arg0 context.Context) (map[string][]peers.PeerScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores", arg0)
+	ret0, _ := ret[0].(map[string][]peers.PeerScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MockModuleMockRecorder) PeerScores(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockModule)(nil).PeerScores), arg0)
+}
+
 // Peers mocks base method.
 func (m *MockModule) Peers(arg0 context.Context) ([]peer.ID, error) {
 	m.ctrl.T.Helper()
@@ -33,7 +33,8 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(contentRouting),
 		fx.Provide(addrsFactory(cfg.AnnounceAddresses, cfg.NoAnnounceAddresses)),
 		fx.Provide(metrics.NewBandwidthCounter),
-		fx.Provide(newModule),
+		// peer managers and blacklist are only provided by the share module
+		fx.Provide(fx.Annotate(newModule, fx.ParamTags("", "", "", "", "", `optional:"true"`, `optional:"true"`))),
 		fx.Invoke(Listen(cfg.ListenAddresses)),
 		fx.Provide(resourceManager),
 		fx.Provide(resourceManagerOpt(allowList)),
@@ -2,8 +2,10 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	libhost "github.com/libp2p/go-libp2p/core/host"
@@ -14,6 +16,8 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/host/autonat"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
 var _ Module = (*API)(nil)
@@ -77,8 +81,22 @@ type Module interface {
 	// PubSubPeers returns the peer IDs of the peers joined on
 	// the given topic.
 	PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error)
+
+	// PeerScores returns the scores share exchange peer managers keep for the peers they
+	// requested data from, keyed by the manager tag.
+	PeerScores(context.Context) (map[string][]peers.PeerScore, error)
+
+	// Blacklist returns the peers blacklisted from share exchange along with the reason and expiry.
+	Blacklist(context.Context) ([]peers.BlacklistEntry, error)
+	// BlacklistPeer blocks the given peer for the given ttl and persists the entry, so the peer stays
+	// blocked across restarts. Zero ttl blacklists the peer permanently.
+	BlacklistPeer(ctx context.Context, id peer.ID, reason string, ttl time.Duration) error
+	// ClearBlacklist removes the given peer from the blacklist and unblocks it.
+	ClearBlacklist(ctx context.Context, id peer.ID) error
 }
 
+var errNoBlacklist = errors.New("p2p: peer blacklist is not available")
+
 // module contains all components necessary to access information and
 // perform actions related to the node's p2p Host / operations.
 type module struct {
@@ -87,6 +105,8 @@ type module struct {
 	connGater *conngater.BasicConnectionGater
 	bw        *metrics.BandwidthCounter
 	rm        network.ResourceManager
+	managers  map[string]*peers.Manager
+	blacklist *peers.Blacklist
 }
 
 func newModule(
@@ -95,6 +115,8 @@ func newModule(
 	cg *conngater.BasicConnectionGater,
 	bw *metrics.BandwidthCounter,
 	rm network.ResourceManager,
+	managers map[string]*peers.Manager,
+	blacklist *peers.Blacklist,
 ) Module {
 	return &module{
 		host:      host,
@@ -102,6 +124,8 @@ func newModule(
 		connGater: cg,
 		bw:        bw,
 		rm:        rm,
+		managers:  managers,
+		blacklist: blacklist,
 	}
 }
 
@@ -192,6 +216,35 @@ func (m *module) PubSubPeers(_ context.Context, topic string) ([]peer.ID, error)
 	return m.ps.ListPeers(topic), nil
 }
 
+func (m *module) PeerScores(context.Context) (map[string][]peers.PeerScore, error) {
+	scores := make(map[string][]peers.PeerScore, len(m.managers))
+	for tag, manager := range m.managers {
+		scores[tag] = manager.Scores()
+	}
+	return scores, nil
+}
+
+func (m *module) Blacklist(context.Context) ([]peers.BlacklistEntry, error) {
+	if m.blacklist == nil {
+		return nil, errNoBlacklist
+	}
+	return m.blacklist.List(), nil
+}
+
+func (m *module) BlacklistPeer(ctx context.Context, id peer.ID, reason string, ttl time.Duration) error {
+	if m.blacklist == nil {
+		return errNoBlacklist
+	}
+	return m.blacklist.Add(ctx, id, reason, ttl)
+}
+
+func (m *module) ClearBlacklist(ctx context.Context, id peer.ID) error {
+	if m.blacklist == nil {
+		return errNoBlacklist
+	}
+	return m.blacklist.Remove(ctx, id)
+}
+
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 //
@@ -216,6 +269,10 @@ type API struct {
 		BandwidthForProtocol func(ctx context.Context, proto protocol.ID) (metrics.Stats, error)  `perm:"admin"`
 		ResourceState        func(context.Context) (rcmgr.ResourceManagerStat, error)             `perm:"admin"`
 		PubSubPeers          func(ctx context.Context, topic string) ([]peer.ID, error)           `perm:"admin"`
+		PeerScores           func(context.Context) (map[string][]peers.PeerScore, error)          `perm:"admin"`
+		Blacklist            func(context.Context) ([]peers.BlacklistEntry, error)                `perm:"admin"`
+		BlacklistPeer        func(context.Context, peer.ID, string, time.Duration) error          `perm:"admin"`
+		ClearBlacklist       func(ctx context.Context, id peer.ID) error                          `perm:"admin"`
 	}
 }
 
@@ -290,3 +347,19 @@ func (api *API) ResourceState(ctx context.Context) (rcmgr.ResourceManagerStat, e
 func (api *API) PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error) {
 	return api.Internal.PubSubPeers(ctx, topic)
 }
+
+func (api *API) PeerScores(ctx context.Context) (map[string][]peers.PeerScore, error) {
+	return api.Internal.PeerScores(ctx)
+}
+
+func (api *API) Blacklist(ctx context.Context) ([]peers.BlacklistEntry, error) {
+	return api.Internal.Blacklist(ctx)
+}
+
+func (api *API) BlacklistPeer(ctx context.Context, id peer.ID, reason string, ttl time.Duration) error {
+	return api.Internal.BlacklistPeer(ctx, id, reason, ttl)
+}
+
+func (api *API) ClearBlacklist(ctx context.Context, id peer.ID) error {
+	return api.Internal.ClearBlacklist(ctx, id)
+}
@@ -87,6 +87,9 @@ func pubSub(cfg Config, params pubSubParams) (*pubsub.PubSub, error) {
 		// floodsub(because gossipsub supports floodsub protocol by default).
 		pubsub.WithGossipSubProtocols([]protocol.ID{pubsub.GossipSubID_v11}, pubsub.GossipSubDefaultFeatures),
 	}
+	for _, tracer := range params.Tracers {
+		opts = append(opts, pubsub.WithRawTracer(tracer))
+	}
 
 	return pubsub.NewGossipSub(
 		params.Ctx,
@@ -108,6 +111,7 @@ type pubSubParams struct {
 	Bootstrappers Bootstrappers
 	Network       Network
 	Unmarshaler   fraud.ProofUnmarshaler[*header.ExtendedHeader]
+	Tracers       []pubsub.RawTracer `group:"pubsub-tracers"`
 }
 
 func topicScoreParams(params pubSubParams) map[string]*pubsub.TopicScoreParams {
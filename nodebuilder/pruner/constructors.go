@@ -2,22 +2,32 @@ package pruner
 
 import (
 	"github.com/ipfs/go-datastore"
+	"go.uber.org/fx"
 
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
-	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/pruner"
 )
 
-func newPrunerService(
-	p pruner.Pruner,
-	window pruner.AvailabilityWindow,
-	getter libhead.Store[*header.ExtendedHeader],
-	ds datastore.Batching,
-	opts ...pruner.Option,
-) (*pruner.Service, error) {
-	serv, err := pruner.NewService(p, window, getter, ds, p2p.BlockTime, opts...)
+type prunerServiceParams struct {
+	fx.In
+
+	Pruner pruner.Pruner
+	Window pruner.AvailabilityWindow
+	Getter libhead.Store[*header.ExtendedHeader]
+	DS     datastore.Batching
+	// HeaderPruner is provided by the header module if header pruning is enabled.
+	HeaderPruner pruner.HeaderPruner `optional:"true"`
+}
+
+func newPrunerService(params prunerServiceParams) (*pruner.Service, error) {
+	var opts []pruner.Option
+	if params.HeaderPruner != nil {
+		opts = append(opts, pruner.WithHeaderPruner(params.HeaderPruner))
+	}
+
+	serv, err := pruner.NewService(params.Pruner, params.Window, params.Getter, params.DS, opts...)
 	if err != nil {
 		return nil, err
 	}
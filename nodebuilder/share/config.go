@@ -3,9 +3,13 @@ package share
 import (
 	"fmt"
 
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/p2p"
 	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
@@ -18,12 +22,29 @@ type Config struct {
 	EDSStoreParams *eds.Parameters
 
 	UseShareExchange bool
+	// UseNamespaceFilters lets namespace requests skip blocks that peers announced as not
+	// containing the namespace. The announcements are not backed by proofs, so it trades
+	// verifiability of absent namespaces for bandwidth: a block is only skipped once at least two
+	// distinct peers announced filters matching its DAH, but colluding peers can still hide a
+	// namespace inside a row, making its data reported as not found.
+	UseNamespaceFilters bool
 	// ShrExEDSParams sets shrexeds client and server configuration parameters
 	ShrExEDSParams *shrexeds.Parameters
 	// ShrExNDParams sets shrexnd client and server configuration parameters
 	ShrExNDParams *shrexnd.Parameters
 	// PeerManagerParams sets peer-manager configuration parameters
 	PeerManagerParams peers.Parameters
+	// TrustedPeers are multiaddresses of full nodes that are always connected and tried first to
+	// serve shrex requests. They are never blacklisted.
+	TrustedPeers []string
+	// PreferredPeers are multiaddresses of full nodes that are always connected and tried before
+	// the nodes found via discovery.
+	PreferredPeers []string
+	// AllowedPeers restricts shrex servers to serve only the listed peer IDs. All peers are served
+	// if empty.
+	AllowedPeers []string
+	// LogRequests enables logging of every request handled by shrex servers.
+	LogRequests bool
 
 	LightAvailability light.Parameters `toml:",omitempty"`
 	Discovery         *discovery.Parameters
@@ -37,6 +58,9 @@ func DefaultConfig(tp node.Type) Config {
 		ShrExNDParams:     shrexnd.DefaultParameters(),
 		UseShareExchange:  true,
 		PeerManagerParams: peers.DefaultParameters(),
+		TrustedPeers:      []string{},
+		PreferredPeers:    []string{},
+		AllowedPeers:      []string{},
 	}
 
 	if tp == node.Light {
@@ -70,5 +94,77 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("nodebuilder/share: %w", err)
 	}
 
+	if _, err := parsePeers(cfg.TrustedPeers); err != nil {
+		return fmt.Errorf("nodebuilder/share: parsing TrustedPeers: %w", err)
+	}
+
+	if _, err := parsePeers(cfg.PreferredPeers); err != nil {
+		return fmt.Errorf("nodebuilder/share: parsing PreferredPeers: %w", err)
+	}
+
+	if _, err := parsePeerIDs(cfg.AllowedPeers); err != nil {
+		return fmt.Errorf("nodebuilder/share: parsing AllowedPeers: %w", err)
+	}
+
 	return nil
 }
+
+// peerManagerOptions returns peer manager options for the static peers.
+func (cfg *Config) peerManagerOptions() ([]peers.Option, error) {
+	trusted, err := parsePeers(cfg.TrustedPeers)
+	if err != nil {
+		return nil, err
+	}
+	preferred, err := parsePeers(cfg.PreferredPeers)
+	if err != nil {
+		return nil, err
+	}
+	return []peers.Option{
+		peers.WithTrustedPeers(trusted...),
+		peers.WithPreferredPeers(preferred...),
+	}, nil
+}
+
+// serverMiddlewares returns middlewares for shrex servers. The given policy is optional and is
+// checked in addition to AllowedPeers.
+func (cfg *Config) serverMiddlewares(policy p2p.AccessPolicy) ([]p2p.StreamMiddleware, error) {
+	middlewares := []p2p.StreamMiddleware{p2p.TracingMiddleware}
+	if cfg.LogRequests {
+		middlewares = append(middlewares, p2p.LoggingMiddleware)
+	}
+
+	allowed, err := parsePeerIDs(cfg.AllowedPeers)
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) > 0 {
+		middlewares = append(middlewares, p2p.AccessMiddleware(p2p.AllowList(allowed...)))
+	}
+	if policy != nil {
+		middlewares = append(middlewares, p2p.AccessMiddleware(policy))
+	}
+	return middlewares, nil
+}
+
+func parsePeerIDs(ids []string) ([]peer.ID, error) {
+	peerIDs := make([]peer.ID, len(ids))
+	for i, id := range ids {
+		peerID, err := peer.Decode(id)
+		if err != nil {
+			return nil, err
+		}
+		peerIDs[i] = peerID
+	}
+	return peerIDs, nil
+}
+
+func parsePeers(addrs []string) (_ []peer.AddrInfo, err error) {
+	maddrs := make([]ma.Multiaddr, len(addrs))
+	for i, addr := range addrs {
+		maddrs[i], err = ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return peer.AddrInfosFromP2pAddrs(maddrs...)
+}
@@ -9,12 +9,16 @@ import (
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	modprune "github.com/celestiaorg/celestia-node/nodebuilder/pruner"
+	"github.com/celestiaorg/celestia-node/pruner"
 	lightprune "github.com/celestiaorg/celestia-node/pruner/light"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/full"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/p2p"
+	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexnd"
@@ -93,13 +97,18 @@ func shrexComponents(tp node.Type, cfg *Config) fx.Option {
 				ndClient *shrexnd.Client,
 				managers map[string]*peers.Manager,
 			) *getters.ShrexGetter {
-				return getters.NewShrexGetter(
+				getter := getters.NewShrexGetter(
 					edsClient,
 					ndClient,
 					managers[fullNodesTag],
 					managers[archivalNodesTag],
 					lightprune.Window,
 				)
+				getter.WithParallelEDS(cfg.ShrExEDSParams.ParallelPeers, cfg.ShrExEDSParams.StallTimeout)
+				if cfg.UseNamespaceFilters {
+					getter.WithNamespaceFilters()
+				}
+				return getter
 			},
 			fx.OnStart(func(ctx context.Context, getter *getters.ShrexGetter) error {
 				return getter.Start(ctx)
@@ -153,11 +162,25 @@ func shrexComponents(tp node.Type, cfg *Config) fx.Option {
 
 func shrexServerComponents(cfg *Config) fx.Option {
 	return fx.Options(
-		fx.Invoke(func(_ *shrexeds.Server, _ *shrexnd.Server) {}),
+		fx.Invoke(func(_ *shrexeds.Server, _ *shrexnd.Server, _ *disc.CapabilitiesServer) {}),
+		fx.Provide(fx.Annotate(
+			cfg.serverMiddlewares,
+			fx.ParamTags(`optional:"true"`),
+		)),
 		fx.Provide(fx.Annotate(
-			func(host host.Host, store *eds.Store, network modp2p.Network) (*shrexeds.Server, error) {
+			func(
+				host host.Host,
+				store *eds.Store,
+				network modp2p.Network,
+				middlewares []p2p.StreamMiddleware,
+			) (*shrexeds.Server, error) {
 				cfg.ShrExEDSParams.WithNetworkID(network.String())
-				return shrexeds.NewServer(cfg.ShrExEDSParams, host, store)
+				server, err := shrexeds.NewServer(cfg.ShrExEDSParams, host, store)
+				if err != nil {
+					return nil, err
+				}
+				server.WithMiddlewares(middlewares...)
+				return server, nil
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexeds.Server) error {
 				return server.Start(ctx)
@@ -171,9 +194,15 @@ func shrexServerComponents(cfg *Config) fx.Option {
 				host host.Host,
 				store *eds.Store,
 				network modp2p.Network,
+				middlewares []p2p.StreamMiddleware,
 			) (*shrexnd.Server, error) {
 				cfg.ShrExNDParams.WithNetworkID(network.String())
-				return shrexnd.NewServer(cfg.ShrExNDParams, host, store)
+				server, err := shrexnd.NewServer(cfg.ShrExNDParams, host, store)
+				if err != nil {
+					return nil, err
+				}
+				server.WithMiddlewares(middlewares...)
+				return server, nil
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexnd.Server) error {
 				return server.Start(ctx)
@@ -182,9 +211,45 @@ func shrexServerComponents(cfg *Config) fx.Option {
 				return server.Stop(ctx)
 			})),
 		),
+		fx.Provide(fx.Annotate(
+			newCapabilitiesServer,
+			fx.ParamTags("", "", "", "", `optional:"true"`, ""),
+			fx.OnStart(func(ctx context.Context, server *disc.CapabilitiesServer) error {
+				return server.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, server *disc.CapabilitiesServer) error {
+				return server.Stop(ctx)
+			}),
+		)),
 	)
 }
 
+// newCapabilitiesServer announces what the node stores to the discovering peers. Pruner service
+// is only provided if pruning is enabled. The middlewares of the shrex servers apply to it as well,
+// so that private nodes don't reveal what they serve.
+func newCapabilitiesServer(
+	host host.Host,
+	network modp2p.Network,
+	pruneCfg *modprune.Config,
+	window pruner.AvailabilityWindow,
+	prunerService *pruner.Service,
+	middlewares []p2p.StreamMiddleware,
+) *disc.CapabilitiesServer {
+	server := disc.NewCapabilitiesServer(host, network.String(), func() disc.Capabilities {
+		capabilities := disc.Capabilities{
+			Archival:  !pruneCfg.EnableService || prunerService == nil,
+			Protocols: disc.ShrexProtocols(host),
+		}
+		if !capabilities.Archival {
+			capabilities.FromHeight = prunerService.LastPrunedHeight() + 1
+			capabilities.Window = window.Duration()
+		}
+		return capabilities
+	})
+	server.WithMiddlewares(middlewares...)
+	return server
+}
+
 func edsStoreComponents(cfg *Config) fx.Option {
 	return fx.Options(
 		fx.Provide(fx.Annotate(
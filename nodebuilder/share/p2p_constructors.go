@@ -1,6 +1,9 @@
 package share
 
 import (
+	"context"
+
+	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/routing"
 	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	modprune "github.com/celestiaorg/celestia-node/nodebuilder/pruner"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
@@ -29,11 +33,28 @@ const (
 // TODO @renaynay: rename
 func peerComponents(tp node.Type, cfg *Config) fx.Option {
 	return fx.Options(
+		blacklistComponents(),
 		fullDiscoveryAndPeerManager(tp, cfg),
 		archivalDiscoveryAndPeerManager(tp, cfg),
 	)
 }
 
+// blacklistComponents provides the Blacklist shared by all peer managers, as they block peers
+// via the same connection gater.
+func blacklistComponents() fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(ds datastore.Batching, h host.Host, connGater *conngater.BasicConnectionGater) *peers.Blacklist {
+			return peers.NewBlacklist(ds, h, connGater)
+		},
+		fx.OnStart(func(ctx context.Context, blacklist *peers.Blacklist) error {
+			return blacklist.Start(ctx)
+		}),
+		fx.OnStop(func(ctx context.Context, blacklist *peers.Blacklist) error {
+			return blacklist.Stop(ctx)
+		}),
+	))
+}
+
 // fullDiscoveryAndPeerManager builds the discovery instance and peer manager
 // for the `full` tag. Every node type (Light, Full, and Bridge) must discovery
 // `full` nodes on the network.
@@ -44,13 +65,19 @@ func fullDiscoveryAndPeerManager(tp node.Type, cfg *Config) fx.Option {
 			host host.Host,
 			r routing.ContentRouting,
 			connGater *conngater.BasicConnectionGater,
+			blacklist *peers.Blacklist,
+			network modp2p.Network,
 			shrexSub *shrexsub.PubSub,
 			headerSub libhead.Subscriber[*header.ExtendedHeader],
 			// we must ensure Syncer is started before PeerManager
 			// so that Syncer registers header validator before PeerManager subscribes to headers
 			_ *sync.Syncer[*header.ExtendedHeader],
 		) (*peers.Manager, *disc.Discovery, error) {
-			var managerOpts []peers.Option
+			managerOpts, err := cfg.peerManagerOptions()
+			if err != nil {
+				return nil, nil, err
+			}
+			managerOpts = append(managerOpts, peers.WithBlacklist(blacklist))
 			if tp != node.Bridge {
 				// BNs do not need the overhead of shrexsub peer pools as
 				// BNs do not sync blocks off the DA network.
@@ -68,7 +95,10 @@ func fullDiscoveryAndPeerManager(tp node.Type, cfg *Config) fx.Option {
 				return nil, nil, err
 			}
 
-			discOpts := []disc.Option{disc.WithOnPeersUpdate(fullManager.UpdateNodePool)}
+			discOpts := []disc.Option{
+				disc.WithOnPeersUpdate(fullManager.UpdateNodePool),
+				disc.WithCapabilities(network.String(), fullManager.UpdateNodeCapabilities),
+			}
 
 			if tp != node.Light {
 				// only FN and BNs should advertise to `full` topic
@@ -105,18 +135,30 @@ func archivalDiscoveryAndPeerManager(tp node.Type, cfg *Config) fx.Option {
 			h host.Host,
 			r routing.ContentRouting,
 			gater *conngater.BasicConnectionGater,
+			blacklist *peers.Blacklist,
+			network modp2p.Network,
 		) (map[string]*peers.Manager, []*disc.Discovery, error) {
+			managerOpts, err := cfg.peerManagerOptions()
+			if err != nil {
+				return nil, nil, err
+			}
+			managerOpts = append(managerOpts, peers.WithBlacklist(blacklist))
+
 			archivalPeerManager, err := peers.NewManager(
 				cfg.PeerManagerParams,
 				h,
 				gater,
 				archivalNodesTag,
+				managerOpts...,
 			)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			discOpts := []disc.Option{disc.WithOnPeersUpdate(archivalPeerManager.UpdateNodePool)}
+			discOpts := []disc.Option{
+				disc.WithOnPeersUpdate(archivalPeerManager.UpdateNodePool),
+				disc.WithCapabilities(network.String(), archivalPeerManager.UpdateNodeCapabilities),
+			}
 
 			if (tp == node.Bridge || tp == node.Full) && !pruneCfg.EnableService {
 				discOpts = append(discOpts, disc.WithAdvertise())
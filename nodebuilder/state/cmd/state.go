@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strconv"
 
@@ -26,6 +27,7 @@ func init() {
 		queryDelegationCmd,
 		queryUnbondingCmd,
 		queryRedelegationCmd,
+		queryVerifiedCmd,
 		grantFeeCmd,
 		revokeGrantFeeCmd,
 	)
@@ -389,6 +391,28 @@ var queryRedelegationCmd = &cobra.Command{
 	},
 }
 
+var queryVerifiedCmd = &cobra.Command{
+	Use: "query-verified [storeKey] [key]",
+	Short: "Retrieves the value of the hex encoded key from the given store of the state and verifies it " +
+		"against the corresponding block's AppHash.",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := cmdnode.ParseClientFromCtx(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		key, err := hex.DecodeString(args[1])
+		if err != nil {
+			return fmt.Errorf("error decoding a key: %w", err)
+		}
+
+		value, err := client.State.QueryVerified(cmd.Context(), args[0], key)
+		return cmdnode.PrintOutput(value, err, nil)
+	},
+}
+
 var grantFeeCmd = &cobra.Command{
 	Use: "grant-fee [granteeAddress] [fee] [gasLimit]",
 	Short: "Grant an allowance to a specified grantee account to pay the fees for their transactions.\n" +
@@ -6,6 +6,7 @@ import (
 	libfraud "github.com/celestiaorg/go-fraud"
 	"github.com/celestiaorg/go-header/sync"
 
+	libcore "github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
@@ -21,6 +22,7 @@ func coreAccessor(
 	keyname AccountName,
 	sync *sync.Syncer[*header.ExtendedHeader],
 	fraudServ libfraud.Service[*header.ExtendedHeader],
+	halts *modfraud.HaltRecorder,
 	opts []state.Option,
 ) (
 	*state.CoreAccessor,
@@ -28,13 +30,27 @@ func coreAccessor(
 	*modfraud.ServiceBreaker[*state.CoreAccessor, *header.ExtendedHeader],
 	error,
 ) {
+	if endpoints := corecfg.FailoverGRPCEndpoints(); len(endpoints) > 0 {
+		opts = append(opts, state.WithFailoverEndpoints(endpoints...))
+	}
 	ca, err := state.NewCoreAccessor(keyring, string(keyname), sync, corecfg.IP, corecfg.GRPCPort, opts...)
 
 	sBreaker := &modfraud.ServiceBreaker[*state.CoreAccessor, *header.ExtendedHeader]{
 		Service:   ca,
 		FraudType: byzantine.BadEncoding,
 		FraudServ: fraudServ,
+		Halts:     halts,
 	}
 
 	return ca, ca, sBreaker, err
 }
+
+// followFailover switches the state gRPC connection along with the health-checked Core endpoint
+// of the BlockFetcher.
+func followFailover(fetcher *libcore.BlockFetcher, ca *state.CoreAccessor) {
+	if ca == nil {
+		return
+	}
+	ca.SetActiveEndpoint(fetcher.ActiveEndpoint())
+	fetcher.OnFailover(ca.SetActiveEndpoint)
+}
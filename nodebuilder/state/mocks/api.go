@@ -175,6 +175,21 @@ func (mr *MockModuleMockRecorder) QueryRedelegations(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRedelegations", reflect.TypeOf((*MockModule)(nil).QueryRedelegations), arg0, arg1, arg2)
 }
 
+// QueryVerified mocks base method.
+func (m *MockModule) QueryVerified(arg0 context.Context, arg1 string, arg2 []byte) (*state.VerifiedValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryVerified", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*state.VerifiedValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryVerified indicates an expected call of QueryVerified.
+func (mr *MockModuleMockRecorder) QueryVerified(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryVerified", reflect.TypeOf((*MockModule)(nil).QueryVerified), arg0, arg1, arg2)
+}
+
 // QueryUnbonding mocks base method.
 func (m *MockModule) QueryUnbonding(arg0 context.Context, arg1 types.ValAddress) (*types0.QueryUnbondingDelegationResponse, error) {
 	m.ctrl.T.Helper()
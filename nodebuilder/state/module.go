@@ -53,11 +53,17 @@ func ConstructModule(tp node.Type, cfg *Config, coreCfg *core.Config) fx.Option
 	)
 
 	switch tp {
-	case node.Light, node.Full, node.Bridge:
+	case node.Light, node.Full:
 		return fx.Module(
 			"state",
 			baseComponents,
 		)
+	case node.Bridge:
+		return fx.Module(
+			"state",
+			baseComponents,
+			fx.Invoke(followFailover),
+		)
 	default:
 		panic("invalid node type")
 	}
@@ -81,16 +81,24 @@ type Module interface {
 		gasLim uint64,
 	) (*state.TxResponse, error)
 
-	// QueryDelegation retrieves the delegation information between a delegator and a validator.
+	// QueryDelegation retrieves the delegation information between a delegator and a validator
+	// and verifies it against the corresponding block's AppHash.
 	QueryDelegation(ctx context.Context, valAddr state.ValAddress) (*types.QueryDelegationResponse, error)
-	// QueryUnbonding retrieves the unbonding status between a delegator and a validator.
+	// QueryUnbonding retrieves the unbonding status between a delegator and a validator
+	// and verifies it against the corresponding block's AppHash.
 	QueryUnbonding(ctx context.Context, valAddr state.ValAddress) (*types.QueryUnbondingDelegationResponse, error)
-	// QueryRedelegations retrieves the status of the redelegations between a delegator and a validator.
+	// QueryRedelegations retrieves the status of the redelegations between a delegator and a validator
+	// and verifies it against the corresponding block's AppHash.
 	QueryRedelegations(
 		ctx context.Context,
 		srcValAddr,
 		dstValAddr state.ValAddress,
 	) (*types.QueryRedelegationsResponse, error)
+	// QueryVerified retrieves the value of the key from the given store of the state and verifies
+	// it, or its absence, against the corresponding block's AppHash.
+	//
+	// NOTE: like with BalanceForAddress, the value is read from the state at head-1.
+	QueryVerified(ctx context.Context, storeKey string, key []byte) (*state.VerifiedValue, error)
 
 	GrantFee(
 		ctx context.Context,
@@ -174,6 +182,11 @@ type API struct {
 			srcValAddr,
 			dstValAddr state.ValAddress,
 		) (*types.QueryRedelegationsResponse, error) `perm:"read"`
+		QueryVerified func(
+			ctx context.Context,
+			storeKey string,
+			key []byte,
+		) (*state.VerifiedValue, error) `perm:"read"`
 		GrantFee func(
 			ctx context.Context,
 			grantee state.AccAddress,
@@ -281,6 +294,10 @@ func (api *API) QueryRedelegations(
 	return api.Internal.QueryRedelegations(ctx, srcValAddr, dstValAddr)
 }
 
+func (api *API) QueryVerified(ctx context.Context, storeKey string, key []byte) (*state.VerifiedValue, error) {
+	return api.Internal.QueryVerified(ctx, storeKey, key)
+}
+
 func (api *API) Balance(ctx context.Context) (*state.Balance, error) {
 	return api.Internal.Balance(ctx)
 }
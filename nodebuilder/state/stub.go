@@ -111,6 +111,10 @@ func (s stubbedStateModule) QueryRedelegations(
 	return nil, ErrNoStateAccess
 }
 
+func (s stubbedStateModule) QueryVerified(context.Context, string, []byte) (*state.VerifiedValue, error) {
+	return nil, ErrNoStateAccess
+}
+
 func (s stubbedStateModule) GrantFee(
 	_ context.Context,
 	_ state.AccAddress,
@@ -37,7 +37,7 @@ Steps:
 3. Create a Full Node(FN) with a connection to BN as a trusted peer.
 4. Start a FN.
 5. Subscribe to a fraud proof and wait when it will be received.
-6. Check FN is not synced to 15.
+6. Check FN is not synced to 15 and the halt is recorded.
 Note: 15 is not available because DASer/Syncer will be stopped
 before reaching this height due to receiving a fraud proof.
 Another note: this test disables share exchange to speed up test results.
@@ -143,6 +143,20 @@ func TestFraudProofHandling(t *testing.T) {
 	}
 	require.ErrorIs(t, err, context.DeadlineExceeded)
 
+	// the halt is recorded
+	record, err := fullClient.Fraud.HaltRecord(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.Equal(t, byzantine.BadEncoding, record.ProofType)
+	require.EqualValues(t, 10, record.Height)
+	require.Contains(t, record.StoppedServices, "sync.Syncer")
+
+	// invalid proofs are rejected
+	proof, err := proofs[0].MarshalBinary()
+	require.NoError(t, err)
+	err = fullClient.Fraud.Submit(ctx, byzantine.BadEncoding, proof[:len(proof)/2])
+	require.Error(t, err)
+
 	// 7.
 	cfg = nodebuilder.DefaultConfig(node.Light)
 	cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, addrs[0].String())
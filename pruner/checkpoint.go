@@ -82,12 +82,14 @@ func (s *Service) loadCheckpoint(ctx context.Context) error {
 				LastPrunedHeight: 1,
 				FailedHeaders:    map[uint64]struct{}{},
 			}
+			s.lastPrunedHeight.Store(s.checkpoint.LastPrunedHeight)
 			return storeCheckpoint(ctx, s.ds, s.checkpoint)
 		}
 		return err
 	}
 
 	s.checkpoint = cp
+	s.lastPrunedHeight.Store(cp.LastPrunedHeight)
 	return nil
 }
 
@@ -103,6 +105,7 @@ func (s *Service) updateCheckpoint(
 	}
 
 	s.checkpoint.LastPrunedHeight = lastPrunedHeight
+	s.lastPrunedHeight.Store(lastPrunedHeight)
 	return storeCheckpoint(ctx, s.ds, s.checkpoint)
 }
 
@@ -26,89 +26,54 @@ func (s *Service) findPruneableHeaders(
 		return nil, nil
 	}
 
-	estimatedCutoffHeight, err := s.calculateEstimatedCutoff(ctx, lastPruned, pruneCutoff)
+	cutoff, err := s.findCutoff(ctx, lastPruned, pruneCutoff)
 	if err != nil {
 		return nil, err
 	}
 
-	if lastPruned.Height() == estimatedCutoffHeight {
+	if lastPruned.Height() == cutoff.Height() {
 		// nothing left to prune
 		return nil, nil
 	}
 
 	log.Debugw("finder: fetching header range", "last pruned", lastPruned.Height(),
-		"target height", estimatedCutoffHeight)
+		"target height", cutoff.Height())
 
-	headers, err := s.getter.GetRangeByHeight(ctx, lastPruned, estimatedCutoffHeight)
+	headers, err := s.getter.GetRangeByHeight(ctx, lastPruned, cutoff.Height()+1)
 	if err != nil {
 		log.Errorw("failed to get range from header store", "from", lastPruned.Height(),
-			"to", estimatedCutoffHeight, "error", err)
+			"to", cutoff.Height()+1, "error", err)
 		return nil, err
 	}
 	// ensures genesis block gets pruned
 	if lastPruned.Height() == 1 {
 		headers = append([]*header.ExtendedHeader{lastPruned}, headers...)
 	}
-
-	// if our estimated range didn't cover enough headers, we need to fetch more
-	// TODO: This is really inefficient in the case that lastPruned is the default value, or if the
-	// node has been offline for a long time. Instead of increasing the boundary by one in the for
-	// loop we could increase by a range every iteration
-	headerCount := len(headers)
-	for {
-		if headerCount > int(maxHeadersPerLoop) {
-			headers = headers[:maxHeadersPerLoop]
-			break
-		}
-		lastHeader := headers[len(headers)-1]
-		if lastHeader.Time().After(pruneCutoff) {
-			break
-		}
-
-		nextHeader, err := s.getter.GetByHeight(ctx, lastHeader.Height()+1)
-		if err != nil {
-			log.Errorw("failed to get header by height", "height", lastHeader.Height()+1, "error", err)
-			return nil, err
-		}
-		headers = append(headers, nextHeader)
-		headerCount++
-	}
-
-	for i, h := range headers {
-		if h.Time().After(pruneCutoff) {
-			if i == 0 {
-				// we can't prune anything
-				return nil, nil
-			}
-
-			// we can ignore the rest of the headers since they are all newer than the cutoff
-			return headers[:i], nil
-		}
+	if len(headers) > int(maxHeadersPerLoop) {
+		headers = headers[:maxHeadersPerLoop]
 	}
 	return headers, nil
 }
 
-func (s *Service) calculateEstimatedCutoff(
+// findCutoff returns the last header at or before the prune cutoff, within maxHeadersPerLoop
+// headers from the last pruned one.
+func (s *Service) findCutoff(
 	ctx context.Context,
 	lastPruned *header.ExtendedHeader,
 	pruneCutoff time.Time,
-) (uint64, error) {
-	estimatedRange := uint64(pruneCutoff.UTC().Sub(lastPruned.Time().UTC()) / s.blockTime)
-	estimatedCutoffHeight := lastPruned.Height() + estimatedRange
-
+) (*header.ExtendedHeader, error) {
 	head, err := s.getter.Head(ctx)
 	if err != nil {
 		log.Errorw("failed to get Head from header store", "error", err)
-		return 0, err
-	}
-
-	if head.Height() < estimatedCutoffHeight {
-		estimatedCutoffHeight = head.Height()
+		return nil, err
 	}
 
-	if estimatedCutoffHeight-lastPruned.Height() > maxHeadersPerLoop {
-		estimatedCutoffHeight = lastPruned.Height() + maxHeadersPerLoop
+	to := min(head.Height(), lastPruned.Height()+maxHeadersPerLoop)
+	cutoff, err := header.FindByTime(ctx, s.getter, lastPruned.Height(), to, pruneCutoff)
+	if err != nil {
+		log.Errorw("failed to find the prune cutoff header", "from", lastPruned.Height(), "to", to,
+			"error", err)
+		return nil, err
 	}
-
-	return estimatedCutoffHeight, nil
+	return cutoff, nil
 }
@@ -32,7 +32,7 @@ func (p *Pruner) Prune(ctx context.Context, eh *header.ExtendedHeader) error {
 
 	log.Debugf("pruning header %s", eh.DAH.Hash())
 
-	err := p.store.Remove(ctx, eh.DAH.Hash())
+	err := p.store.RemoveAtHeight(ctx, eh.DAH.Hash(), eh.Height())
 	if err != nil && !errors.Is(err, dagstore.ErrShardUnknown) {
 		return err
 	}
@@ -11,6 +11,8 @@ type Params struct {
 	// pruneCycle is the frequency at which the pruning Service
 	// runs the ticker. If set to 0, the Service will not run.
 	pruneCycle time.Duration
+	// headerPruner, if set, prunes headers after every pruning cycle.
+	headerPruner HeaderPruner
 }
 
 func (p *Params) Validate() error {
@@ -34,6 +36,14 @@ func WithPruneCycle(cycle time.Duration) Option {
 	}
 }
 
+// WithHeaderPruner configures the pruning Service to prune headers
+// along with the data.
+func WithHeaderPruner(hp HeaderPruner) Option {
+	return func(p *Params) {
+		p.headerPruner = hp
+	}
+}
+
 // WithPrunerMetrics is a utility function to turn on pruner metrics and that is
 // expected to be "invoked" by the fx lifecycle.
 func WithPrunerMetrics(s *Service) error {
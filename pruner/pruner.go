@@ -11,3 +11,10 @@ import (
 type Pruner interface {
 	Prune(context.Context, *header.ExtendedHeader) error
 }
+
+// HeaderPruner prunes headers from the node's header store.
+type HeaderPruner interface {
+	// PruneHeaders prunes headers below the given height. The Service never passes a height above
+	// the data it has pruned, so headers are not pruned ahead of the data they commit to.
+	PruneHeaders(ctx context.Context, to uint64) error
+}
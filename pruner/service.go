@@ -3,6 +3,7 @@ package pruner
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/go-datastore"
@@ -25,8 +26,8 @@ type Service struct {
 
 	ds         datastore.Datastore
 	checkpoint *checkpoint
-
-	blockTime time.Duration
+	// lastPrunedHeight mirrors the checkpoint for concurrent readers
+	lastPrunedHeight atomic.Uint64
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -41,7 +42,6 @@ func NewService(
 	window AvailabilityWindow,
 	getter libhead.Getter[*header.ExtendedHeader],
 	ds datastore.Datastore,
-	blockTime time.Duration,
 	opts ...Option,
 ) (*Service, error) {
 	params := DefaultParams()
@@ -59,7 +59,6 @@ func NewService(
 		getter:     getter,
 		checkpoint: &checkpoint{FailedHeaders: map[uint64]struct{}{}},
 		ds:         namespace.Wrap(ds, storePrefix),
-		blockTime:  blockTime,
 		doneCh:     make(chan struct{}),
 		params:     params,
 	}, nil
@@ -81,6 +80,11 @@ func (s *Service) Start(context.Context) error {
 	return nil
 }
 
+// LastPrunedHeight returns the height up to which the blocks were pruned.
+func (s *Service) LastPrunedHeight() uint64 {
+	return s.lastPrunedHeight.Load()
+}
+
 func (s *Service) Stop(ctx context.Context) error {
 	s.cancel()
 
@@ -113,6 +117,7 @@ func (s *Service) run() {
 
 	for {
 		lastPrunedHeader = s.prune(s.ctx, lastPrunedHeader)
+		s.pruneHeaders(s.ctx)
 		// pruning may take a while beyond ticker's time
 		// and this ensures we don't do idle spins right after the pruning
 		// and ensures there is always pruneCycle period between each run
@@ -201,3 +206,21 @@ func (s *Service) retryFailed(ctx context.Context) {
 		delete(s.checkpoint.FailedHeaders, failed)
 	}
 }
+
+// pruneHeaders prunes headers below the last pruned height, keeping the headers of the blocks
+// that failed to be pruned, so they can be retried.
+func (s *Service) pruneHeaders(ctx context.Context) {
+	if s.params.headerPruner == nil {
+		return
+	}
+
+	to := s.checkpoint.LastPrunedHeight
+	for failed := range s.checkpoint.FailedHeaders {
+		to = min(to, failed)
+	}
+
+	err := s.params.headerPruner.PruneHeaders(ctx, to)
+	if err != nil {
+		log.Errorw("failed to prune headers", "to", to, "err", err)
+	}
+}
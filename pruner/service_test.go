@@ -43,7 +43,6 @@ func TestService(t *testing.T) {
 		AvailabilityWindow(time.Millisecond*2),
 		store,
 		sync.MutexWrap(datastore.NewMapDatastore()),
-		blockTime,
 	)
 	require.NoError(t, err)
 
@@ -85,7 +84,6 @@ func TestService_FailedAreRecorded(t *testing.T) {
 		AvailabilityWindow(time.Millisecond*20),
 		store,
 		sync.MutexWrap(datastore.NewMapDatastore()),
-		blockTime,
 	)
 	require.NoError(t, err)
 
@@ -117,6 +115,54 @@ func TestService_FailedAreRecorded(t *testing.T) {
 	assert.Len(t, serv.checkpoint.FailedHeaders, 0)
 }
 
+// TestService_PruneHeaders checks that headers are never pruned
+// beyond the last pruned height or the failed heights.
+func TestService_PruneHeaders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	blockTime := time.Millisecond
+
+	suite := headertest.NewTestSuite(t, 1, blockTime)
+	store := headertest.NewCustomStore(t, suite, 100)
+
+	mp := &mockPruner{
+		failHeight: map[uint64]int{13: 0},
+	}
+	hp := &mockHeaderPruner{}
+
+	serv, err := NewService(
+		mp,
+		AvailabilityWindow(time.Millisecond*20),
+		store,
+		sync.MutexWrap(datastore.NewMapDatastore()),
+		WithHeaderPruner(hp),
+	)
+	require.NoError(t, err)
+
+	serv.ctx = ctx
+
+	err = serv.loadCheckpoint(ctx)
+	require.NoError(t, err)
+
+	// ensures at least 13 blocks are prune-able
+	time.Sleep(time.Millisecond * 50)
+
+	lastPruned, err := serv.lastPruned(ctx)
+	require.NoError(t, err)
+	_ = serv.prune(ctx, lastPruned)
+	serv.pruneHeaders(ctx)
+	assert.EqualValues(t, 13, hp.to)
+
+	// failed block is pruned on retry
+	lastPruned, err = serv.lastPruned(ctx)
+	require.NoError(t, err)
+	_ = serv.prune(ctx, lastPruned)
+	serv.pruneHeaders(ctx)
+	assert.Equal(t, serv.checkpoint.LastPrunedHeight, hp.to)
+	assert.Greater(t, hp.to, uint64(13))
+}
+
 func TestServiceCheckpointing(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -130,7 +176,6 @@ func TestServiceCheckpointing(t *testing.T) {
 		AvailabilityWindow(time.Second),
 		store,
 		sync.MutexWrap(datastore.NewMapDatastore()),
-		time.Millisecond,
 	)
 	require.NoError(t, err)
 
@@ -179,7 +224,6 @@ func TestPrune_LargeNumberOfBlocks(t *testing.T) {
 		availabilityWindow,
 		store,
 		sync.MutexWrap(datastore.NewMapDatastore()),
-		blockTime,
 	)
 	require.NoError(t, err)
 	serv.ctx = ctx
@@ -196,7 +240,7 @@ func TestPrune_LargeNumberOfBlocks(t *testing.T) {
 	_ = serv.prune(ctx, lastPruned)
 
 	// ensure all headers have been pruned
-	assert.Equal(t, maxHeadersPerLoop*5, serv.checkpoint.LastPrunedHeight)
+	assert.Equal(t, store.Height(), serv.checkpoint.LastPrunedHeight)
 	assert.Len(t, serv.checkpoint.FailedHeaders, 0)
 }
 
@@ -259,7 +303,6 @@ func TestFindPruneableHeaders(t *testing.T) {
 				tc.availWindow,
 				store,
 				sync.MutexWrap(datastore.NewMapDatastore()),
-				tc.blockTime,
 			)
 			require.NoError(t, err)
 
@@ -319,6 +362,15 @@ func (mp *mockPruner) Prune(_ context.Context, h *header.ExtendedHeader) error {
 	return nil
 }
 
+type mockHeaderPruner struct {
+	to uint64
+}
+
+func (mhp *mockHeaderPruner) PruneHeaders(_ context.Context, to uint64) error {
+	mhp.to = to
+	return nil
+}
+
 // TODO @renaynay @distractedm1nd: Deduplicate via headertest utility.
 // https://github.com/celestiaorg/celestia-node/issues/3278.
 type SpacedHeaderGenerator struct {
@@ -55,7 +55,8 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 
 	// a hack to avoid loading the whole EDS in mem if we store it already.
 	if ok, _ := fa.store.Has(ctx, dah.Hash()); ok {
-		return nil
+		// the square can be shared by multiple heights, so it must be referenced by this one as well
+		return fa.store.ReferenceAtHeight(ctx, dah.Hash(), header.Height())
 	}
 
 	adder := ipld.NewProofsAdder(len(dah.RowRoots))
@@ -75,7 +76,7 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, header *header
 		return err
 	}
 
-	err = fa.store.Put(ctx, dah.Hash(), eds)
+	err = fa.store.PutAtHeight(ctx, dah.Hash(), header.Height(), eds)
 	if err != nil && !errors.Is(err, dagstore.ErrShardExists) {
 		return fmt.Errorf("full availability: failed to store eds: %w", err)
 	}
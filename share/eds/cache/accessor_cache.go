@@ -11,14 +11,14 @@ import (
 
 	"github.com/filecoin-project/dagstore"
 	"github.com/filecoin-project/dagstore/shard"
-	lru "github.com/hashicorp/golang-lru/v2"
 )
 
 const defaultCloseTimeout = time.Minute
 
 var _ Cache = (*AccessorCache)(nil)
 
-// AccessorCache implements the Cache interface using an LRU cache backend.
+// AccessorCache implements the Cache interface on top of a pluggable EvictionPolicy. The cache is
+// bounded by the amount of items and, optionally, by their total size in bytes.
 type AccessorCache struct {
 	// The name is a prefix that will be used for cache metrics if they are enabled.
 	name string
@@ -26,13 +26,51 @@ type AccessorCache struct {
 	// of using only one lock or one lock per key, we stripe the shard keys across 256 locks. 256 is
 	// chosen because it 0-255 is the range of values we get looking at the last byte of the key.
 	stripedLocks [256]sync.Mutex
+
+	// lock guards items, policy and size
+	lock sync.Mutex
 	// Caches the blockstore for a given shard for shard read affinity, i.e., further reads will likely
 	// be from the same shard. Maps (shard key -> blockstore).
-	cache *lru.Cache[shard.Key, *accessorWithBlockstore]
+	items  map[shard.Key]*accessorWithBlockstore
+	policy EvictionPolicy
+	// size is the total size in bytes of all the cached items
+	size atomic.Int64
+
+	maxItems int
+	maxBytes int64
+	sizeFn   SizeFn
 
 	metrics *metrics
 }
 
+// SizeFn returns the size in bytes of the accessor for the given key.
+type SizeFn func(shard.Key) int64
+
+// Option configures an AccessorCache.
+type Option func(*AccessorCache)
+
+// WithMaxBytes bounds the total size of the cached accessors, as reported by the SizeFn set with
+// WithSizeFn. Zero means no bound.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(bc *AccessorCache) {
+		bc.maxBytes = maxBytes
+	}
+}
+
+// WithSizeFn sets the function used to estimate the size of cached accessors.
+func WithSizeFn(fn SizeFn) Option {
+	return func(bc *AccessorCache) {
+		bc.sizeFn = fn
+	}
+}
+
+// WithEvictionPolicy sets the EvictionPolicy of the cache. LRU is used by default.
+func WithEvictionPolicy(policy EvictionPolicy) Option {
+	return func(bc *AccessorCache) {
+		bc.policy = policy
+	}
+}
+
 // accessorWithBlockstore is the value that we store in the blockstore Cache. It implements the
 // Accessor interface.
 type accessorWithBlockstore struct {
@@ -42,6 +80,9 @@ type accessorWithBlockstore struct {
 	// accessor reopens the underlying CAR.
 	bs dagstore.ReadBlockstore
 
+	// size is the accessor size in bytes at the time it was cached
+	size int64
+
 	done     chan struct{}
 	refs     atomic.Int32
 	isClosed bool
@@ -109,33 +150,100 @@ func (s *accessorWithBlockstore) close() error {
 	return nil
 }
 
-func NewAccessorCache(name string, cacheSize int) (*AccessorCache, error) {
+// NewAccessorCache creates a new AccessorCache holding up to cacheSize accessors.
+func NewAccessorCache(name string, cacheSize int, opts ...Option) (*AccessorCache, error) {
+	if cacheSize <= 0 {
+		return nil, fmt.Errorf("failed to instantiate blockstore cache: must provide a positive size")
+	}
+
 	bc := &AccessorCache{
-		name: name,
+		name:     name,
+		items:    make(map[shard.Key]*accessorWithBlockstore),
+		maxItems: cacheSize,
 	}
-	// Instantiate the blockstore Cache.
-	bslru, err := lru.NewWithEvict[shard.Key, *accessorWithBlockstore](cacheSize, bc.evictFn())
-	if err != nil {
-		return nil, fmt.Errorf("failed to instantiate blockstore cache: %w", err)
+	for _, opt := range opts {
+		opt(bc)
+	}
+	if bc.policy == nil {
+		bc.policy = newLRUPolicy()
+	}
+	if bc.maxBytes < 0 {
+		return nil, fmt.Errorf("failed to instantiate blockstore cache: max bytes cannot be negative")
+	}
+	if bc.maxBytes > 0 && bc.sizeFn == nil {
+		return nil, fmt.Errorf("failed to instantiate blockstore cache: size function is required for max bytes")
 	}
-	bc.cache = bslru
 	return bc, nil
 }
 
-// evictFn will be invoked when an item is evicted from the cache.
-func (bc *AccessorCache) evictFn() func(shard.Key, *accessorWithBlockstore) {
-	return func(_ shard.Key, abs *accessorWithBlockstore) {
-		// we can release accessor from cache early, while it is being closed in parallel routine
-		go func() {
-			err := abs.close()
-			if err != nil {
-				bc.metrics.observeEvicted(true)
-				log.Errorf("couldn't close accessor after cache eviction: %s", err)
-				return
-			}
-			bc.metrics.observeEvicted(false)
-		}()
+// evict will be invoked when an item is evicted from the cache.
+func (bc *AccessorCache) evict(abs *accessorWithBlockstore) {
+	// we can release accessor from cache early, while it is being closed in parallel routine
+	go func() {
+		err := abs.close()
+		if err != nil {
+			bc.metrics.observeEvicted(true)
+			log.Errorf("couldn't close accessor after cache eviction: %s", err)
+			return
+		}
+		bc.metrics.observeEvicted(false)
+	}()
+}
+
+// add puts the accessor into the cache, evicting other items until it fits into the budget.
+func (bc *AccessorCache) add(key shard.Key, abs *accessorWithBlockstore) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	if old, ok := bc.items[key]; ok {
+		bc.removeItem(key, old)
+		bc.evict(old)
+	}
+
+	for len(bc.items) > 0 && bc.overBudget(abs.size) {
+		victim, ok := bc.policy.Evict()
+		if !ok {
+			break
+		}
+		evicted, ok := bc.items[victim]
+		if !ok {
+			continue
+		}
+		delete(bc.items, victim)
+		bc.size.Add(-evicted.size)
+		bc.evict(evicted)
+	}
+
+	bc.items[key] = abs
+	bc.size.Add(abs.size)
+	bc.policy.Add(key)
+}
+
+// overBudget reports whether adding an item of the given size exceeds the cache bounds.
+func (bc *AccessorCache) overBudget(size int64) bool {
+	if len(bc.items)+1 > bc.maxItems {
+		return true
 	}
+	return bc.maxBytes > 0 && bc.size.Load()+size > bc.maxBytes
+}
+
+// removeItem removes the item from the cache without closing it. The caller must hold the lock.
+func (bc *AccessorCache) removeItem(key shard.Key, abs *accessorWithBlockstore) {
+	delete(bc.items, key)
+	bc.size.Add(-abs.size)
+	bc.policy.Remove(key)
+}
+
+// Len returns the amount of items in the cache.
+func (bc *AccessorCache) Len() int {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	return len(bc.items)
+}
+
+// Size returns the total size in bytes of the cached items.
+func (bc *AccessorCache) Size() int64 {
+	return bc.size.Load()
 }
 
 // Get retrieves the Accessor for a given shard key from the Cache. If the Accessor is not in
@@ -155,10 +263,13 @@ func (bc *AccessorCache) Get(key shard.Key) (Accessor, error) {
 }
 
 func (bc *AccessorCache) get(key shard.Key) (*accessorWithBlockstore, error) {
-	abs, ok := bc.cache.Get(key)
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	abs, ok := bc.items[key]
 	if !ok {
 		return nil, errCacheMiss
 	}
+	bc.policy.Touch(key)
 	return abs, nil
 }
 
@@ -192,6 +303,9 @@ func (bc *AccessorCache) GetOrLoad(
 	abs = &accessorWithBlockstore{
 		shardAccessor: accessor,
 	}
+	if bc.sizeFn != nil {
+		abs.size = bc.sizeFn(key)
+	}
 
 	// Create a new accessor first to increment the reference count in it, so it cannot get evicted
 	// from the inner lru cache before it is used.
@@ -199,7 +313,8 @@ func (bc *AccessorCache) GetOrLoad(
 	if err != nil {
 		return nil, err
 	}
-	bc.cache.Add(key, abs)
+	bc.add(key, abs)
+	bc.metrics.observeGet(false)
 	return accessorWithRef, nil
 }
 
@@ -216,8 +331,14 @@ func (bc *AccessorCache) Remove(key shard.Key) error {
 	if err = accessor.close(); err != nil {
 		return err
 	}
-	// The cache will call evictFn on removal, where accessor close will be called.
-	bc.cache.Remove(key)
+
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	// the item could have been replaced while it was being closed
+	if bc.items[key] == accessor {
+		bc.removeItem(key, accessor)
+		bc.metrics.observeEvicted(false)
+	}
 	return nil
 }
 
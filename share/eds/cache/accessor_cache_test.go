@@ -285,6 +285,48 @@ func TestAccessorCache(t *testing.T) {
 	})
 }
 
+func TestAccessorCache_MaxBytes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sizes := map[shard.Key]int64{
+		shard.KeyFromString("small1"): 10,
+		shard.KeyFromString("small2"): 10,
+		shard.KeyFromString("big"):    25,
+	}
+	cache, err := NewAccessorCache("test", 10,
+		WithMaxBytes(30),
+		WithSizeFn(func(key shard.Key) int64 { return sizes[key] }),
+	)
+	require.NoError(t, err)
+
+	load := func(key string) *mockAccessor {
+		mock := &mockAccessor{}
+		ac, err := cache.GetOrLoad(ctx, shard.KeyFromString(key), func(context.Context, shard.Key) (Accessor, error) {
+			return mock, nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, ac.Close())
+		return mock
+	}
+
+	small1, small2 := load("small1"), load("small2")
+	require.Equal(t, 2, cache.Len())
+	require.EqualValues(t, 20, cache.Size())
+
+	// adding the big item exceeds the byte budget, so both small items have to go
+	load("big")
+	require.Equal(t, 1, cache.Len())
+	require.EqualValues(t, 25, cache.Size())
+	small1.checkClosed(t, true)
+	small2.checkClosed(t, true)
+
+	err = cache.Remove(shard.KeyFromString("big"))
+	require.NoError(t, err)
+	require.Zero(t, cache.Len())
+	require.Zero(t, cache.Size())
+}
+
 type mockAccessor struct {
 	m          sync.Mutex
 	data       []byte
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -10,12 +11,14 @@ import (
 const (
 	cacheFoundKey = "found"
 	failedKey     = "failed"
+	policyKey     = "policy"
 )
 
 type metrics struct {
 	getCounter     metric.Int64Counter
 	evictedCounter metric.Int64Counter
 
+	policy    attribute.KeyValue
 	clientReg metric.Registration
 }
 
@@ -29,7 +32,7 @@ func newMetrics(bc *AccessorCache) (*metrics, error) {
 	}
 
 	getCounter, err := meter.Int64Counter(metricsPrefix+"_get_counter",
-		metric.WithDescription("eds blockstore cache evicted event counter"))
+		metric.WithDescription("eds blockstore cache get event counter"))
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +44,20 @@ func newMetrics(bc *AccessorCache) (*metrics, error) {
 		return nil, err
 	}
 
+	cacheBytes, err := meter.Int64ObservableGauge(metricsPrefix+"_bytes",
+		metric.WithDescription("total size in bytes of items in blockstore cache"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := attribute.String(policyKey, policyName(bc.policy))
 	callback := func(_ context.Context, observer metric.Observer) error {
-		observer.ObserveInt64(cacheSize, int64(bc.cache.Len()))
+		observer.ObserveInt64(cacheSize, int64(bc.Len()), metric.WithAttributes(policy))
+		observer.ObserveInt64(cacheBytes, bc.Size(), metric.WithAttributes(policy))
 		return nil
 	}
-	clientReg, err := meter.RegisterCallback(callback, cacheSize)
+	clientReg, err := meter.RegisterCallback(callback, cacheSize, cacheBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +65,7 @@ func newMetrics(bc *AccessorCache) (*metrics, error) {
 	return &metrics{
 		getCounter:     getCounter,
 		evictedCounter: evictedCounter,
+		policy:         policy,
 		clientReg:      clientReg,
 	}, nil
 }
@@ -70,7 +83,8 @@ func (m *metrics) observeEvicted(failed bool) {
 	}
 	m.evictedCounter.Add(context.Background(), 1,
 		metric.WithAttributes(
-			attribute.Bool(failedKey, failed)))
+			attribute.Bool(failedKey, failed),
+			m.policy))
 }
 
 func (m *metrics) observeGet(found bool) {
@@ -78,5 +92,14 @@ func (m *metrics) observeGet(found bool) {
 		return
 	}
 	m.getCounter.Add(context.Background(), 1, metric.WithAttributes(
-		attribute.Bool(cacheFoundKey, found)))
+		attribute.Bool(cacheFoundKey, found),
+		m.policy))
+}
+
+// policyName returns the name of the policy to be used in metrics.
+func policyName(policy EvictionPolicy) string {
+	if s, ok := policy.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", policy)
 }
@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"container/heap"
+	"container/list"
+	"fmt"
+
+	"github.com/filecoin-project/dagstore/shard"
+)
+
+// PolicyType names one of the built-in eviction policies.
+type PolicyType string
+
+const (
+	// PolicyLRU evicts the least recently used item.
+	PolicyLRU PolicyType = "lru"
+	// PolicyLFU evicts the least frequently used item, breaking ties by recency.
+	PolicyLFU PolicyType = "lfu"
+	// PolicyARC evicts according to the Adaptive Replacement Cache algorithm, balancing between
+	// recency and frequency based on the observed access pattern.
+	PolicyARC PolicyType = "arc"
+)
+
+// EvictionPolicy decides which item is evicted from the AccessorCache once it is over budget.
+// Implementations are not required to be safe for concurrent use, as the cache serializes all the
+// calls.
+type EvictionPolicy interface {
+	// Add starts tracking the key. The key is guaranteed not to be tracked already.
+	Add(shard.Key)
+	// Touch records an access to the tracked key.
+	Touch(shard.Key)
+	// Remove stops tracking the key without treating it as an eviction.
+	Remove(shard.Key)
+	// Evict stops tracking and returns the key that should be evicted next. It returns false if no
+	// key is tracked.
+	Evict() (shard.Key, bool)
+}
+
+// NewEvictionPolicy instantiates one of the built-in eviction policies. An empty PolicyType
+// defaults to LRU.
+func NewEvictionPolicy(tp PolicyType) (EvictionPolicy, error) {
+	switch tp {
+	case PolicyLRU, "":
+		return newLRUPolicy(), nil
+	case PolicyLFU:
+		return newLFUPolicy(), nil
+	case PolicyARC:
+		return newARCPolicy(), nil
+	default:
+		return nil, fmt.Errorf("unknown eviction policy: %s", tp)
+	}
+}
+
+// lruPolicy is an EvictionPolicy that evicts the least recently used key.
+type lruPolicy struct {
+	// front of the list is the most recently used key
+	order *list.List
+	items map[shard.Key]*list.Element
+}
+
+func newLRUPolicy() *lruPolicy {
+	return &lruPolicy{
+		order: list.New(),
+		items: make(map[shard.Key]*list.Element),
+	}
+}
+
+func (p *lruPolicy) String() string {
+	return string(PolicyLRU)
+}
+
+func (p *lruPolicy) Add(key shard.Key) {
+	p.items[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy) Touch(key shard.Key) {
+	if el, ok := p.items[key]; ok {
+		p.order.MoveToFront(el)
+	}
+}
+
+func (p *lruPolicy) Remove(key shard.Key) {
+	if el, ok := p.items[key]; ok {
+		p.order.Remove(el)
+		delete(p.items, key)
+	}
+}
+
+func (p *lruPolicy) Evict() (shard.Key, bool) {
+	el := p.order.Back()
+	if el == nil {
+		return shard.Key{}, false
+	}
+	key := el.Value.(shard.Key)
+	p.order.Remove(el)
+	delete(p.items, key)
+	return key, true
+}
+
+func (p *lruPolicy) has(key shard.Key) bool {
+	_, ok := p.items[key]
+	return ok
+}
+
+func (p *lruPolicy) len() int {
+	return p.order.Len()
+}
+
+// lfuPolicy is an EvictionPolicy that evicts the least frequently used key. Among keys with the
+// same frequency, the least recently used one is evicted.
+type lfuPolicy struct {
+	heap  lfuHeap
+	items map[shard.Key]*lfuEntry
+	// clock is a logical time of the last access, used to break frequency ties
+	clock uint64
+}
+
+type lfuEntry struct {
+	key        shard.Key
+	freq       uint64
+	lastAccess uint64
+	idx        int
+}
+
+func newLFUPolicy() *lfuPolicy {
+	return &lfuPolicy{
+		items: make(map[shard.Key]*lfuEntry),
+	}
+}
+
+func (p *lfuPolicy) String() string {
+	return string(PolicyLFU)
+}
+
+func (p *lfuPolicy) Add(key shard.Key) {
+	p.clock++
+	entry := &lfuEntry{key: key, freq: 1, lastAccess: p.clock}
+	p.items[key] = entry
+	heap.Push(&p.heap, entry)
+}
+
+func (p *lfuPolicy) Touch(key shard.Key) {
+	entry, ok := p.items[key]
+	if !ok {
+		return
+	}
+	p.clock++
+	entry.freq++
+	entry.lastAccess = p.clock
+	heap.Fix(&p.heap, entry.idx)
+}
+
+func (p *lfuPolicy) Remove(key shard.Key) {
+	entry, ok := p.items[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&p.heap, entry.idx)
+	delete(p.items, key)
+}
+
+func (p *lfuPolicy) Evict() (shard.Key, bool) {
+	if p.heap.Len() == 0 {
+		return shard.Key{}, false
+	}
+	entry := heap.Pop(&p.heap).(*lfuEntry)
+	delete(p.items, entry.key)
+	return entry.key, true
+}
+
+// lfuHeap is a min-heap of lfuEntries ordered by frequency and then by last access.
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].freq == h[j].freq {
+		return h[i].lastAccess < h[j].lastAccess
+	}
+	return h[i].freq < h[j].freq
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].idx = i
+	h[j].idx = j
+}
+
+func (h *lfuHeap) Push(x any) {
+	entry := x.(*lfuEntry)
+	entry.idx = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// arcPolicy is an EvictionPolicy implementing the Adaptive Replacement Cache algorithm. Keys seen
+// once live in t1 and keys seen at least twice in t2. Recently evicted keys are remembered in the
+// ghost lists b1 and b2, and hits on them adapt the target size of t1.
+type arcPolicy struct {
+	// target is the adaptive target size of t1
+	target         int
+	t1, t2, b1, b2 *lruPolicy
+}
+
+func newARCPolicy() *arcPolicy {
+	return &arcPolicy{
+		t1: newLRUPolicy(),
+		t2: newLRUPolicy(),
+		b1: newLRUPolicy(),
+		b2: newLRUPolicy(),
+	}
+}
+
+func (p *arcPolicy) String() string {
+	return string(PolicyARC)
+}
+
+func (p *arcPolicy) Add(key shard.Key) {
+	switch {
+	case p.b1.has(key):
+		// the key was evicted from t1 too early, so favour recency
+		p.target = min(p.target+max(p.b2.len()/p.b1.len(), 1), p.size()+1)
+		p.b1.Remove(key)
+		p.t2.Add(key)
+	case p.b2.has(key):
+		// the key was evicted from t2 too early, so favour frequency
+		p.target = max(p.target-max(p.b1.len()/p.b2.len(), 1), 0)
+		p.b2.Remove(key)
+		p.t2.Add(key)
+	default:
+		p.t1.Add(key)
+	}
+}
+
+func (p *arcPolicy) Touch(key shard.Key) {
+	switch {
+	case p.t1.has(key):
+		p.t1.Remove(key)
+		p.t2.Add(key)
+	case p.t2.has(key):
+		p.t2.Touch(key)
+	}
+}
+
+func (p *arcPolicy) Remove(key shard.Key) {
+	p.t1.Remove(key)
+	p.t2.Remove(key)
+}
+
+func (p *arcPolicy) Evict() (shard.Key, bool) {
+	size := p.size()
+	if size == 0 {
+		return shard.Key{}, false
+	}
+
+	var key shard.Key
+	if p.t1.len() > 0 && (p.t1.len() > p.target || p.t2.len() == 0) {
+		key, _ = p.t1.Evict()
+		p.b1.Add(key)
+	} else {
+		key, _ = p.t2.Evict()
+		p.b2.Add(key)
+	}
+
+	// ghost lists never remember more keys than the cache holds
+	for p.b1.len() > size {
+		p.b1.Evict()
+	}
+	for p.b2.len() > size {
+		p.b2.Evict()
+	}
+	return key, true
+}
+
+// size returns the amount of resident keys.
+func (p *arcPolicy) size() int {
+	return p.t1.len() + p.t2.len()
+}
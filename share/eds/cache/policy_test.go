@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/dagstore/shard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictionPolicy(t *testing.T) {
+	k1, k2, k3 := shard.KeyFromString("k1"), shard.KeyFromString("k2"), shard.KeyFromString("k3")
+
+	t.Run("lru evicts least recently used", func(t *testing.T) {
+		p := newLRUPolicy()
+		p.Add(k1)
+		p.Add(k2)
+		p.Add(k3)
+		p.Touch(k1)
+
+		requireEvicted(t, p, k2, k3, k1)
+	})
+
+	t.Run("lfu evicts least frequently used", func(t *testing.T) {
+		p := newLFUPolicy()
+		p.Add(k1)
+		p.Add(k2)
+		p.Add(k3)
+		p.Touch(k1)
+		p.Touch(k1)
+		p.Touch(k3)
+
+		requireEvicted(t, p, k2, k3, k1)
+	})
+
+	t.Run("lfu breaks ties by recency", func(t *testing.T) {
+		p := newLFUPolicy()
+		p.Add(k1)
+		p.Add(k2)
+		p.Add(k3)
+		p.Remove(k2)
+
+		requireEvicted(t, p, k1, k3)
+	})
+
+	t.Run("arc protects frequently used items", func(t *testing.T) {
+		p := newARCPolicy()
+		p.Add(k1)
+		p.Add(k2)
+		p.Touch(k1)
+		p.Add(k3)
+
+		// k1 was used twice, so recently used once items are evicted first
+		requireEvicted(t, p, k2, k3, k1)
+	})
+
+	t.Run("arc adapts to ghost hits", func(t *testing.T) {
+		p := newARCPolicy()
+		p.Add(k1)
+		p.Add(k2)
+		p.Touch(k2)
+
+		key, ok := p.Evict()
+		require.True(t, ok)
+		require.Equal(t, k1, key)
+		require.True(t, p.b1.has(k1))
+
+		// re-adding recently evicted key grows the recency target and promotes it
+		p.Add(k1)
+		require.Equal(t, 1, p.target)
+		require.True(t, p.t2.has(k1))
+		require.False(t, p.b1.has(k1))
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := NewEvictionPolicy("mru")
+		require.Error(t, err)
+	})
+}
+
+func requireEvicted(t *testing.T, p EvictionPolicy, expected ...shard.Key) {
+	t.Helper()
+	for _, exp := range expected {
+		key, ok := p.Evict()
+		require.True(t, ok)
+		require.Equal(t, exp, key)
+	}
+	_, ok := p.Evict()
+	require.False(t, ok)
+}
@@ -51,6 +51,9 @@ type metrics struct {
 	longOpTime metric.Float64Histogram
 	gcTime     metric.Float64Histogram
 
+	tieringTime    metric.Float64Histogram
+	tieringCounter metric.Int64Counter
+
 	clientReg metric.Registration
 	closerFn  func() error
 }
@@ -122,6 +125,18 @@ func (s *Store) WithMetrics() error {
 		return err
 	}
 
+	tieringTime, err := meter.Float64Histogram("eds_store_tiering_time_histogram",
+		metric.WithDescription("eds store cold tier migration time histogram(s)"))
+	if err != nil {
+		return err
+	}
+
+	tieringCounter, err := meter.Int64Counter("eds_store_tiering_counter",
+		metric.WithDescription("eds store amount of squares migrated to cold tier"))
+	if err != nil {
+		return err
+	}
+
 	dagStoreShards, err := meter.Int64ObservableGauge("eds_store_dagstore_shards",
 		metric.WithDescription("dagstore amount of shards by status"))
 	if err != nil {
@@ -161,6 +176,8 @@ func (s *Store) WithMetrics() error {
 		shardFailureCount:    shardFailureCount,
 		longOpTime:           longOpTime,
 		gcTime:               gcTime,
+		tieringTime:          tieringTime,
+		tieringCounter:       tieringCounter,
 		clientReg:            clientReg,
 		closerFn:             closerFn,
 	}
@@ -184,6 +201,16 @@ func (m *metrics) observeGCtime(ctx context.Context, dur time.Duration, failed b
 		attribute.Bool(failedKey, failed)))
 }
 
+func (m *metrics) observeTiering(ctx context.Context, dur time.Duration, migrated int, failed bool) {
+	if m == nil {
+		return
+	}
+	ctx = utils.ResetContextOnError(ctx)
+	m.tieringTime.Record(ctx, dur.Seconds(), metric.WithAttributes(
+		attribute.Bool(failedKey, failed)))
+	m.tieringCounter.Add(ctx, int64(migrated))
+}
+
 func (m *metrics) observeShardFailure(ctx context.Context, shardKey string) {
 	if m == nil {
 		return
@@ -13,6 +13,10 @@ import (
 	"github.com/ipld/go-car/util"
 )
 
+// ErrOffsetOutOfBounds is returned when the requested ODS offset is not below the amount of shares
+// in the ODS.
+var ErrOffsetOutOfBounds = errors.New("eds: offset out of ODS bounds")
+
 // bufferedODSReader will read odsSquareSize amount of leaves from reader into the buffer.
 // It exposes the buffer to be read by io.Reader interface implementation
 type bufferedODSReader struct {
@@ -26,6 +30,12 @@ type bufferedODSReader struct {
 // ODSReader reads CARv1 encoded data from io.ReadCloser and limits the reader to the CAR header
 // and first quadrant (ODS)
 func ODSReader(carReader io.Reader) (io.Reader, error) {
+	return ODSReaderFrom(carReader, 0)
+}
+
+// ODSReaderFrom works like ODSReader, but skips the first offset leaves of the ODS, so that the
+// CAR header is directly followed by the leaf at the offset.
+func ODSReaderFrom(carReader io.Reader, offset int) (io.Reader, error) {
 	if carReader == nil {
 		return nil, errors.New("eds: can't create ODSReader over nil reader")
 	}
@@ -51,6 +61,15 @@ func ODSReader(carReader io.Reader) (io.Reader, error) {
 	// we divide by 4 to get the ODSWidth
 	odsWidth := len(header.Roots) / 4
 	odsR.odsSquareSize = odsWidth * odsWidth
+	if offset < 0 || (offset > 0 && offset >= odsR.odsSquareSize) {
+		return nil, fmt.Errorf("%w: %d of %d", ErrOffsetOutOfBounds, offset, odsR.odsSquareSize)
+	}
+
+	for ; odsR.current < offset; odsR.current++ {
+		if err := odsR.skipLeaf(); err != nil {
+			return nil, fmt.Errorf("skipping leaf: %w", err)
+		}
+	}
 
 	// NewCarReader will expect to read the header first, so write it first
 	return odsR, util.LdWrite(odsR.buf, data)
@@ -71,6 +90,24 @@ func (r *bufferedODSReader) Read(p []byte) (n int, err error) {
 	return r.buf.Read(p)
 }
 
+// skipLeaf reads one leaf from reader and discards it
+func (r *bufferedODSReader) skipLeaf() error {
+	l, err := binary.ReadUvarint(r.carReader)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	if l > uint64(util.MaxAllowedSectionSize) { // Don't OOM
+		return fmt.Errorf("malformed car; header `length`: %v is bigger than %v", l, util.MaxAllowedSectionSize)
+	}
+
+	_, err = r.carReader.Discard(int(l))
+	return err
+}
+
 // readLeaf reads one leaf from reader into bufferedODSReader buffer
 func (r *bufferedODSReader) readLeaf() error {
 	if _, err := r.carReader.Peek(1); err != nil { // no more blocks, likely clean io.EOF
@@ -108,3 +108,56 @@ func TestODSReaderReconstruction(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, colRoots, loadedColRoots)
 }
+
+// TestODSReaderFrom ensures that the reader returned from ODSReaderFrom skips the leaves before the
+// offset.
+func TestODSReaderFrom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// launch eds store
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	// generate random eds data and put it into the store
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	t.Run("offset within ODS", func(t *testing.T) {
+		r, err := edsStore.GetCAR(ctx, dah.Hash())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, r.Close())
+		}()
+
+		const offset = 6
+		odsR, err := ODSReaderFrom(r, offset)
+		require.NoError(t, err)
+
+		carReader, err := car.NewCarReader(odsR)
+		require.NoError(t, err)
+
+		for i := offset; i < 16; i++ {
+			block, err := carReader.Next()
+			require.NoError(t, err)
+			assert.Equal(t, eds.GetCell(uint(i/4), uint(i%4)), share.GetData(block.RawData()))
+		}
+
+		_, err = carReader.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("offset out of bounds", func(t *testing.T) {
+		r, err := edsStore.GetCAR(ctx, dah.Hash())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, r.Close())
+		}()
+
+		_, err = ODSReaderFrom(r, 16)
+		require.ErrorIs(t, err, ErrOffsetOutOfBounds)
+	})
+}
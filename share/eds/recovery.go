@@ -0,0 +1,299 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/filecoin-project/dagstore/shard"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	carindex "github.com/ipld/go-car/v2/index"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var putJournalKey = datastore.NewKey("put-journal")
+
+// putJournal is a write-ahead record of in-flight puts. An entry is added before the CAR file of a
+// square is written and removed once the shard is registered, so any entry found on startup points
+// to a put that was interrupted by a crash.
+type putJournal struct {
+	ds datastore.Batching
+}
+
+func newPutJournal(ds datastore.Batching) *putJournal {
+	return &putJournal{ds: namespace.Wrap(ds, putJournalKey)}
+}
+
+// begin records the start of the put for the given root.
+func (j *putJournal) begin(ctx context.Context, root share.DataHash) error {
+	key := datastore.NewKey(root.String())
+	if err := j.ds.Put(ctx, key, []byte{}); err != nil {
+		return err
+	}
+	return j.ds.Sync(ctx, key)
+}
+
+// end removes the record of the put for the given root.
+func (j *putJournal) end(ctx context.Context, root share.DataHash) error {
+	return j.ds.Delete(ctx, datastore.NewKey(root.String()))
+}
+
+// pending returns roots of all the puts that have begun but not ended.
+func (j *putJournal) pending(ctx context.Context) ([]share.DataHash, error) {
+	res, err := j.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]share.DataHash, 0, len(entries))
+	for _, entry := range entries {
+		root, err := hex.DecodeString(strings.TrimPrefix(entry.Key, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid journal entry %s: %w", entry.Key, err)
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// recoverPuts completes or rolls back every put interrupted by a crash. A put is completed if the ODS
+// stored in its CAR file is intact, otherwise all of its leftovers are removed.
+func (s *Store) recoverPuts(ctx context.Context) error {
+	roots, err := s.journal.pending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read put journal: %w", err)
+	}
+
+	for _, root := range roots {
+		if err := s.recoverPut(ctx, root); err != nil {
+			// the journal entry is kept, so recovery will be retried on the next start
+			log.Errorw("failed to recover interrupted put", "root", root.String(), "err", err)
+			continue
+		}
+		log.Infow("recovered interrupted put", "root", root.String())
+	}
+	return nil
+}
+
+func (s *Store) recoverPut(ctx context.Context, root share.DataHash) error {
+	key := shard.KeyFromString(root.String())
+	// the shard is registered and its data is accessible, so only the journal entry is left behind
+	if _, err := s.dgstr.GetShardInfo(key); err == nil {
+		accessor, err := s.getAccessor(ctx, key)
+		if err == nil {
+			closeAndLog("accessor", accessor)
+			if stat, err := s.carIdx.StatFullIndex(key); err == nil && stat.Exists {
+				return s.journal.end(ctx, root)
+			}
+		}
+	}
+
+	eds, readErr := s.readCARFile(ctx, root)
+	if err := s.rollbackPut(ctx, root); err != nil {
+		return fmt.Errorf("failed to roll back: %w", err)
+	}
+
+	if readErr != nil {
+		log.Warnw("rolled back interrupted put", "root", root.String(), "reason", readErr)
+		return s.journal.end(ctx, root)
+	}
+	// the square is intact, so it can be written again from scratch
+	if err := s.put(ctx, root, eds); err != nil {
+		return fmt.Errorf("failed to complete: %w", err)
+	}
+	return nil
+}
+
+// readCARFile reads and verifies the square from the CAR file, without going through the DAGStore.
+func (s *Store) readCARFile(ctx context.Context, root share.DataHash) (*rsmt2d.ExtendedDataSquare, error) {
+	f, err := os.Open(s.basepath + blocksPath + root.String())
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndLog("car file", f)
+	return ReadEDS(ctx, f, root)
+}
+
+// rollbackPut removes everything a partial put for the given root could have left behind.
+func (s *Store) rollbackPut(ctx context.Context, root share.DataHash) error {
+	key := shard.KeyFromString(root.String())
+	if _, err := s.dgstr.GetShardInfo(key); err == nil {
+		err = s.remove(ctx, root)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := s.carIdx.DropFullIndex(key)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+	err = os.Remove(s.basepath + blocksPath + root.String())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove CAR file: %w", err)
+	}
+	return nil
+}
+
+// ConsistencyReport lists inconsistencies found between the shards registered on the DAGStore, the
+// index repository, the inverted index and the blocks directories.
+type ConsistencyReport struct {
+	// MissingFiles are registered shards without a CAR file.
+	MissingFiles []share.DataHash
+	// OrphanedFiles are CAR files without a registered shard.
+	OrphanedFiles []share.DataHash
+	// MissingIndices are registered shards without a full index.
+	MissingIndices []share.DataHash
+	// OrphanedIndices are full indices without a registered shard.
+	OrphanedIndices []share.DataHash
+	// MissingInvertedIndex are registered shards with multihashes absent in the inverted index.
+	MissingInvertedIndex []share.DataHash
+	// PendingPuts are puts that have been interrupted and are not recovered yet.
+	PendingPuts []share.DataHash
+}
+
+// IsConsistent reports whether no inconsistencies were found.
+func (r *ConsistencyReport) IsConsistent() bool {
+	return len(r.MissingFiles) == 0 &&
+		len(r.OrphanedFiles) == 0 &&
+		len(r.MissingIndices) == 0 &&
+		len(r.OrphanedIndices) == 0 &&
+		len(r.MissingInvertedIndex) == 0 &&
+		len(r.PendingPuts) == 0
+}
+
+// CheckConsistency cross-checks the shards registered on the DAGStore against the index
+// repository, the inverted index and the blocks directories. It reads the full index of every
+// shard, so it is expensive on large stores.
+func (s *Store) CheckConsistency(ctx context.Context) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{}
+	shards := s.dgstr.AllShardsInfo()
+
+	files, err := s.listCARFiles()
+	if err != nil {
+		return nil, err
+	}
+	for key := range files {
+		if _, ok := shards[shard.KeyFromString(key)]; !ok {
+			report.OrphanedFiles = append(report.OrphanedFiles, share.MustDataHashFromString(key))
+		}
+	}
+
+	for key := range shards {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		root := share.MustDataHashFromString(key.String())
+		if _, ok := files[key.String()]; !ok {
+			report.MissingFiles = append(report.MissingFiles, root)
+		}
+
+		stat, err := s.carIdx.StatFullIndex(key)
+		if err != nil || !stat.Exists {
+			report.MissingIndices = append(report.MissingIndices, root)
+			continue
+		}
+
+		indexed, err := s.isInvertedIndexed(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("checking inverted index of %s: %w", key, err)
+		}
+		if !indexed {
+			report.MissingInvertedIndex = append(report.MissingInvertedIndex, root)
+		}
+	}
+
+	err = s.carIdx.ForEach(func(key shard.Key) (bool, error) {
+		if _, ok := shards[key]; !ok {
+			report.OrphanedIndices = append(report.OrphanedIndices, share.MustDataHashFromString(key.String()))
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("iterating index repository: %w", err)
+	}
+
+	report.PendingPuts, err = s.journal.pending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading put journal: %w", err)
+	}
+	return report, nil
+}
+
+// listCARFiles returns the set of keys of CAR files in the hot and, if enabled, cold blocks
+// directories.
+func (s *Store) listCARFiles() (map[string]struct{}, error) {
+	dirs := []string{s.basepath + blocksPath}
+	if s.coldDir != "" {
+		dirs = append(dirs, s.coldDir)
+	}
+
+	files := make(map[string]struct{})
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading blocks directory: %w", err)
+		}
+		for _, entry := range entries {
+			root, err := hex.DecodeString(entry.Name())
+			if err != nil || share.DataHash(root).Validate() != nil {
+				continue
+			}
+			files[entry.Name()] = struct{}{}
+		}
+	}
+	return files, nil
+}
+
+// isInvertedIndexed checks whether the first multihash of the shard is present in the inverted
+// index. The inverted index stores only one shard per multihash, so the shard it points to is not
+// compared.
+func (s *Store) isInvertedIndexed(ctx context.Context, key shard.Key) (bool, error) {
+	idx, err := s.carIdx.GetFullIndex(key)
+	if err != nil {
+		return false, err
+	}
+	iterIdx, ok := idx.(carindex.IterableIndex)
+	if !ok {
+		return false, errors.New("index is not iterable")
+	}
+
+	var mh multihash.Multihash
+	errStop := errors.New("stop")
+	err = iterIdx.ForEach(func(m multihash.Multihash, _ uint64) error {
+		mh = m
+		return errStop
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return false, err
+	}
+	if mh == nil {
+		// empty index has nothing to look for
+		return true, nil
+	}
+
+	_, err = s.invertedIdx.GetShardsForMultihash(ctx, mh)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, datastore.ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
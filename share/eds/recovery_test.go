@@ -0,0 +1,131 @@
+package eds
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_RecoverPuts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	t.Run("complete intact put", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		err := edsStore.journal.begin(ctx, dah.Hash())
+		require.NoError(t, err)
+
+		// the file was written, but the shard has never been registered
+		f, err := os.Create(edsStore.basepath + blocksPath + dah.String())
+		require.NoError(t, err)
+		err = WriteEDS(ctx, eds, f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		err = edsStore.recoverPuts(ctx)
+		require.NoError(t, err)
+
+		got, err := edsStore.Get(ctx, dah.Hash())
+		require.NoError(t, err)
+		require.True(t, eds.Equals(got))
+		requireNoPendingPuts(ctx, t, edsStore)
+	})
+
+	t.Run("roll back corrupted put", func(t *testing.T) {
+		_, dah := randomEDS(t)
+		err := edsStore.journal.begin(ctx, dah.Hash())
+		require.NoError(t, err)
+
+		path := edsStore.basepath + blocksPath + dah.String()
+		err = os.WriteFile(path, []byte("truncated"), 0o600)
+		require.NoError(t, err)
+
+		err = edsStore.recoverPuts(ctx)
+		require.NoError(t, err)
+
+		has, err := edsStore.Has(ctx, dah.Hash())
+		require.NoError(t, err)
+		require.False(t, has)
+		_, err = os.Stat(path)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		requireNoPendingPuts(ctx, t, edsStore)
+	})
+
+	t.Run("clean up journal of registered shard", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		err := edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		err = edsStore.journal.begin(ctx, dah.Hash())
+		require.NoError(t, err)
+
+		err = edsStore.recoverPuts(ctx)
+		require.NoError(t, err)
+
+		has, err := edsStore.Has(ctx, dah.Hash())
+		require.NoError(t, err)
+		require.True(t, has)
+		requireNoPendingPuts(ctx, t, edsStore)
+	})
+
+	t.Run("clean up journal of failed put", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		// a directory in place of the CAR file fails its opening
+		path := edsStore.basepath + blocksPath + dah.String()
+		require.NoError(t, os.Mkdir(path, 0o700))
+		t.Cleanup(func() {
+			_ = os.Remove(path)
+		})
+
+		err := edsStore.Put(ctx, dah.Hash(), eds)
+		require.Error(t, err)
+		requireNoPendingPuts(ctx, t, edsStore)
+	})
+}
+
+func TestStore_CheckConsistency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	report, err := edsStore.CheckConsistency(ctx)
+	require.NoError(t, err)
+	require.True(t, report.IsConsistent())
+
+	// orphan CAR file without a registered shard
+	_, orphan := randomEDS(t)
+	err = os.WriteFile(edsStore.basepath+blocksPath+orphan.String(), []byte{}, 0o600)
+	require.NoError(t, err)
+	// registered shard without a CAR file
+	err = os.Remove(edsStore.basepath + blocksPath + dah.String())
+	require.NoError(t, err)
+
+	report, err = edsStore.CheckConsistency(ctx)
+	require.NoError(t, err)
+	require.False(t, report.IsConsistent())
+	require.Len(t, report.OrphanedFiles, 1)
+	require.EqualValues(t, orphan.Hash(), report.OrphanedFiles[0])
+	require.Len(t, report.MissingFiles, 1)
+	require.EqualValues(t, dah.Hash(), report.MissingFiles[0])
+}
+
+func requireNoPendingPuts(ctx context.Context, t *testing.T, store *Store) {
+	t.Helper()
+	pending, err := store.journal.pending(ctx)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
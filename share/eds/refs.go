@@ -0,0 +1,109 @@
+package eds
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var heightRefsKey = datastore.NewKey("height-refs")
+
+// heightRefs tracks which heights reference a square. Squares are keyed by the data hash, so
+// heights with identical data (e.g. repeated block content) share a single stored square.
+type heightRefs struct {
+	ds datastore.Batching
+}
+
+func newHeightRefs(ds datastore.Batching) *heightRefs {
+	return &heightRefs{ds: namespace.Wrap(ds, heightRefsKey)}
+}
+
+// add records the reference of the height to the square.
+func (r *heightRefs) add(ctx context.Context, root share.DataHash, height uint64) error {
+	return r.ds.Put(ctx, refKey(root, height), []byte{})
+}
+
+// remove drops the reference of the height to the square and returns the amount of heights still
+// referencing it.
+func (r *heightRefs) remove(ctx context.Context, root share.DataHash, height uint64) (int, error) {
+	if err := r.ds.Delete(ctx, refKey(root, height)); err != nil {
+		return 0, err
+	}
+	return r.count(ctx, root)
+}
+
+// count returns the amount of heights referencing the square.
+func (r *heightRefs) count(ctx context.Context, root share.DataHash) (int, error) {
+	res, err := r.ds.Query(ctx, query.Query{
+		Prefix:   datastore.NewKey(root.String()).String(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+func refKey(root share.DataHash, height uint64) datastore.Key {
+	return datastore.NewKey(root.String()).ChildString(strconv.FormatUint(height, 10))
+}
+
+// PutAtHeight stores the given data square like Put, additionally recording that the square is
+// referenced by the given height. It returns dagstore.ErrShardExists if the square is already
+// stored, in which case only the reference is recorded.
+func (s *Store) PutAtHeight(
+	ctx context.Context,
+	root share.DataHash,
+	height uint64,
+	square *rsmt2d.ExtendedDataSquare,
+) error {
+	if err := s.ReferenceAtHeight(ctx, root, height); err != nil {
+		return err
+	}
+	return s.Put(ctx, root, square)
+}
+
+// ReferenceAtHeight records that the square is referenced by the given height, so that pruning of
+// other heights with the same square doesn't remove it.
+func (s *Store) ReferenceAtHeight(ctx context.Context, root share.DataHash, height uint64) error {
+	lk := &s.stripedLocks[root[len(root)-1]]
+	lk.Lock()
+	defer lk.Unlock()
+
+	if err := s.refs.add(ctx, root, height); err != nil {
+		return fmt.Errorf("failed to add height reference: %w", err)
+	}
+	return nil
+}
+
+// RemoveAtHeight drops the reference of the given height to the square and removes the square
+// from the Store only if no other height references it anymore. Squares stored without height
+// references are removed unconditionally.
+func (s *Store) RemoveAtHeight(ctx context.Context, root share.DataHash, height uint64) error {
+	lk := &s.stripedLocks[root[len(root)-1]]
+	lk.Lock()
+	defer lk.Unlock()
+
+	remaining, err := s.refs.remove(ctx, root, height)
+	if err != nil {
+		return fmt.Errorf("failed to remove height reference: %w", err)
+	}
+	if remaining > 0 {
+		log.Debugw("keeping square referenced by other heights",
+			"root", root.String(), "height", height, "references", remaining)
+		return nil
+	}
+	return s.Remove(ctx, root)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -53,12 +54,19 @@ type Store struct {
 
 	carIdx      index.FullIndexRepo
 	invertedIdx *simpleInvertedIndex
+	journal     *putJournal
+	refs        *heightRefs
 
 	basepath   string
 	gcInterval time.Duration
 	// lastGCResult is only stored on the store for testing purposes.
 	lastGCResult atomic.Pointer[dagstore.GCResult]
 
+	// coldDir is the directory squares are migrated to after coldAge. Empty if tiering is disabled.
+	coldDir         string
+	coldAge         time.Duration
+	tieringInterval time.Duration
+
 	// stripedLocks is used to synchronize parallel operations
 	stripedLocks  [256]sync.Mutex
 	shardFailures chan dagstore.ShardResult
@@ -77,8 +85,18 @@ func NewStore(params *Parameters, basePath string, ds datastore.Batching) (*Stor
 		return nil, fmt.Errorf("failed to setup eds.Store directories: %w", err)
 	}
 
+	var coldDir string
+	if params.ColdStoragePath != "" {
+		coldDir = params.ColdStoragePath + blocksPath
+		if err = os.MkdirAll(coldDir, os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create cold blocks directory: %w", err)
+		}
+	}
+
 	r := mount.NewRegistry()
-	err = r.Register("fs", &inMemoryOnceMount{})
+	// the registered mount is used as a template by the registry, so ColdDir gets carried over to
+	// all the mounts restored from the datastore
+	err = r.Register("fs", &inMemoryOnceMount{ColdDir: coldDir})
 	if err != nil {
 		return nil, fmt.Errorf("failed to register memory mount on the registry: %w", err)
 	}
@@ -111,24 +129,31 @@ func NewStore(params *Parameters, basePath string, ds datastore.Batching) (*Stor
 		return nil, fmt.Errorf("failed to create DAGStore: %w", err)
 	}
 
-	recentBlocksCache, err := cache.NewAccessorCache("recent", params.RecentBlocksCacheSize)
+	recentBlocksCache, err := newAccessorCache(
+		"recent", params.RecentBlocksCacheSize, params.RecentBlocksCacheBytes, params, basePath, coldDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create recent blocks cache: %w", err)
 	}
 
-	blockstoreCache, err := cache.NewAccessorCache("blockstore", params.BlockstoreCacheSize)
+	blockstoreCache, err := newAccessorCache(
+		"blockstore", params.BlockstoreCacheSize, params.BlockstoreCacheBytes, params, basePath, coldDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create blockstore cache: %w", err)
 	}
 
 	store := &Store{
-		basepath:      basePath,
-		dgstr:         dagStore,
-		carIdx:        fsRepo,
-		invertedIdx:   invertedIdx,
-		gcInterval:    params.GCInterval,
-		mounts:        r,
-		shardFailures: failureChan,
+		basepath:        basePath,
+		dgstr:           dagStore,
+		carIdx:          fsRepo,
+		invertedIdx:     invertedIdx,
+		journal:         newPutJournal(ds),
+		refs:            newHeightRefs(ds),
+		gcInterval:      params.GCInterval,
+		coldDir:         coldDir,
+		coldAge:         params.ColdStorageAge,
+		tieringInterval: params.TieringInterval,
+		mounts:          r,
+		shardFailures:   failureChan,
 	}
 	store.bs = newBlockstore(store, ds)
 	store.cache.Store(cache.NewDoubleCache(recentBlocksCache, blockstoreCache))
@@ -140,6 +165,11 @@ func (s *Store) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+
+	err = s.recoverPuts(ctx)
+	if err != nil {
+		return err
+	}
 	// start Store only if DagStore succeeds
 	runCtx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
@@ -152,6 +182,10 @@ func (s *Store) Start(ctx context.Context) error {
 		go s.gc(runCtx)
 	}
 
+	if s.coldDir != "" {
+		go s.tier(runCtx)
+	}
+
 	go s.watchForFailures(runCtx)
 	return nil
 }
@@ -242,18 +276,43 @@ func (s *Store) put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 		return dagstore.ErrShardExists
 	}
 
+	// record the put before touching the disk, so it can be recovered if interrupted by a crash
+	if err = s.journal.begin(ctx, root); err != nil {
+		return fmt.Errorf("failed to journal put: %w", err)
+	}
+
 	key := root.String()
-	f, err := os.OpenFile(s.basepath+blocksPath+key, os.O_CREATE|os.O_WRONLY, 0o600)
+	path := s.basepath + blocksPath + key
+	// until shard registration is initiated, nothing but the file and the journal entry has to be
+	// rolled back on failure
+	var created, registering bool
+	defer func() {
+		if err == nil || registering {
+			return
+		}
+		if created {
+			if rmErr := os.Remove(path); rmErr != nil {
+				log.Warnw("failed to remove CAR file after failed put", "key", key, "err", rmErr)
+			}
+		}
+		if jErr := s.journal.end(ctx, root); jErr != nil {
+			log.Warnw("failed to remove put journal entry", "key", key, "err", jErr)
+		}
+	}()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
 	if err != nil {
 		return err
 	}
+	created = true
 	defer closeAndLog("car file", f)
 
 	// save encoded eds into buffer
 	mount := &inMemoryOnceMount{
 		// TODO: buffer could be pre-allocated with capacity calculated based on eds size.
 		buf:       bytes.NewBuffer(nil),
-		FileMount: mount.FileMount{Path: s.basepath + blocksPath + key},
+		FileMount: mount.FileMount{Path: path},
+		ColdDir:   s.coldDir,
 	}
 	err = WriteEDS(ctx, square, mount)
 	if err != nil {
@@ -264,7 +323,13 @@ func (s *Store) put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 	if _, err = mount.WriteTo(f); err != nil {
 		return fmt.Errorf("failed to write EDS to file: %w", err)
 	}
+	// the file must be durable before the shard gets registered, otherwise the shard may be
+	// registered on restart while the file is lost
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync EDS file: %w", err)
+	}
 
+	registering = true
 	ch := make(chan dagstore.ShardResult, 1)
 	err = s.dgstr.RegisterShard(ctx, shard.KeyFromString(key), mount, ch, dagstore.RegisterOpts{})
 	if err != nil {
@@ -284,6 +349,10 @@ func (s *Store) put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 		return fmt.Errorf("failed to register shard: %w", result.Error)
 	}
 
+	if err := s.journal.end(ctx, root); err != nil {
+		log.Warnw("failed to remove put journal entry", "key", key, "err", err)
+	}
+
 	// the accessor returned in the result will be nil, so the shard needs to be acquired first to
 	// become available in the cache. It might take some time, and the result should not affect the put
 	// operation, so do it in a goroutine
@@ -516,6 +585,10 @@ func (s *Store) remove(ctx context.Context, root share.DataHash) (err error) {
 	}
 
 	err = os.Remove(s.basepath + blocksPath + root.String())
+	if errors.Is(err, os.ErrNotExist) && s.coldDir != "" {
+		// the square has been migrated to the cold tier
+		err = os.Remove(s.coldDir + root.String())
+	}
 	if err != nil {
 		return fmt.Errorf("failed to remove CAR file: %w", err)
 	}
@@ -597,6 +670,37 @@ func (s *Store) list() ([]share.DataHash, error) {
 	return hashes, nil
 }
 
+// newAccessorCache creates an AccessorCache bounded by the given amount of items and bytes, sizing
+// the accessors by their CAR files.
+func newAccessorCache(
+	name string,
+	size int,
+	maxBytes int64,
+	params *Parameters,
+	basepath, coldDir string,
+) (*cache.AccessorCache, error) {
+	policy, err := cache.NewEvictionPolicy(params.CacheEvictionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.NewAccessorCache(name, size,
+		cache.WithEvictionPolicy(policy),
+		cache.WithMaxBytes(maxBytes),
+		cache.WithSizeFn(func(key shard.Key) int64 {
+			info, err := os.Stat(basepath + blocksPath + key.String())
+			if errors.Is(err, os.ErrNotExist) && coldDir != "" {
+				info, err = os.Stat(coldDir + key.String())
+			}
+			if err != nil {
+				log.Warnw("unable to stat CAR file for cache sizing", "key", key, "err", err)
+				return 0
+			}
+			return info.Size()
+		}),
+	)
+}
+
 func setupPath(basepath string) error {
 	err := os.MkdirAll(basepath+blocksPath, os.ModePerm)
 	if err != nil {
@@ -619,6 +723,10 @@ type inMemoryOnceMount struct {
 
 	readOnce atomic.Bool
 	mount.FileMount
+
+	// ColdDir is the cold tier blocks directory the file is looked up in, if it is not found under
+	// the hot path. It has to be exported to be carried over by the mount registry.
+	ColdDir string
 }
 
 func (m *inMemoryOnceMount) Fetch(ctx context.Context) (mount.Reader, error) {
@@ -628,7 +736,25 @@ func (m *inMemoryOnceMount) Fetch(ctx context.Context) (mount.Reader, error) {
 		m.buf = nil
 		return reader, nil
 	}
-	return m.FileMount.Fetch(ctx)
+
+	r, err := m.FileMount.Fetch(ctx)
+	if errors.Is(err, os.ErrNotExist) && m.ColdDir != "" {
+		return m.coldMount().Fetch(ctx)
+	}
+	return r, err
+}
+
+func (m *inMemoryOnceMount) Stat(ctx context.Context) (mount.Stat, error) {
+	stat, err := m.FileMount.Stat(ctx)
+	if errors.Is(err, os.ErrNotExist) && m.ColdDir != "" {
+		return m.coldMount().Stat(ctx)
+	}
+	return stat, err
+}
+
+// coldMount returns the FileMount of the file in the cold tier.
+func (m *inMemoryOnceMount) coldMount() *mount.FileMount {
+	return &mount.FileMount{Path: m.ColdDir + filepath.Base(m.Path)}
 }
 
 func (m *inMemoryOnceMount) Write(b []byte) (int, error) {
@@ -2,7 +2,10 @@ package eds
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/celestiaorg/celestia-node/share/eds/cache"
 )
 
 type Parameters struct {
@@ -16,6 +19,27 @@ type Parameters struct {
 
 	// BlockstoreCacheSize is the size of the cache for blockstore requested accessors.
 	BlockstoreCacheSize int
+
+	// RecentBlocksCacheBytes additionally bounds the recent blocks cache by the total size of
+	// the cached CAR files in bytes. Zero disables the bound.
+	RecentBlocksCacheBytes int64
+
+	// BlockstoreCacheBytes additionally bounds the blockstore cache by the total size of the cached
+	// CAR files in bytes. Zero disables the bound.
+	BlockstoreCacheBytes int64
+
+	// CacheEvictionPolicy is the eviction policy of the accessor caches: "lru", "lfu" or "arc".
+	CacheEvictionPolicy cache.PolicyType
+
+	// ColdStoragePath is an optional secondary directory (e.g. on a large HDD volume) where
+	// squares older than ColdStorageAge are migrated. Tiering is disabled when empty.
+	ColdStoragePath string
+
+	// ColdStorageAge is the age of a stored square after which it is moved to ColdStoragePath.
+	ColdStorageAge time.Duration
+
+	// TieringInterval is how often the store looks for squares to migrate to ColdStoragePath.
+	TieringInterval time.Duration
 }
 
 // DefaultParameters returns the default configuration values for the EDS store parameters.
@@ -24,6 +48,10 @@ func DefaultParameters() *Parameters {
 		GCInterval:            0,
 		RecentBlocksCacheSize: 10,
 		BlockstoreCacheSize:   128,
+		CacheEvictionPolicy:   cache.PolicyLRU,
+		ColdStoragePath:       "",
+		ColdStorageAge:        7 * 24 * time.Hour,
+		TieringInterval:       time.Hour,
 	}
 }
 
@@ -39,5 +67,22 @@ func (p *Parameters) Validate() error {
 	if p.BlockstoreCacheSize < 1 {
 		return errors.New("eds: blockstore cache size must be positive")
 	}
+
+	if p.RecentBlocksCacheBytes < 0 || p.BlockstoreCacheBytes < 0 {
+		return errors.New("eds: cache bytes cannot be negative")
+	}
+
+	if _, err := cache.NewEvictionPolicy(p.CacheEvictionPolicy); err != nil {
+		return fmt.Errorf("eds: %w", err)
+	}
+
+	if p.ColdStoragePath != "" {
+		if p.ColdStorageAge <= 0 {
+			return errors.New("eds: cold storage age must be positive")
+		}
+		if p.TieringInterval <= 0 {
+			return errors.New("eds: tiering interval must be positive")
+		}
+	}
 	return nil
 }
@@ -295,6 +295,95 @@ func TestEDSStore_GC(t *testing.T) {
 	assert.Nil(t, edsStore.lastGCResult.Load().Shards[shardKey])
 }
 
+// TestEDSStore_Tiering verifies that squares migrated to the cold tier stay accessible through the
+// Store and are removed from the cold tier.
+func TestEDSStore_Tiering(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	params := DefaultParameters()
+	params.ColdStoragePath = t.TempDir()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	basepath := t.TempDir()
+	edsStore, err := NewStore(params, basepath, ds)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	// nothing is old enough to be migrated yet
+	migrated, err := edsStore.migrateCold(ctx)
+	require.NoError(t, err)
+	require.Zero(t, migrated)
+
+	edsStore.coldAge = 0
+	migrated, err = edsStore.migrateCold(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+
+	hotPath := basepath + blocksPath + dah.String()
+	coldPath := params.ColdStoragePath + blocksPath + dah.String()
+	_, err = os.Stat(hotPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(coldPath)
+	require.NoError(t, err)
+
+	// the accessor was evicted on migration, so the square is read from the cold tier
+	_, err = edsStore.cache.Load().Get(shard.KeyFromString(dah.String()))
+	require.Error(t, err)
+	got, err := edsStore.Get(ctx, dah.Hash())
+	require.NoError(t, err)
+	require.True(t, eds.Equals(got))
+
+	err = edsStore.Remove(ctx, dah.Hash())
+	require.NoError(t, err)
+	_, err = os.Stat(coldPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestEDSStore_HeightReferences verifies that a square shared by multiple heights is removed only
+// after the last referencing height is removed.
+func TestEDSStore_HeightReferences(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.PutAtHeight(ctx, dah.Hash(), 1, eds)
+	require.NoError(t, err)
+	err = edsStore.PutAtHeight(ctx, dah.Hash(), 2, eds)
+	require.ErrorIs(t, err, dagstore.ErrShardExists)
+
+	err = edsStore.RemoveAtHeight(ctx, dah.Hash(), 1)
+	require.NoError(t, err)
+	has, err := edsStore.Has(ctx, dah.Hash())
+	require.NoError(t, err)
+	require.True(t, has)
+
+	err = edsStore.RemoveAtHeight(ctx, dah.Hash(), 2)
+	require.NoError(t, err)
+	has, err = edsStore.Has(ctx, dah.Hash())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// squares stored without references are removed right away
+	eds, dah = randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+	err = edsStore.RemoveAtHeight(ctx, dah.Hash(), 1)
+	require.NoError(t, err)
+	has, err = edsStore.Has(ctx, dah.Hash())
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
 func Test_BlockstoreCache(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -0,0 +1,137 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/filecoin-project/dagstore/shard"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// tier periodically migrates squares older than coldAge from the hot blocks directory to the cold
+// one. Migrated squares stay registered on the DAGStore and are transparently read from the cold
+// tier by inMemoryOnceMount.
+func (s *Store) tier(ctx context.Context) {
+	ticker := time.NewTicker(s.tieringInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tnow := time.Now()
+			migrated, err := s.migrateCold(ctx)
+			s.metrics.observeTiering(ctx, time.Since(tnow), migrated, err != nil)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorw("migrating squares to cold storage", "migrated", migrated, "err", err)
+			}
+		}
+	}
+}
+
+// migrateCold moves every square in the hot blocks directory older than coldAge to the cold tier
+// and returns the amount of migrated squares.
+func (s *Store) migrateCold(ctx context.Context) (int, error) {
+	entries, err := os.ReadDir(s.basepath + blocksPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read blocks directory: %w", err)
+	}
+
+	var migrated int
+	cutoff := time.Now().Add(-s.coldAge)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return migrated, ctx.Err()
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// the file was removed in the meantime
+			continue
+		}
+		if info.ModTime().After(cutoff) {
+			continue
+		}
+
+		root, err := hex.DecodeString(entry.Name())
+		if err != nil || share.DataHash(root).Validate() != nil {
+			// not a CAR file of the store, e.g. leftover of an interrupted write
+			continue
+		}
+		if err := s.moveToCold(root); err != nil {
+			return migrated, fmt.Errorf("failed to migrate %s: %w", entry.Name(), err)
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// moveToCold moves the CAR file of the given square from the hot to the cold tier.
+func (s *Store) moveToCold(root share.DataHash) error {
+	lk := &s.stripedLocks[root[len(root)-1]]
+	lk.Lock()
+	defer lk.Unlock()
+
+	key := root.String()
+	hotPath, coldPath := s.basepath+blocksPath+key, s.coldDir+key
+	// the tiers are usually on different volumes, so rename will fail in most cases
+	if err := os.Rename(hotPath, coldPath); err != nil {
+		if err := copyFile(hotPath, coldPath); err != nil {
+			return err
+		}
+
+		err = os.Remove(hotPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// the square was removed concurrently while being copied, so clean up the copy
+			return os.Remove(coldPath)
+		case err != nil:
+			return fmt.Errorf("failed to remove hot CAR file: %w", err)
+		}
+	}
+
+	// cached accessors keep the hot file open, so release them to free up the space on the hot tier
+	if err := s.cache.Load().Remove(shard.KeyFromString(key)); err != nil {
+		log.Warnw("remove migrated accessor from cache", "key", key, "err", err)
+	}
+	return nil
+}
+
+// copyFile durably copies the file at src to dst. The copy is written to a temporary file first, so
+// that dst never contains partially written data.
+func copyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer closeAndLog("source file", in)
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(tmp)
+		}
+	}()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err = out.Sync(); err != nil {
+		return fmt.Errorf("failed to sync destination file: %w", err)
+	}
+	if err = out.Close(); err != nil {
+		return fmt.Errorf("failed to close destination file: %w", err)
+	}
+	return os.Rename(tmp, dst)
+}
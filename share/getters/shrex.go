@@ -99,6 +99,13 @@ type ShrexGetter struct {
 	// attempt multiple peers in scope of one request before context timeout is reached
 	minAttemptsCount int
 
+	// parallelPeers is the amount of peers the EDS is downloaded from at once by row ranges.
+	parallelPeers int
+	// stallTimeout is the time after which row ranges not received yet are requested as parity.
+	stallTimeout time.Duration
+	// useNamespaceFilters enables skipping blocks announced as not containing the namespace.
+	useNamespaceFilters bool
+
 	availabilityWindow pruner.AvailabilityWindow
 
 	metrics *metrics
@@ -156,6 +163,19 @@ func (sg *ShrexGetter) GetEDS(ctx context.Context, header *header.ExtendedHeader
 		return share.EmptyExtendedDataSquare(), nil
 	}
 
+	// parallel download needs at least two ODS rows to split
+	if sg.parallelPeers > 1 && len(header.DAH.RowRoots) > 2 {
+		var eds *rsmt2d.ExtendedDataSquare
+		eds, err = sg.getEDSParallel(ctx, header)
+		if err == nil || ctx.Err() != nil {
+			return eds, err
+		}
+		log.Debugw("eds: parallel download failed, falling back to a single peer",
+			"hash", header.DAH.String(),
+			"err", err)
+		err = nil
+	}
+
 	var attempt int
 	for {
 		if ctx.Err() != nil {
@@ -181,19 +201,24 @@ func (sg *ShrexGetter) GetEDS(ctx context.Context, header *header.ExtendedHeader
 		cancel()
 		switch {
 		case getErr == nil:
-			setStatus(peers.ResultNoop)
+			// only the original quadrant is sent over the wire
+			odsWidth := int(eds.Width() / 2)
+			setStatus(peers.ResultNoop, odsWidth*odsWidth*share.Size)
 			sg.metrics.recordEDSAttempt(ctx, attempt, true)
 			return eds, nil
 		case errors.Is(getErr, context.DeadlineExceeded),
 			errors.Is(getErr, context.Canceled):
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultCooldownPeer, 0)
+		case errors.Is(getErr, p2p.ErrRateLimited):
+			// give the overloaded peer time to recover
+			setStatus(peers.ResultCooldownPeer, 0)
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultNotFound, 0)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
-			setStatus(peers.ResultBlacklistPeer)
+			setStatus(peers.ResultBlacklistPeer, 0)
 		default:
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultCooldownPeer, 0)
 		}
 
 		if !ErrorContains(err, getErr) {
@@ -234,6 +259,14 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 		return []share.NamespacedRow{}, nil
 	}
 
+	// skip the block if peers announced it does not contain the namespace
+	if sg.useNamespaceFilters && !sg.mayContainNamespace(header, namespace) {
+		log.Debugw("nd: namespace excluded by announced filters",
+			"hash", dah.String(),
+			"namespace", namespace.String())
+		return []share.NamespacedRow{}, nil
+	}
+
 	for {
 		if ctx.Err() != nil {
 			sg.metrics.recordNDAttempt(ctx, attempt, false)
@@ -262,22 +295,25 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 			// both inclusion and non-inclusion cases needs verification
 			if verErr := nd.Verify(dah, namespace); verErr != nil {
 				getErr = verErr
-				setStatus(peers.ResultBlacklistPeer)
+				setStatus(peers.ResultBlacklistPeer, 0)
 				break
 			}
-			setStatus(peers.ResultNoop)
+			setStatus(peers.ResultNoop, len(nd.Flatten())*share.Size)
 			sg.metrics.recordNDAttempt(ctx, attempt, true)
 			return nd, nil
 		case errors.Is(getErr, context.DeadlineExceeded),
 			errors.Is(getErr, context.Canceled):
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultCooldownPeer, 0)
+		case errors.Is(getErr, p2p.ErrRateLimited):
+			// give the overloaded peer time to recover
+			setStatus(peers.ResultCooldownPeer, 0)
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultNotFound, 0)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
-			setStatus(peers.ResultBlacklistPeer)
+			setStatus(peers.ResultBlacklistPeer, 0)
 		default:
-			setStatus(peers.ResultCooldownPeer)
+			setStatus(peers.ResultCooldownPeer, 0)
 		}
 
 		if !ErrorContains(err, getErr) {
@@ -293,6 +329,20 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 	}
 }
 
+// WithNamespaceFilters makes GetSharesByNamespace trust namespace filters announced over
+// shrexsub and skip blocks that do not contain the namespace according to them. Empty results of
+// skipped blocks come with no proofs of absence.
+func (sg *ShrexGetter) WithNamespaceFilters() {
+	sg.useNamespaceFilters = true
+}
+
+func (sg *ShrexGetter) mayContainNamespace(header *header.ExtendedHeader, namespace share.Namespace) bool {
+	if !pruner.IsWithinAvailabilityWindow(header.Time(), sg.availabilityWindow) {
+		return sg.archivalPeerManager.MayContainNamespace(header.DAH.Hash(), namespace)
+	}
+	return sg.fullPeerManager.MayContainNamespace(header.DAH.Hash(), namespace)
+}
+
 func (sg *ShrexGetter) getPeer(
 	ctx context.Context,
 	header *header.ExtendedHeader,
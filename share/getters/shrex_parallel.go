@@ -0,0 +1,230 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/p2p"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
+)
+
+// WithParallelEDS enables downloading the EDS from multiple peers at once. The ODS rows are split
+// into parallelPeers ranges, each requested from a different peer. Ranges that were not received
+// within stallTimeout are additionally requested as parity rows from other peers, so a single slow
+// peer does not hold up the whole download. Values of parallelPeers below 2 disable it.
+func (sg *ShrexGetter) WithParallelEDS(parallelPeers int, stallTimeout time.Duration) {
+	sg.parallelPeers = parallelPeers
+	sg.stallTimeout = stallTimeout
+}
+
+// rowRange is a range of EDS rows [from, to) fetched from a single peer.
+type rowRange struct {
+	from, to int
+}
+
+type rowsResult struct {
+	part   int
+	parity bool
+	rows   [][]share.Share
+	err    error
+}
+
+// getEDSParallel downloads the EDS by row ranges from multiple peers. Any half of the EDS rows is
+// enough to repair the square, so every ODS row range can be replaced by the matching range of
+// parity rows.
+func (sg *ShrexGetter) getEDSParallel(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	odsWidth := len(header.DAH.RowRoots) / 2
+	parts := splitRows(odsWidth, sg.parallelPeers)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan rowsResult, len(parts)*2)
+	fetch := func(part int, parity bool) {
+		rng := parts[part]
+		if parity {
+			rng = rowRange{from: rng.from + odsWidth, to: rng.to + odsWidth}
+		}
+		go func() {
+			rows, err := sg.fetchRows(ctx, header, rng)
+			results <- rowsResult{part: part, parity: parity, rows: rows, err: err}
+		}()
+	}
+	for part := range parts {
+		fetch(part, false)
+	}
+
+	stall := time.NewTimer(sg.stallTimeout)
+	defer stall.Stop()
+
+	var (
+		// done tracks the parts for which either ODS or parity rows were received, while
+		// failed tracks the amount of failed requests per part.
+		done     = make([]bool, len(parts))
+		failed   = make([]int, len(parts))
+		parity   = make([]bool, len(parts))
+		received = make(map[int][]share.Share, odsWidth)
+		err      error
+	)
+	for len(received) < odsWidth {
+		select {
+		case <-stall.C:
+			for part := range parts {
+				if !done[part] && !parity[part] {
+					log.Debugw("eds: row range stalled, requesting parity rows",
+						"hash", header.DAH.String(),
+						"from", parts[part].from,
+						"to", parts[part].to)
+					parity[part] = true
+					fetch(part, true)
+				}
+			}
+		case res := <-results:
+			if res.err != nil {
+				failed[res.part]++
+				if !ErrorContains(err, res.err) {
+					err = errors.Join(err, res.err)
+				}
+				switch {
+				case done[res.part]:
+				case !parity[res.part]:
+					// no need to wait for the stall, recover the range from parity rows right away
+					parity[res.part] = true
+					fetch(res.part, true)
+				case failed[res.part] == 2:
+					return nil, err
+				}
+				continue
+			}
+			if done[res.part] {
+				continue
+			}
+			done[res.part] = true
+
+			from := parts[res.part].from
+			if res.parity {
+				from += odsWidth
+			}
+			for i, row := range res.rows {
+				received[from+i] = row
+			}
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		}
+	}
+
+	return repairRows(header.DAH, received)
+}
+
+// fetchRows requests the range of rows from peers until it succeeds, no peers are left or the
+// attempts are exhausted, so the caller can fall back to a full ODS download.
+func (sg *ShrexGetter) fetchRows(
+	ctx context.Context,
+	header *header.ExtendedHeader,
+	rng rowRange,
+) ([][]share.Share, error) {
+	var err error
+	for attempt := 1; attempt <= sg.minAttemptsCount; attempt++ {
+		if ctx.Err() != nil {
+			return nil, errors.Join(err, ctx.Err())
+		}
+		start := time.Now()
+
+		peer, setStatus, getErr := sg.getPeer(ctx, header)
+		if getErr != nil {
+			log.Debugw("eds rows: couldn't find peer",
+				"hash", header.DAH.String(),
+				"err", getErr,
+				"finished (s)", time.Since(start))
+			return nil, errors.Join(err, getErr)
+		}
+
+		reqStart := time.Now()
+		reqCtx, cancel := ctxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minRequestTimeout)
+		rows, getErr := sg.edsClient.RequestRows(reqCtx, header.DAH, rng.from, rng.to, peer)
+		cancel()
+		switch {
+		case getErr == nil:
+			// only the left half of each row is sent over the wire
+			setStatus(peers.ResultNoop, len(rows)*len(rows[0])/2*share.Size)
+			return rows, nil
+		case errors.Is(getErr, context.DeadlineExceeded),
+			errors.Is(getErr, context.Canceled):
+			setStatus(peers.ResultCooldownPeer, 0)
+		case errors.Is(getErr, p2p.ErrRateLimited):
+			// give the overloaded peer time to recover
+			setStatus(peers.ResultCooldownPeer, 0)
+		case errors.Is(getErr, p2p.ErrNotFound):
+			getErr = share.ErrNotFound
+			setStatus(peers.ResultNotFound, 0)
+		case errors.Is(getErr, p2p.ErrInvalidResponse):
+			setStatus(peers.ResultBlacklistPeer, 0)
+		default:
+			setStatus(peers.ResultCooldownPeer, 0)
+		}
+
+		if !ErrorContains(err, getErr) {
+			err = errors.Join(err, getErr)
+		}
+		log.Debugw("eds rows: request failed",
+			"hash", header.DAH.String(),
+			"peer", peer.String(),
+			"from", rng.from,
+			"to", rng.to,
+			"attempt", attempt,
+			"err", getErr,
+			"finished (s)", time.Since(reqStart))
+	}
+	return nil, err
+}
+
+// splitRows splits ODS rows into at most parts ranges of nearly equal size.
+func splitRows(odsWidth, parts int) []rowRange {
+	parts = min(parts, odsWidth)
+	ranges := make([]rowRange, 0, parts)
+	for i := 0; i < parts; i++ {
+		ranges = append(ranges, rowRange{
+			from: i * odsWidth / parts,
+			to:   (i + 1) * odsWidth / parts,
+		})
+	}
+	return ranges
+}
+
+// repairRows reconstructs the EDS out of at least half of its verified rows.
+func repairRows(dah *share.Root, rows map[int][]share.Share) (*rsmt2d.ExtendedDataSquare, error) {
+	width := len(dah.RowRoots)
+	eds, err := rsmt2d.NewExtendedDataSquare(
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(width/2)),
+		uint(width),
+		share.Size,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating eds: %w", err)
+	}
+
+	for rowIdx, row := range rows {
+		for colIdx, shr := range row {
+			if err := eds.SetCell(uint(rowIdx), uint(colIdx), shr); err != nil {
+				return nil, fmt.Errorf("setting share (%d, %d): %w", rowIdx, colIdx, err)
+			}
+		}
+	}
+
+	err = eds.Repair(dah.RowRoots, dah.ColumnRoots)
+	if err != nil {
+		return nil, fmt.Errorf("repairing eds: %w", err)
+	}
+	return eds, nil
+}
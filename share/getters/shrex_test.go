@@ -179,6 +179,30 @@ func TestShrexGetter(t *testing.T) {
 		require.Equal(t, randEDS.Flattened(), got.Flattened())
 	})
 
+	t.Run("EDS_parallel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		t.Cleanup(cancel)
+
+		// stall right away, so parity rows are requested as well
+		getter.WithParallelEDS(3, time.Nanosecond)
+		t.Cleanup(func() {
+			getter.WithParallelEDS(0, 0)
+		})
+
+		// generate test data
+		randEDS, dah, _ := generateTestEDS(t)
+		eh := headertest.RandExtendedHeaderWithRoot(t, dah)
+		require.NoError(t, edsStore.Put(ctx, dah.Hash(), randEDS))
+		fullPeerManager.Validate(ctx, srvHost.ID(), shrexsub.Notification{
+			DataHash: dah.Hash(),
+			Height:   1,
+		})
+
+		got, err := getter.GetEDS(ctx, eh)
+		require.NoError(t, err)
+		require.Equal(t, randEDS.Flattened(), got.Flattened())
+	})
+
 	t.Run("EDS_ctx_deadline", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(ctx, time.Second)
 
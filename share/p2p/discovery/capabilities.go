@@ -0,0 +1,170 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	"github.com/celestiaorg/celestia-node/share/p2p"
+	pb "github.com/celestiaorg/celestia-node/share/p2p/discovery/pb"
+)
+
+const (
+	capabilitiesProtocolString = "/shrex/capabilities/v0.0.1"
+
+	// capabilitiesTimeout limits the time of a single capabilities exchange.
+	capabilitiesTimeout = 10 * time.Second
+)
+
+// capabilitiesProtocolID returns the protocol the capabilities are exchanged over.
+func capabilitiesProtocolID(networkID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s%s", networkID, capabilitiesProtocolString))
+}
+
+// Capabilities describe what data a node can serve.
+type Capabilities struct {
+	// Archival nodes store all the blocks.
+	Archival bool `json:"archival"`
+	// FromHeight is the lowest height a pruned node stores blocks from. It only grows as the node
+	// prunes, so discovery refreshes capabilities periodically.
+	FromHeight uint64 `json:"from_height"`
+	// Window is the availability window of a pruned node. It is informational only, as heights
+	// can't be told from it without the header times; CanServe relies on FromHeight instead.
+	Window time.Duration `json:"window"`
+	// Protocols lists shrex protocols served by the node.
+	Protocols []protocol.ID `json:"protocols"`
+}
+
+// CanServe reports whether the node stores the block at the given height.
+func (c Capabilities) CanServe(height uint64) bool {
+	return c.Archival || height >= c.FromHeight
+}
+
+// CapabilitiesFn returns up-to-date capabilities of the node.
+type CapabilitiesFn func() Capabilities
+
+// OnCapabilities is called with capabilities received from a discovered peer.
+type OnCapabilities func(peerID peer.ID, capabilities Capabilities)
+
+// ShrexProtocols returns shrex protocols registered on the host.
+func ShrexProtocols(h host.Host) []protocol.ID {
+	var protocols []protocol.ID
+	for _, id := range h.Mux().Protocols() {
+		if strings.Contains(string(id), "/shrex/") && !strings.HasSuffix(string(id), capabilitiesProtocolString) {
+			protocols = append(protocols, id)
+		}
+	}
+	slices.Sort(protocols)
+	return protocols
+}
+
+// CapabilitiesServer responds to capabilities requests of discovering peers.
+type CapabilitiesServer struct {
+	host         host.Host
+	protocolID   protocol.ID
+	capabilities CapabilitiesFn
+	// middlewares are run around every request
+	middlewares []p2p.StreamMiddleware
+}
+
+// NewCapabilitiesServer creates a new CapabilitiesServer.
+func NewCapabilitiesServer(h host.Host, networkID string, capabilities CapabilitiesFn) *CapabilitiesServer {
+	return &CapabilitiesServer{
+		host:         h,
+		protocolID:   capabilitiesProtocolID(networkID),
+		capabilities: capabilities,
+	}
+}
+
+// WithMiddlewares adds middlewares to run around every request, e.g. the access policies of the
+// shrex servers. Middlewares are run in the given order after the panic recovery. It must be called
+// before Start.
+func (s *CapabilitiesServer) WithMiddlewares(middlewares ...p2p.StreamMiddleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+func (s *CapabilitiesServer) Start(context.Context) error {
+	middlewares := append([]p2p.StreamMiddleware{p2p.RecoveryMiddleware}, s.middlewares...)
+	s.host.SetStreamHandler(s.protocolID, p2p.Chain(s.handleStream, middlewares...))
+	return nil
+}
+
+func (s *CapabilitiesServer) Stop(context.Context) error {
+	s.host.RemoveStreamHandler(s.protocolID)
+	return nil
+}
+
+func (s *CapabilitiesServer) handleStream(stream network.Stream) {
+	logger := log.With("peer", stream.Conn().RemotePeer().String())
+
+	err := stream.SetWriteDeadline(time.Now().Add(capabilitiesTimeout))
+	if err != nil {
+		logger.Debugw("capabilities: set write deadline", "err", err)
+	}
+
+	capabilities := s.capabilities()
+	resp := &pb.Capabilities{
+		Archival:   capabilities.Archival,
+		FromHeight: capabilities.FromHeight,
+		Window:     int64(capabilities.Window),
+		Protocols:  make([]string, len(capabilities.Protocols)),
+	}
+	for i, id := range capabilities.Protocols {
+		resp.Protocols[i] = string(id)
+	}
+
+	_, err = serde.Write(stream, resp)
+	if err != nil {
+		logger.Debugw("capabilities: writing response", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	if err = stream.Close(); err != nil {
+		logger.Debugw("capabilities: closing stream", "err", err)
+	}
+}
+
+// RequestCapabilities requests capabilities of the peer.
+func RequestCapabilities(ctx context.Context, h host.Host, networkID string, peerID peer.ID) (Capabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, capabilitiesTimeout)
+	defer cancel()
+
+	stream, err := h.NewStream(ctx, peerID, capabilitiesProtocolID(networkID))
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("opening stream: %w", err)
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetReadDeadline(deadline); err != nil {
+			log.Debugw("capabilities: set read deadline", "err", err)
+		}
+	}
+
+	resp := new(pb.Capabilities)
+	_, err = serde.Read(stream, resp)
+	if err != nil {
+		stream.Reset() //nolint:errcheck
+		return Capabilities{}, fmt.Errorf("reading capabilities: %w", err)
+	}
+
+	capabilities := Capabilities{
+		Archival:   resp.Archival,
+		FromHeight: resp.FromHeight,
+		Window:     time.Duration(resp.Window),
+		Protocols:  make([]protocol.ID, len(resp.Protocols)),
+	}
+	for i, id := range resp.Protocols {
+		capabilities.Protocols[i] = protocol.ID(id)
+	}
+	return capabilities, nil
+}
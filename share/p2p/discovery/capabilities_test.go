@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/p2p"
+)
+
+func TestCapabilities(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	server, client := net.Hosts()[0], net.Hosts()[1]
+
+	shrexProtocol := protocol.ID("/private/shrex/v0.0.1")
+	server.SetStreamHandler(shrexProtocol, nil)
+
+	capabilities := Capabilities{
+		FromHeight: 100,
+		Window:     time.Hour,
+		Protocols:  ShrexProtocols(server),
+	}
+	srv := NewCapabilitiesServer(server, "private", func() Capabilities {
+		return capabilities
+	})
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(ctx))
+	})
+
+	got, err := RequestCapabilities(ctx, client, "private", server.ID())
+	require.NoError(t, err)
+	assert.Equal(t, capabilities, got)
+	assert.False(t, got.CanServe(99))
+	assert.True(t, got.CanServe(100))
+
+	// network id scopes the protocol
+	_, err = RequestCapabilities(ctx, client, "other", server.ID())
+	require.Error(t, err)
+}
+
+func TestCapabilities_Middlewares(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err)
+	server, allowed, denied := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2]
+
+	srv := NewCapabilitiesServer(server, "private", func() Capabilities {
+		return Capabilities{Archival: true}
+	})
+	srv.WithMiddlewares(p2p.AccessMiddleware(p2p.AllowList(allowed.ID())))
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(ctx))
+	})
+
+	got, err := RequestCapabilities(ctx, allowed, "private", server.ID())
+	require.NoError(t, err)
+	assert.True(t, got.Archival)
+
+	// private nodes don't reveal what they serve to the peers they don't serve
+	_, err = RequestCapabilities(ctx, denied, "private", server.ID())
+	require.Error(t, err)
+}
+
+func TestCapabilities_CanServe(t *testing.T) {
+	archival := Capabilities{Archival: true}
+	assert.True(t, archival.CanServe(1))
+
+	pruned := Capabilities{FromHeight: 10}
+	assert.False(t, pruned.CanServe(9))
+	assert.True(t, pruned.CanServe(10))
+}
@@ -36,8 +36,13 @@ const (
 	logInterval = 5 * time.Minute
 )
 
-// discoveryRetryTimeout defines time interval between discovery attempts, needed for tests
-var discoveryRetryTimeout = retryTimeout
+var (
+	// discoveryRetryTimeout defines time interval between discovery attempts, needed for tests
+	discoveryRetryTimeout = retryTimeout
+	// capabilitiesRefreshInterval defines time interval between requesting capabilities of the
+	// discovered peers again, as pruned peers advance the lowest height they store.
+	capabilitiesRefreshInterval = 10 * time.Minute
+)
 
 // Discovery combines advertise and discover services and allows to store discovered nodes.
 // TODO: The code here gets horribly hairy, so we should refactor this at some point
@@ -51,6 +56,9 @@ type Discovery struct {
 
 	// onUpdatedPeers will be called on peer set changes
 	onUpdatedPeers OnUpdatedPeers
+	// onCapabilities will be called with capabilities of discovered peers, if set
+	onCapabilities OnCapabilities
+	networkID      string
 	// indicates whether the discovery instance should also advertise
 	// to the topic
 	advertise bool
@@ -96,6 +104,8 @@ func NewDiscovery(
 		disc:           d,
 		connector:      newBackoffConnector(h, defaultBackoffFactory),
 		onUpdatedPeers: o.onUpdatedPeers,
+		onCapabilities: o.onCapabilities,
+		networkID:      o.networkID,
 		advertise:      o.advertise,
 		params:         params,
 		triggerDisc:    make(chan struct{}),
@@ -114,6 +124,9 @@ func (d *Discovery) Start(context.Context) error {
 	go d.discoveryLoop(ctx)
 	go d.disconnectsLoop(ctx, sub)
 	go d.connector.GC(ctx)
+	if d.onCapabilities != nil {
+		go d.capabilitiesLoop(ctx)
+	}
 
 	if d.advertise {
 		log.Infow("advertising to topic", "topic", d.tag)
@@ -386,9 +399,56 @@ func (d *Discovery) handleDiscoveredPeer(ctx context.Context, peer peer.AddrInfo
 	//  In the future, we should design a protocol that keeps bidirectional agreement on whether
 	//  connection should be kept or not, similar to mesh link in GossipSub.
 	d.host.ConnManager().Protect(peer.ID, d.tag)
+
+	if d.onCapabilities != nil {
+		go d.requestCapabilities(ctx, peer.ID)
+	}
 	return true
 }
 
+// requestCapabilities requests capabilities of the discovered peer and reports them.
+func (d *Discovery) requestCapabilities(ctx context.Context, peerID peer.ID) {
+	capabilities, err := RequestCapabilities(ctx, d.host, d.networkID, peerID)
+	if err != nil {
+		// older nodes do not serve capabilities, so they stay with unknown ones
+		log.Debugw("requesting capabilities", "peer", peerID.String(), "err", err)
+		return
+	}
+	if !d.set.Contains(peerID) {
+		return
+	}
+	log.Debugw("got peer capabilities",
+		"peer", peerID.String(),
+		"archival", capabilities.Archival,
+		"from_height", capabilities.FromHeight)
+	d.onCapabilities(peerID, capabilities)
+}
+
+// capabilitiesLoop periodically refreshes capabilities of the discovered peers.
+func (d *Discovery) capabilitiesLoop(ctx context.Context) {
+	ticker := time.NewTicker(capabilitiesRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if d.set.Size() == 0 {
+			continue
+		}
+		peers, err := d.set.Peers(ctx)
+		if err != nil {
+			return
+		}
+		for _, peerID := range peers {
+			d.requestCapabilities(ctx, peerID)
+		}
+	}
+}
+
 func drainChannel(c <-chan time.Time) {
 	for {
 		select {
@@ -24,6 +24,10 @@ type options struct {
 	// advertise indicates whether the node should also
 	// advertise to the discovery instance's topic
 	advertise bool
+	// onCapabilities will be called with capabilities of discovered peers
+	onCapabilities OnCapabilities
+	// networkID scopes the capabilities protocol
+	networkID string
 }
 
 // Option is a function that configures Discovery Parameters
@@ -63,6 +67,16 @@ func WithAdvertise() Option {
 	}
 }
 
+// WithCapabilities requests capabilities of every discovered peer and passes them to the given
+// callback. Peers that do not serve capabilities are reported to the callback only by
+// OnUpdatedPeers.
+func WithCapabilities(networkID string, f OnCapabilities) Option {
+	return func(p *options) {
+		p.networkID = networkID
+		p.onCapabilities = f
+	}
+}
+
 func newOptions(opts ...Option) *options {
 	defaults := &options{
 		onUpdatedPeers: func(peer.ID, bool) {},
@@ -14,5 +14,21 @@
 // shares. The peer manager is primarily responsible for providing peers to request shares from,
 // and is primarily used by `getters.ShrexGetter` in share/getters/shrex.go.
 //
+// Servers of the request/response protocols limit the amount of concurrently handled requests and
+// can enforce per-peer request rate and bandwidth budgets. Requests over the concurrency limit get
+// their streams closed right away, while requests over the per-peer budgets are answered with the
+// RATE_LIMITED status, unless the negotiated protocol version does not define it. In both cases, the
+// clients put such peers on cooldown.
+//
+// Additional stream middlewares, e.g. access policies, request logging or tracing, can be chained
+// around the server handlers with Chain. They run after the panic recovery and before the rate
+// limiting.
+//
+// Protocol IDs of the request/response protocols are versioned. Servers handle every supported
+// version, while clients negotiate the newest version supported by both sides and fall back to
+// older ones, so that protocol changes can be rolled out without upgrading the whole network at
+// once. Both sides use only the messages and fields defined by the version negotiated for the
+// stream. The versions served by a node are reported in its discovery capabilities.
+//
 // Find out more about each protocol in their respective sub-packages.
 package p2p
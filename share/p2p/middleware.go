@@ -1,13 +1,25 @@
 package p2p
 
 import (
+	"context"
 	"sync/atomic"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var log = logging.Logger("shrex/middleware")
+var (
+	log = logging.Logger("shrex/middleware")
+	// requestsLog is separate, so that logging of requests can be tuned independently
+	requestsLog = logging.Logger("shrex/requests")
+	tracer      = otel.Tracer("share/p2p")
+)
 
 type Middleware struct {
 	// concurrencyLimit is the maximum number of requests that can be processed at once.
@@ -16,12 +28,46 @@ type Middleware struct {
 	parallelRequests atomic.Int64
 	// numRateLimited is the number of requests that were rate limited.
 	numRateLimited atomic.Int64
+
+	// peerLimiter enforces per-peer budgets. Nil PeerLimiter allows all requests.
+	peerLimiter *PeerLimiter
+	// reject informs the peer that the request exceeded its budget. The stream is closed if it is
+	// not set.
+	reject network.StreamHandler
+}
+
+// RejectReadTimeout limits the time a reject handler waits for the request of a rejected stream,
+// so that slow peers can't keep the handlers busy.
+const RejectReadTimeout = time.Second
+
+// MiddlewareOption configures the Middleware.
+type MiddlewareOption func(*Middleware)
+
+// WithPeerLimiter enforces per-peer request rate and bandwidth budgets.
+func WithPeerLimiter(limiter *PeerLimiter) MiddlewareOption {
+	return func(m *Middleware) {
+		m.peerLimiter = limiter
+	}
+}
+
+// WithRejectHandler sets the handler of streams exceeding the per-peer budgets, so that the server
+// can respond with a protocol specific status. The handler should not block for long, as it takes a
+// concurrency slot, so it should read requests within RejectReadTimeout. Streams over the
+// concurrency limit are closed right away, so that the limit sheds load cheaply.
+func WithRejectHandler(reject network.StreamHandler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.reject = reject
+	}
 }
 
-func NewMiddleware(concurrencyLimit int) *Middleware {
-	return &Middleware{
+func NewMiddleware(concurrencyLimit int, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
 		concurrencyLimit: int64(concurrencyLimit),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // DrainCounter returns the current value of the rate limit counter and resets it to 0.
@@ -29,20 +75,122 @@ func (m *Middleware) DrainCounter() int64 {
 	return m.numRateLimited.Swap(0)
 }
 
+// Consume charges the bytes served to the peer from its bandwidth budget.
+func (m *Middleware) Consume(peerID peer.ID, bytes int) {
+	m.peerLimiter.Consume(peerID, bytes)
+}
+
 func (m *Middleware) RateLimitHandler(handler network.StreamHandler) network.StreamHandler {
 	return func(stream network.Stream) {
 		current := m.parallelRequests.Add(1)
 		defer m.parallelRequests.Add(-1)
 
 		if current > m.concurrencyLimit {
-			m.numRateLimited.Add(1)
 			log.Debug("concurrency limit reached")
-			err := stream.Close()
-			if err != nil {
-				log.Debugw("server: closing stream", "err", err)
-			}
+			m.rateLimited(stream, nil)
+			return
+		}
+		if peerID := stream.Conn().RemotePeer(); !m.peerLimiter.Allow(peerID) {
+			log.Debugw("peer limit reached", "peer", peerID.String())
+			m.rateLimited(stream, m.reject)
 			return
 		}
 		handler(stream)
 	}
 }
+
+// rateLimited rejects the stream with the given handler, or closes it if there is none.
+func (m *Middleware) rateLimited(stream network.Stream, reject network.StreamHandler) {
+	m.numRateLimited.Add(1)
+	if reject != nil {
+		reject(stream)
+		return
+	}
+	err := stream.Close()
+	if err != nil {
+		log.Debugw("server: closing stream", "err", err)
+	}
+}
+
+// StreamMiddleware wraps a stream handler of a shrex server to run logic around handling of
+// requests.
+type StreamMiddleware func(network.StreamHandler) network.StreamHandler
+
+// Chain wraps the handler with the middlewares. The first middleware is the outermost one, so it
+// is the first to see the stream.
+func Chain(handler network.StreamHandler, middlewares ...StreamMiddleware) network.StreamHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// AccessPolicy reports whether the peer is allowed to make requests over the protocol.
+type AccessPolicy func(peerID peer.ID, protocolID protocol.ID) bool
+
+// AllowList returns an AccessPolicy that allows only the given peers.
+func AllowList(peers ...peer.ID) AccessPolicy {
+	allowed := make(map[peer.ID]struct{}, len(peers))
+	for _, peerID := range peers {
+		allowed[peerID] = struct{}{}
+	}
+	return func(peerID peer.ID, _ protocol.ID) bool {
+		_, ok := allowed[peerID]
+		return ok
+	}
+}
+
+// AccessMiddleware resets streams of peers that are not allowed by the policy.
+func AccessMiddleware(policy AccessPolicy) StreamMiddleware {
+	return func(handler network.StreamHandler) network.StreamHandler {
+		return func(stream network.Stream) {
+			peerID := stream.Conn().RemotePeer()
+			if !policy(peerID, stream.Protocol()) {
+				log.Debugw("access denied", "peer", peerID.String(), "protocol", stream.Protocol())
+				stream.Reset() //nolint:errcheck
+				return
+			}
+			handler(stream)
+		}
+	}
+}
+
+// LoggingMiddleware logs every handled request along with the time it took.
+func LoggingMiddleware(handler network.StreamHandler) network.StreamHandler {
+	return func(stream network.Stream) {
+		start := time.Now()
+		handler(stream)
+		requestsLog.Infow("handled request",
+			"peer", stream.Conn().RemotePeer().String(),
+			"protocol", stream.Protocol(),
+			"duration", time.Since(start))
+	}
+}
+
+// TracingMiddleware records a span for every handled request. The span is carried by the stream
+// passed to the next handlers, which can nest their spans under it using StreamContext.
+func TracingMiddleware(handler network.StreamHandler) network.StreamHandler {
+	return func(stream network.Stream) {
+		_, span := tracer.Start(context.Background(), "shrex/handle-request", trace.WithAttributes(
+			attribute.String("peer", stream.Conn().RemotePeer().String()),
+			attribute.String("protocol", string(stream.Protocol())),
+		))
+		defer span.End()
+		handler(&tracedStream{Stream: stream, span: span})
+	}
+}
+
+// tracedStream carries the span of the request recorded by TracingMiddleware.
+type tracedStream struct {
+	network.Stream
+	span trace.Span
+}
+
+// StreamContext returns the parent context carrying the span of the request recorded for the
+// stream by TracingMiddleware. The parent is returned as is for streams without a span.
+func StreamContext(parent context.Context, stream network.Stream) context.Context {
+	if traced, ok := stream.(*tracedStream); ok {
+		return trace.ContextWithSpan(parent, traced.span)
+	}
+	return parent
+}
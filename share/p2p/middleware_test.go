@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	record := func(name string) StreamMiddleware {
+		return func(handler network.StreamHandler) network.StreamHandler {
+			return func(stream network.Stream) {
+				order = append(order, name)
+				handler(stream)
+			}
+		}
+	}
+
+	handler := Chain(func(network.Stream) {
+		order = append(order, "handler")
+	}, record("first"), record("second"))
+	handler(nil)
+	require.Equal(t, []string{"first", "second", "handler"}, order)
+}
+
+func TestMiddleware_ConcurrencyLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	server, client := net.Hosts()[0], net.Hosts()[1]
+
+	const protocolID = protocol.ID("/test/concurrency")
+	rejected := make(chan struct{}, 1)
+	middleware := NewMiddleware(0, WithRejectHandler(func(stream network.Stream) {
+		rejected <- struct{}{}
+		require.NoError(t, stream.Close())
+	}))
+	server.SetStreamHandler(protocolID, middleware.RateLimitHandler(func(network.Stream) {
+		t.Fatal("handled over the concurrency limit")
+	}))
+
+	// streams over the concurrency limit are closed without waiting for the request
+	stream, err := client.NewStream(ctx, server.ID(), protocolID)
+	require.NoError(t, err)
+	_, err = io.ReadAll(stream)
+	require.NoError(t, err)
+	require.Len(t, rejected, 0)
+	require.EqualValues(t, 1, middleware.DrainCounter())
+}
+
+func TestAccessMiddleware(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err)
+	server, allowed, denied := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2]
+
+	const protocolID = protocol.ID("/test/access")
+	handler := func(stream network.Stream) {
+		_, err := stream.Write([]byte("ok"))
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+	}
+	server.SetStreamHandler(protocolID, Chain(handler, AccessMiddleware(AllowList(allowed.ID()))))
+
+	stream, err := allowed.NewStream(ctx, server.ID(), protocolID)
+	require.NoError(t, err)
+	resp, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ok"), resp)
+
+	stream, err = denied.NewStream(ctx, server.ID(), protocolID)
+	require.NoError(t, err)
+	_, err = io.ReadAll(stream)
+	require.Error(t, err)
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+	})
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	server, client := net.Hosts()[0], net.Hosts()[1]
+
+	const protocolID = protocol.ID("/test/tracing")
+	spans := make(chan trace.Span, 1)
+	handler := func(stream network.Stream) {
+		spans <- trace.SpanFromContext(StreamContext(ctx, stream))
+		require.NoError(t, stream.Close())
+	}
+	server.SetStreamHandler(protocolID, Chain(handler, TracingMiddleware, LoggingMiddleware))
+
+	stream, err := client.NewStream(ctx, server.ID(), protocolID)
+	require.NoError(t, err)
+	_, err = io.ReadAll(stream)
+	require.NoError(t, err)
+
+	// the handler gets the span of the request
+	span := <-spans
+	require.True(t, span.SpanContext().IsValid())
+	require.Equal(t, ctx, StreamContext(ctx, stream))
+}
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -21,6 +23,18 @@ type Parameters struct {
 	// ConcurrencyLimit is the maximum number of concurrently handled streams
 	ConcurrencyLimit int
 
+	// PeerRequestsPerSecond is the average rate of requests served to a single peer. Set 0 to
+	// disable.
+	PeerRequestsPerSecond float64
+	// PeerRequestsBurst is the maximum amount of requests a single peer can make at once.
+	PeerRequestsBurst int
+
+	// PeerBandwidth is the average amount of bytes per second served to a single peer. Set 0 to
+	// disable.
+	PeerBandwidth uint64
+	// PeerBandwidthBurst is the maximum amount of bytes that can be served to a single peer at once.
+	PeerBandwidthBurst uint64
+
 	// networkID is prepended to the protocolID and represents the network the protocol is
 	// running on.
 	networkID string
@@ -50,6 +64,15 @@ func (p *Parameters) Validate() error {
 	if p.ConcurrencyLimit <= 0 {
 		return fmt.Errorf("invalid concurrency limit: %s", errSuffix)
 	}
+	if p.PeerRequestsPerSecond < 0 {
+		return fmt.Errorf("invalid peer requests rate: %v, value should not be negative", p.PeerRequestsPerSecond)
+	}
+	if p.PeerRequestsPerSecond > 0 && p.PeerRequestsBurst <= 0 {
+		return fmt.Errorf("invalid peer requests burst: %v, %s", p.PeerRequestsBurst, errSuffix)
+	}
+	if p.PeerBandwidth > 0 && p.PeerBandwidthBurst == 0 {
+		return fmt.Errorf("invalid peer bandwidth burst: %v, %s", p.PeerBandwidthBurst, errSuffix)
+	}
 	return nil
 }
 
@@ -67,3 +90,26 @@ func (p *Parameters) NetworkID() string {
 func ProtocolID(networkID, protocolString string) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/%s%s", networkID, protocolString))
 }
+
+// ProtocolIDs creates protocol IDs for every version of the protocol. Versions are expected to be
+// ordered from the newest to the oldest. Passing the IDs to host.NewStream negotiates the newest
+// version supported by the remote peer.
+func ProtocolIDs(networkID, protocolName string, versions ...string) []protocol.ID {
+	ids := make([]protocol.ID, len(versions))
+	for i, version := range versions {
+		ids[i] = ProtocolID(networkID, path.Join(protocolName, version))
+	}
+	return ids
+}
+
+// ProtocolVersion returns the version of the protocol ID created by ProtocolIDs.
+func ProtocolVersion(id protocol.ID) string {
+	return path.Base(string(id))
+}
+
+// IsVersionAtLeast reports whether the protocol ID created by ProtocolIDs is of the given version
+// or a newer one. Versions are expected to be ordered from the newest to the oldest.
+func IsVersionAtLeast(id protocol.ID, version string, versions []string) bool {
+	idx := slices.Index(versions, ProtocolVersion(id))
+	return idx != -1 && idx <= slices.Index(versions, version)
+}
@@ -0,0 +1,246 @@
+package peers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+)
+
+var blacklistPrefix = datastore.NewKey("shrex-blacklist")
+
+// blacklistGCInterval is the interval at which expired blacklist entries are removed.
+const blacklistGCInterval = time.Minute
+
+// BlacklistEntry describes a peer blocked from any p2p communication with the node.
+type BlacklistEntry struct {
+	ID     peer.ID `json:"id"`
+	Reason string  `json:"reason"`
+	// Time is when the peer was blacklisted.
+	Time time.Time `json:"time"`
+	// Expiry is when the peer is unblocked. Zero value means the peer is blacklisted permanently.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+func (e BlacklistEntry) expired(now time.Time) bool {
+	return !e.Expiry.IsZero() && !now.Before(e.Expiry)
+}
+
+// Blacklist keeps blacklisted peers in the datastore, so they stay blocked across restarts, and
+// unblocks them once their entry expires. The peers are blocked via libp2p connection gater.
+type Blacklist struct {
+	ds        datastore.Datastore
+	host      host.Host
+	connGater *conngater.BasicConnectionGater
+
+	lock    sync.Mutex
+	entries map[peer.ID]BlacklistEntry
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBlacklist creates a new Blacklist backed by the given datastore.
+func NewBlacklist(
+	ds datastore.Batching,
+	host host.Host,
+	connGater *conngater.BasicConnectionGater,
+) *Blacklist {
+	return &Blacklist{
+		ds:        namespace.Wrap(ds, blacklistPrefix),
+		host:      host,
+		connGater: connGater,
+		entries:   make(map[peer.ID]BlacklistEntry),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start loads persisted entries, blocks the peers that are still blacklisted and unblocks the ones
+// whose entries expired while the node was offline.
+func (b *Blacklist) Start(ctx context.Context) error {
+	res, err := b.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return fmt.Errorf("blacklist: querying entries: %w", err)
+	}
+	stored, err := res.Rest()
+	if err != nil {
+		return fmt.Errorf("blacklist: reading entries: %w", err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	for _, r := range stored {
+		var entry BlacklistEntry
+		if err := json.Unmarshal(r.Value, &entry); err != nil {
+			log.Warnw("blacklist: removing corrupted entry", "key", r.Key, "err", err)
+			if err := b.ds.Delete(ctx, datastore.NewKey(r.Key)); err != nil {
+				return fmt.Errorf("blacklist: deleting corrupted entry: %w", err)
+			}
+			continue
+		}
+
+		if entry.expired(now) {
+			if err := b.unblock(ctx, entry.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := b.connGater.BlockPeer(entry.ID); err != nil {
+			return fmt.Errorf("blacklist: blocking peer %s: %w", entry.ID, err)
+		}
+		b.entries[entry.ID] = entry
+	}
+	log.Infow("loaded peer blacklist", "amount", len(b.entries))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	go b.gc(ctx)
+	return nil
+}
+
+// Stop stops the expiry routine.
+func (b *Blacklist) Stop(ctx context.Context) error {
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Add blacklists the peer for the given ttl, or permanently if ttl is zero. The peer is blocked and
+// disconnected. Adding an already blacklisted peer overrides its entry.
+func (b *Blacklist) Add(ctx context.Context, peerID peer.ID, reason string, ttl time.Duration) error {
+	if err := peerID.Validate(); err != nil {
+		return fmt.Errorf("blacklist: invalid peer: %w", err)
+	}
+	if ttl < 0 {
+		return fmt.Errorf("blacklist: negative ttl")
+	}
+
+	entry := BlacklistEntry{
+		ID:     peerID,
+		Reason: reason,
+		Time:   time.Now(),
+	}
+	if ttl > 0 {
+		entry.Expiry = entry.Time.Add(ttl)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("blacklist: marshaling entry: %w", err)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.ds.Put(ctx, entryKey(peerID), data); err != nil {
+		return fmt.Errorf("blacklist: storing entry: %w", err)
+	}
+	b.entries[peerID] = entry
+
+	if err := b.connGater.BlockPeer(peerID); err != nil {
+		return fmt.Errorf("blacklist: blocking peer: %w", err)
+	}
+	if err := b.host.Network().ClosePeer(peerID); err != nil {
+		log.Warnw("failed to close connection with peer", "peer", peerID, "err", err)
+	}
+	return nil
+}
+
+// Remove removes the peer from the blacklist and unblocks it.
+func (b *Blacklist) Remove(ctx context.Context, peerID peer.ID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.unblock(ctx, peerID)
+}
+
+// List returns all active entries sorted by the time peers were blacklisted.
+func (b *Blacklist) List() []BlacklistEntry {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	entries := make([]BlacklistEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		if !entry.expired(now) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries
+}
+
+// gc periodically unblocks peers with expired entries.
+func (b *Blacklist) gc(ctx context.Context) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(blacklistGCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := b.removeExpired(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Errorw("blacklist: removing expired entries", "err", err)
+		}
+	}
+}
+
+func (b *Blacklist) removeExpired(ctx context.Context) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	for peerID, entry := range b.entries {
+		if !entry.expired(now) {
+			continue
+		}
+		log.Debugw("blacklist entry expired, unblocking peer", "peer", peerID, "reason", entry.Reason)
+		if err := b.unblock(ctx, peerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unblock must be called under lock.
+func (b *Blacklist) unblock(ctx context.Context, peerID peer.ID) error {
+	if err := b.ds.Delete(ctx, entryKey(peerID)); err != nil {
+		return fmt.Errorf("blacklist: deleting entry: %w", err)
+	}
+	delete(b.entries, peerID)
+
+	if err := b.connGater.UnblockPeer(peerID); err != nil {
+		return fmt.Errorf("blacklist: unblocking peer: %w", err)
+	}
+	return nil
+}
+
+func entryKey(peerID peer.ID) datastore.Key {
+	return datastore.NewKey(peerID.String())
+}
@@ -0,0 +1,60 @@
+package peers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlacklist(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	host, bad, expiring := net.Hosts()[0], net.Hosts()[1].ID(), net.Hosts()[2].ID()
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	newBlacklist := func() (*Blacklist, *conngater.BasicConnectionGater) {
+		// use a separate datastore for the gater to check that the blacklist restores blocked peers
+		gater, err := conngater.NewBasicConnectionGater(dssync.MutexWrap(datastore.NewMapDatastore()))
+		require.NoError(t, err)
+		bl := NewBlacklist(ds, host, gater)
+		require.NoError(t, bl.Start(ctx))
+		t.Cleanup(func() {
+			require.NoError(t, bl.Stop(ctx))
+		})
+		return bl, gater
+	}
+
+	bl, gater := newBlacklist()
+	require.NoError(t, bl.Add(ctx, bad, string(reasonMisbehave), 0))
+	require.NoError(t, bl.Add(ctx, expiring, string(reasonInvalidHash), time.Millisecond*50))
+	require.ElementsMatch(t, []peer.ID{bad, expiring}, gater.ListBlockedPeers())
+
+	entries := bl.List()
+	require.Len(t, entries, 2)
+	require.Equal(t, bad, entries[0].ID)
+	require.Equal(t, string(reasonMisbehave), entries[0].Reason)
+	require.True(t, entries[0].Expiry.IsZero())
+
+	// entries survive the restart, except for the expired ones
+	time.Sleep(time.Millisecond * 100)
+	bl, gater = newBlacklist()
+	require.Len(t, bl.List(), 1)
+	require.Equal(t, []peer.ID{bad}, gater.ListBlockedPeers())
+
+	require.NoError(t, bl.Remove(ctx, bad))
+	require.Empty(t, bl.List())
+	require.Empty(t, gater.ListBlockedPeers())
+
+	bl, _ = newBlacklist()
+	require.Empty(t, bl.List())
+}
@@ -20,8 +20,16 @@
 //
 // This gives the peer manager an ability to block peers that gossip invalid shares, but also access a list of peers
 // that are known to have been gossiping valid shares.
-// The peers are then returned on request using a round-robin algorithm to return a different peer each time.
+// The peers are then returned on request at random, weighted by a score built from latency, throughput,
+// success rate and not-found ratio of previous requests to them. Scores decay over time, so peers can recover.
 // If no peers are found, the peer manager will rely on full nodes retrieved from discovery.
+// Statically configured peers take precedence: trusted peers are tried before any other source and
+// are never blacklisted, while preferred peers are tried before the discovered nodes. Both are kept
+// connected and protected from connection pruning.
+// Discovered nodes announce their capabilities, so nodes known to store the requested height are
+// preferred, nodes that have not announced capabilities are used next, and pruned nodes that do
+// not store the height are skipped. Capabilities are refreshed by discovery periodically, and
+// nodes responding with NOT_FOUND are put on cooldown, as any other failing peers.
 //
 // The peer manager is only concerned with recent heights, thus it retrieves peers that
 // were active since `initialHeight`.
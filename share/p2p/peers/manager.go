@@ -1,9 +1,11 @@
 package peers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +23,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -30,6 +33,9 @@ const (
 	// ResultCooldownPeer will put returned peer on cooldown, meaning it won't be available by Peer
 	// method for some time
 	ResultCooldownPeer = "result_cooldown_peer"
+	// ResultNotFound indicates peer did not have the requested data. It puts peer on cooldown the
+	// same way ResultCooldownPeer does, but is accounted separately in the peer's score
+	ResultNotFound = "result_not_found"
 	// ResultBlacklistPeer will blacklist peer. Blacklisted peers will be disconnected and blocked from
 	// any p2p communication in future by libp2p Gater
 	ResultBlacklistPeer = "result_blacklist_peer"
@@ -41,6 +47,14 @@ const (
 	// storedPoolsAmount is the amount of pools for recent headers that will be stored in the peer
 	// manager
 	storedPoolsAmount = 10
+
+	// maxPendingNamespaceFilters is the amount of distinct namespace filters kept per datahash until
+	// it is validated. Honest peers announce equal filters, so more only come from misbehaving ones.
+	maxPendingNamespaceFilters = 8
+	// minNamespaceFilterPeers is the amount of distinct peers that must announce valid namespace
+	// filters for a datahash before they are trusted. Filters are only checked against the min and
+	// max namespaces of the rows, so a single peer could still hide a namespace inside a row.
+	minNamespaceFilterPeers = 2
 )
 
 type result string
@@ -71,22 +85,41 @@ type Manager struct {
 	// track peers for those headers
 	storeFrom atomic.Uint64
 
+	// trusted and preferred pools hold statically configured peers. Trusted peers are tried before
+	// any other source and are never blacklisted, while preferred peers are tried before discovered
+	// nodes.
+	trustedPeers   []peer.AddrInfo
+	preferredPeers []peer.AddrInfo
+	trusted        *pool
+	preferred      *pool
+
 	// nodes collects nodes' peer.IDs found via discovery
 	nodes *pool
+	// capabilities announced by discovered nodes. Nodes without announced capabilities are assumed
+	// to possibly store any height.
+	capabilitiesLock sync.RWMutex
+	capabilities     map[peer.ID]discovery.Capabilities
 
 	// hashes that are not in the chain
 	blacklistedHashes map[string]bool
+	// blacklist persists blacklisted peers if set
+	blacklist *Blacklist
+
+	// scores tracks request outcomes per peer and weights peer selection in pools
+	scores *scorer
 
 	metrics *metrics
 
 	headerSubDone         chan struct{}
 	disconnectedPeersDone chan struct{}
+	staticPeersDone       chan struct{}
 	cancel                context.CancelFunc
 }
 
 // DoneFunc updates internal state depending on call results. Should be called once per returned
-// peer from Peer method
-type DoneFunc func(result)
+// peer from Peer method. Size is the amount of bytes received from the peer and is used to
+// estimate its throughput.
+type DoneFunc func(result result, size int)
 
 type syncPool struct {
 	*pool
@@ -98,6 +131,27 @@ type syncPool struct {
 	height uint64
 	// createdAt is the syncPool creation time
 	createdAt time.Time
+
+	// root is the DAH of the validated datahash the announced namespace filters are checked against.
+	// Guarded by the Manager lock, as the fields below.
+	root *share.Root
+	// pendingNamespaces are the distinct namespace filters announced before the root is known.
+	pendingNamespaces []pendingNamespaceFilter
+	// namespaces is the union of the valid namespace filters announced for the datahash.
+	namespaces shrexsub.NamespaceFilter
+	// namespacesPeers are the distinct peers that announced valid namespace filters, up to
+	// minNamespaceFilterPeers.
+	namespacesPeers []peer.ID
+	// namespacesConflict is set once filters that can not be merged were announced, so namespace
+	// presence becomes unknown.
+	namespacesConflict bool
+}
+
+// pendingNamespaceFilter is a namespace filter announced before the DAH of the datahash is known,
+// along with the peers that announced it, up to minNamespaceFilterPeers.
+type pendingNamespaceFilter struct {
+	filter shrexsub.NamespaceFilter
+	peers  []peer.ID
 }
 
 func NewManager(
@@ -117,8 +171,11 @@ func NewManager(
 		host:                  host,
 		pools:                 make(map[string]*syncPool),
 		blacklistedHashes:     make(map[string]bool),
+		capabilities:          make(map[peer.ID]discovery.Capabilities),
+		scores:                newScorer(scoreHalfLife),
 		headerSubDone:         make(chan struct{}),
 		disconnectedPeersDone: make(chan struct{}),
+		staticPeersDone:       make(chan struct{}),
 		tag:                   tag,
 	}
 
@@ -129,7 +186,15 @@ func NewManager(
 		}
 	}
 
-	s.nodes = newPool(s.params.PeerCooldown)
+	s.nodes = s.newPool()
+	s.trusted = s.newPool()
+	for _, info := range s.trustedPeers {
+		s.trusted.add(info.ID)
+	}
+	s.preferred = s.newPool()
+	for _, info := range s.preferredPeers {
+		s.preferred.add(info.ID)
+	}
 	return s, nil
 }
 
@@ -137,6 +202,8 @@ func (m *Manager) Start(startCtx context.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	m.cancel = cancel
 
+	go m.keepStaticPeersConnected(ctx)
+
 	// pools will only be populated with senders of shrexsub notifications if the WithShrexSubPools
 	// option is used.
 	if m.shrexSub == nil && m.headerSub == nil {
@@ -176,6 +243,12 @@ func (m *Manager) Stop(ctx context.Context) error {
 		log.Warnw("closing metrics", "err", err)
 	}
 
+	select {
+	case <-m.staticPeersDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
 	// we do not need to wait for headersub and disconnected peers to finish
 	// here, since they were never started
 	if m.headerSub == nil && m.shrexSub == nil {
@@ -197,17 +270,24 @@ func (m *Manager) Stop(ctx context.Context) error {
 	return nil
 }
 
-// Peer returns peer collected from shrex.Sub for given datahash if any available.
-// If there is none, it will look for nodes collected from discovery. If there is no discovered
-// nodes, it will wait until any peer appear in either source or timeout happen.
+// Peer returns a trusted peer if any is available, or otherwise a peer collected from shrex.Sub
+// for given datahash. If there is none, it will look for preferred peers and then for nodes
+// collected from discovery. If there is no peer in any source, it will wait until any peer appear
+// or timeout happen.
 // After fetching data using given peer, caller is required to call returned DoneFunc using
 // appropriate result value
 func (m *Manager) Peer(ctx context.Context, datahash share.DataHash, height uint64,
 ) (peer.ID, DoneFunc, error) {
+	// trusted peers are always tried first
+	peerID, ok := m.trusted.tryGet()
+	if ok {
+		return m.newPeer(ctx, datahash, peerID, sourceTrustedPeers, m.trusted.len(), 0)
+	}
+
 	p := m.validatedPool(datahash.String(), height)
 
-	// first, check if a peer is available for the given datahash
-	peerID, ok := p.tryGet()
+	// then, check if a peer is available for the given datahash
+	peerID, ok = p.tryGet()
 	if ok {
 		if m.removeIfUnreachable(p, peerID) {
 			return m.Peer(ctx, datahash, height)
@@ -215,9 +295,19 @@ func (m *Manager) Peer(ctx context.Context, datahash share.DataHash, height uint
 		return m.newPeer(ctx, datahash, peerID, sourceShrexSub, p.len(), 0)
 	}
 
+	// preferred peers are used before nodes obtained from discovery
+	peerID, ok = m.preferred.tryGet()
+	if ok {
+		return m.newPeer(ctx, datahash, peerID, sourcePreferredPeers, m.preferred.len(), 0)
+	}
+
 	// if no peer for datahash is currently available, try to use node
-	// obtained from discovery
-	peerID, ok = m.nodes.tryGet()
+	// obtained from discovery. Prefer nodes known to store the height over the ones that have not
+	// announced their capabilities yet.
+	peerID, ok = m.nodes.tryGetIf(m.canServe(height, false))
+	if !ok {
+		peerID, ok = m.nodes.tryGetIf(m.canServe(height, true))
+	}
 	if ok {
 		return m.newPeer(ctx, datahash, peerID, sourceDiscoveredNodes, m.nodes.len(), 0)
 	}
@@ -225,18 +315,28 @@ func (m *Manager) Peer(ctx context.Context, datahash share.DataHash, height uint
 	// no peers are available right now, wait for the first one
 	start := time.Now()
 	select {
+	case peerID = <-m.trusted.next(ctx):
+		return m.newPeer(ctx, datahash, peerID, sourceTrustedPeers, m.trusted.len(), time.Since(start))
 	case peerID = <-p.next(ctx):
 		if m.removeIfUnreachable(p, peerID) {
 			return m.Peer(ctx, datahash, height)
 		}
 		return m.newPeer(ctx, datahash, peerID, sourceShrexSub, p.len(), time.Since(start))
-	case peerID = <-m.nodes.next(ctx):
+	case peerID = <-m.preferred.next(ctx):
+		return m.newPeer(ctx, datahash, peerID, sourcePreferredPeers, m.preferred.len(), time.Since(start))
+	case peerID = <-m.nodes.nextIf(ctx, m.canServe(height, true)):
 		return m.newPeer(ctx, datahash, peerID, sourceDiscoveredNodes, m.nodes.len(), time.Since(start))
 	case <-ctx.Done():
 		return "", nil, ctx.Err()
 	}
 }
 
+// Scores returns the scores of all peers the Manager has requested data from, sorted from best
+// to worst.
+func (m *Manager) Scores() []PeerScore {
+	return m.scores.scores()
+}
+
 // UpdateNodePool is called by discovery when new node is discovered or removed.
 func (m *Manager) UpdateNodePool(peerID peer.ID, isAdded bool) {
 	if isAdded {
@@ -251,6 +351,48 @@ func (m *Manager) UpdateNodePool(peerID peer.ID, isAdded bool) {
 
 	log.Debugw("removing peer from discovered nodes pool", "peer", peerID.String())
 	m.nodes.remove(peerID)
+
+	m.forgetCapabilities(peerID)
+}
+
+// UpdateNodeCapabilities is called by discovery when capabilities of a discovered node are
+// received.
+func (m *Manager) UpdateNodeCapabilities(peerID peer.ID, capabilities discovery.Capabilities) {
+	if !m.nodes.has(peerID) {
+		return
+	}
+
+	m.capabilitiesLock.Lock()
+	defer m.capabilitiesLock.Unlock()
+	m.capabilities[peerID] = capabilities
+}
+
+// forgetCapabilities drops the capabilities announced by the discovered node, until discovery
+// reports them again.
+func (m *Manager) forgetCapabilities(peerID peer.ID) {
+	m.capabilitiesLock.Lock()
+	defer m.capabilitiesLock.Unlock()
+	delete(m.capabilities, peerID)
+}
+
+// Capabilities returns capabilities announced by the discovered node, if any.
+func (m *Manager) Capabilities(peerID peer.ID) (discovery.Capabilities, bool) {
+	m.capabilitiesLock.RLock()
+	defer m.capabilitiesLock.RUnlock()
+	capabilities, ok := m.capabilities[peerID]
+	return capabilities, ok
+}
+
+// canServe returns a filter of discovered nodes that store the given height. Nodes without
+// announced capabilities are accepted only if acceptUnknown is set.
+func (m *Manager) canServe(height uint64, acceptUnknown bool) func(peer.ID) bool {
+	return func(peerID peer.ID) bool {
+		capabilities, ok := m.Capabilities(peerID)
+		if !ok {
+			return acceptUnknown
+		}
+		return capabilities.CanServe(height)
+	}
 }
 
 func (m *Manager) newPeer(
@@ -268,25 +410,34 @@ func (m *Manager) newPeer(
 		"pool_size", poolSize,
 		"wait (s)", waitTime)
 	m.metrics.observeGetPeer(ctx, source, poolSize, waitTime)
-	return peerID, m.doneFunc(datahash, peerID, source), nil
+	return peerID, m.doneFunc(datahash, peerID, source, time.Now()), nil
 }
 
-func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerSource) DoneFunc {
-	return func(result result) {
+func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerSource, start time.Time) DoneFunc {
+	return func(result result, size int) {
 		log.Debugw("set peer result",
 			"hash", datahash.String(),
 			"peer", peerID.String(),
 			"source", source,
-			"result", result)
+			"result", result,
+			"size", size)
 		m.metrics.observeDoneResult(source, result)
+		m.scores.observe(peerID, result, size, time.Since(start))
 		switch result {
 		case ResultNoop:
-		case ResultCooldownPeer:
-			if source == sourceDiscoveredNodes {
+		case ResultCooldownPeer, ResultNotFound:
+			switch source {
+			case sourceTrustedPeers:
+				m.trusted.putOnCooldown(peerID)
+			case sourcePreferredPeers:
+				m.preferred.putOnCooldown(peerID)
+			case sourceDiscoveredNodes:
+				// capabilities are kept on NOT_FOUND, as the block could just not be received yet,
+				// while the cooldown keeps the node from being retried right away
 				m.nodes.putOnCooldown(peerID)
-				return
+			default:
+				m.getPool(datahash.String()).putOnCooldown(peerID)
 			}
-			m.getPool(datahash.String()).putOnCooldown(peerID)
 		case ResultBlacklistPeer:
 			m.blacklistPeers(reasonMisbehave, peerID)
 		}
@@ -307,7 +458,8 @@ func (m *Manager) subscribeHeader(ctx context.Context, headerSub libhead.Subscri
 			log.Errorw("get next header from sub", "err", err)
 			continue
 		}
-		m.validatedPool(h.DataHash.String(), h.Height())
+		p := m.validatedPool(h.DataHash.String(), h.Height())
+		m.setRoot(p, h.DAH)
 
 		// store first header for validation purposes
 		if m.initialHeight.CompareAndSwap(0, h.Height()) {
@@ -376,6 +528,9 @@ func (m *Manager) Validate(_ context.Context, peerID peer.ID, msg shrexsub.Notif
 
 	p := m.getOrCreatePool(msg.DataHash.String(), msg.Height)
 	logger.Debugw("got hash from shrex-sub")
+	if msg.Namespaces != nil {
+		m.addNamespaces(p, peerID, msg.Namespaces)
+	}
 
 	p.add(peerID)
 	if p.isValidatedDataHash.Load() {
@@ -385,6 +540,94 @@ func (m *Manager) Validate(_ context.Context, peerID peer.ID, msg shrexsub.Notif
 	return pubsub.ValidationIgnore
 }
 
+// MayContainNamespace reports whether the block with the given datahash may contain the namespace
+// according to namespace filters announced by peers along with the datahash. It is only a hint
+// that is not backed by proofs: it returns true unless every valid filter announced for the block
+// excludes the namespace, and when valid filters are known from less than minNamespaceFilterPeers
+// peers. Filters are only trusted once the datahash is validated by headerSub and they are checked
+// against its DAH.
+func (m *Manager) MayContainNamespace(datahash share.DataHash, namespace share.Namespace) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	p, ok := m.pools[datahash.String()]
+	if !ok || !p.isValidatedDataHash.Load() || p.root == nil || p.namespacesConflict ||
+		len(p.namespacesPeers) < minNamespaceFilterPeers {
+		return true
+	}
+	return p.namespaces.Contains(namespace)
+}
+
+// addNamespaces merges the namespace filter announced by the peer into the pool if the DAH of the
+// pool is known already, or keeps it until then otherwise.
+func (m *Manager) addNamespaces(p *syncPool, peerID peer.ID, filter shrexsub.NamespaceFilter) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if p.root != nil {
+		m.mergeNamespaces(p, filter, peerID)
+		return
+	}
+	for i := range p.pendingNamespaces {
+		pending := &p.pendingNamespaces[i]
+		if bytes.Equal(pending.filter, filter) {
+			pending.peers = addNamespacesPeer(pending.peers, peerID)
+			return
+		}
+	}
+	if len(p.pendingNamespaces) < maxPendingNamespaceFilters {
+		p.pendingNamespaces = append(p.pendingNamespaces, pendingNamespaceFilter{
+			filter: filter,
+			peers:  []peer.ID{peerID},
+		})
+	}
+}
+
+// setRoot sets the DAH of the validated pool and merges the namespace filters announced before.
+func (m *Manager) setRoot(p *syncPool, root *share.Root) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if p.root != nil || root == nil {
+		return
+	}
+	p.root = root
+	for _, pending := range p.pendingNamespaces {
+		m.mergeNamespaces(p, pending.filter, pending.peers...)
+	}
+	p.pendingNamespaces = nil
+}
+
+// mergeNamespaces merges the namespace filter announced by the peers into the pool, unless it
+// doesn't match the DAH of the pool. Should be called with the Manager lock held.
+func (m *Manager) mergeNamespaces(p *syncPool, filter shrexsub.NamespaceFilter, peerIDs ...peer.ID) {
+	if err := filter.ValidateRoot(p.root); err != nil {
+		log.Debugw("ignoring invalid namespace filter", "height", p.height, "err", err)
+		return
+	}
+
+	for _, peerID := range peerIDs {
+		p.namespacesPeers = addNamespacesPeer(p.namespacesPeers, peerID)
+	}
+	switch {
+	case p.namespacesConflict:
+	case p.namespaces == nil:
+		p.namespaces = filter
+	default:
+		p.namespaces = p.namespaces.Merge(filter)
+		p.namespacesConflict = p.namespaces == nil
+	}
+}
+
+// addNamespacesPeer adds the peer to the distinct peers that announced a namespace filter, unless
+// minNamespaceFilterPeers of them are known already.
+func addNamespacesPeer(peerIDs []peer.ID, peerID peer.ID) []peer.ID {
+	if len(peerIDs) >= minNamespaceFilterPeers || slices.Contains(peerIDs, peerID) {
+		return peerIDs
+	}
+	return append(peerIDs, peerID)
+}
+
 func (m *Manager) getPool(datahash string) *syncPool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -399,7 +642,7 @@ func (m *Manager) getOrCreatePool(datahash string, height uint64) *syncPool {
 	if !ok {
 		p = &syncPool{
 			height:    height,
-			pool:      newPool(m.params.PeerCooldown),
+			pool:      m.newPool(),
 			createdAt: time.Now(),
 		}
 		m.pools[datahash] = p
@@ -408,6 +651,13 @@ func (m *Manager) getOrCreatePool(datahash string, height uint64) *syncPool {
 	return p
 }
 
+// newPool creates a pool that picks peers weighted by their score.
+func (m *Manager) newPool() *pool {
+	p := newPool(m.params.PeerCooldown)
+	p.weight = m.scores.score
+	return p
+}
+
 func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID) {
 	m.metrics.observeBlacklistPeers(reason, len(peerIDs))
 
@@ -418,8 +668,21 @@ func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID)
 		if !m.params.EnableBlackListing {
 			continue
 		}
+		if m.isTrustedPeer(peerID) {
+			log.Warnw("not blacklisting trusted peer", "peer", peerID.String(), "reason", reason)
+			continue
+		}
 
 		m.nodes.remove(peerID)
+		m.preferred.remove(peerID)
+		m.scores.remove(peerID)
+		if m.blacklist != nil {
+			err := m.blacklist.Add(context.Background(), peerID, string(reason), m.params.BlacklistTTL)
+			if err != nil {
+				log.Warnw("failed to blacklist peer", "peer", peerID, "err", err)
+			}
+			continue
+		}
 		// add peer to the blacklist, so we can't connect to it in the future.
 		err := m.connGater.BlockPeer(peerID)
 		if err != nil {
@@ -479,6 +742,7 @@ func (m *Manager) GC(ctx context.Context) {
 		if len(blacklist) > 0 {
 			m.blacklistPeers(reasonInvalidHash, blacklist...)
 		}
+		m.scores.gc()
 	}
 }
 
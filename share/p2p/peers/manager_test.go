@@ -2,6 +2,7 @@ package peers
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -71,6 +72,81 @@ func TestManager(t *testing.T) {
 		require.True(t, manager.getPool(h.DataHash.String()).isValidatedDataHash.Load())
 	})
 
+	t.Run("namespace filters", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		t.Cleanup(cancel)
+
+		// namespaces are fixed, as random ones could hit a false positive of the filter
+		ns1, err := share.NewBlobNamespaceV0([]byte("ns1"))
+		require.NoError(t, err)
+		ns2, err := share.NewBlobNamespaceV0([]byte("ns2"))
+		require.NoError(t, err)
+		ns3, err := share.NewBlobNamespaceV0([]byte("ns3"))
+		require.NoError(t, err)
+		absent, err := share.NewBlobNamespaceV0([]byte("absent"))
+		require.NoError(t, err)
+
+		// the block has a row of ns1 shares and a row of ns2 shares
+		rowRoot := func(minNs, maxNs share.Namespace) []byte {
+			return append(append(slices.Clone(minNs), maxNs...), rand.Bytes(32)...)
+		}
+		h := testHeader()
+		h.DAH = &share.Root{RowRoots: [][]byte{
+			rowRoot(ns1, ns1),
+			rowRoot(ns2, ns2),
+			rowRoot(share.ParitySharesNamespace, share.ParitySharesNamespace),
+			rowRoot(share.ParitySharesNamespace, share.ParitySharesNamespace),
+		}}
+		headerSub := newSubLock(h, nil)
+
+		// start test manager
+		manager, err := testManager(ctx, headerSub)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			stopManager(t, manager)
+		})
+		// unknown until a filter is announced
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		msg := newShrexSubMsg(h)
+		msg.Namespaces = shrexsub.NewNamespaceFilter([]share.Namespace{ns1, ns2})
+		manager.Validate(ctx, "peer1", msg)
+		// filters are not trusted until the datahash is validated
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		require.NoError(t, headerSub.wait(ctx, 1))
+		// nor until they are announced by enough distinct peers
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+		manager.Validate(ctx, "peer1", msg)
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		// a filter hiding a namespace of the block is ignored
+		msg.Namespaces = shrexsub.NewNamespaceFilter([]share.Namespace{ns1})
+		manager.Validate(ctx, "peer2", msg)
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+		msg.Namespaces = shrexsub.NewNamespaceFilter([]share.Namespace{ns1, ns2})
+		manager.Validate(ctx, "peer3", msg)
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), ns1))
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), ns2))
+		require.False(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		// filters not matching the DAH are ignored
+		msg.Namespaces = shrexsub.NewNamespaceFilter([]share.Namespace{absent})
+		manager.Validate(ctx, "peer4", msg)
+		require.False(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		// a peer can not hide a namespace announced by another one
+		msg.Namespaces = shrexsub.NewNamespaceFilter([]share.Namespace{ns1, ns2, ns3})
+		manager.Validate(ctx, "peer5", msg)
+		require.True(t, manager.MayContainNamespace(h.DataHash.Bytes(), ns3))
+		require.False(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+
+		// announcements without a filter do not change anything
+		msg.Namespaces = nil
+		manager.Validate(ctx, "peer6", msg)
+		require.False(t, manager.MayContainNamespace(h.DataHash.Bytes(), absent))
+	})
+
 	t.Run("validator", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		t.Cleanup(cancel)
@@ -98,7 +174,7 @@ func TestManager(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, peerID, pID)
 		manager.params.EnableBlackListing = true
-		done(ResultBlacklistPeer)
+		done(ResultBlacklistPeer, 0)
 
 		// new messages from misbehaved peer should be Rejected
 		result = manager.Validate(ctx, pID, msg)
@@ -175,6 +251,66 @@ func TestManager(t *testing.T) {
 		stopManager(t, manager)
 	})
 
+	t.Run("no peers from shrex.Sub, get from discovery by capabilities", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		t.Cleanup(cancel)
+
+		// create headerSub mock
+		h := testHeader()
+		headerSub := newSubLock(h)
+
+		// start test manager
+		manager, err := testManager(ctx, headerSub)
+		require.NoError(t, err)
+
+		manager.nodes.add("pruned", "unknown", "archival")
+		manager.UpdateNodeCapabilities("pruned", discovery.Capabilities{FromHeight: h.Height() + 1})
+		manager.UpdateNodeCapabilities("archival", discovery.Capabilities{Archival: true})
+		// capabilities of peers that were not discovered are ignored
+		manager.UpdateNodeCapabilities("other", discovery.Capabilities{Archival: true})
+		_, ok := manager.Capabilities("other")
+		require.False(t, ok)
+
+		// nodes known to store the height are preferred
+		for i := 0; i < 3; i++ {
+			peerID, _, err := manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+			require.NoError(t, err)
+			require.Equal(t, peer.ID("archival"), peerID)
+		}
+
+		// nodes with unknown capabilities are used next, but never the ones missing the height
+		manager.UpdateNodePool("archival", false)
+		_, ok = manager.Capabilities("archival")
+		require.False(t, ok)
+		for i := 0; i < 3; i++ {
+			peerID, _, err := manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+			require.NoError(t, err)
+			require.Equal(t, peer.ID("unknown"), peerID)
+		}
+
+		// wait until a node that stores the height appears
+		manager.UpdateNodePool("unknown", false)
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+		t.Cleanup(cancel)
+		_, _, err = manager.Peer(timeoutCtx, h.DataHash.Bytes(), h.Height())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		peerID, done, err := manager.Peer(ctx, h.DataHash.Bytes(), h.Height()+1)
+		require.NoError(t, err)
+		require.Equal(t, peer.ID("pruned"), peerID)
+
+		// a node responding with NOT_FOUND keeps its capabilities and is put on cooldown
+		done(ResultNotFound, 0)
+		_, ok = manager.Capabilities("pruned")
+		require.True(t, ok)
+		timeoutCtx, cancel = context.WithTimeout(ctx, time.Millisecond*100)
+		t.Cleanup(cancel)
+		_, _, err = manager.Peer(timeoutCtx, h.DataHash.Bytes(), h.Height()+1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		stopManager(t, manager)
+	})
+
 	t.Run("no peers from shrex.Sub and from discovery. Wait", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		t.Cleanup(cancel)
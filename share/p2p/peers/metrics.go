@@ -23,6 +23,8 @@ const (
 	sourceKey                        = "source"
 	sourceShrexSub        peerSource = "shrexsub"
 	sourceDiscoveredNodes peerSource = "discovered_nodes"
+	sourceTrustedPeers    peerSource = "trusted_peers"
+	sourcePreferredPeers  peerSource = "preferred_peers"
 
 	blacklistPeerReasonKey                     = "blacklist_reason"
 	reasonInvalidHash      blacklistPeerReason = "invalid_hash"
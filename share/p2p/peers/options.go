@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
+
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -24,6 +26,10 @@ type Parameters struct {
 
 	// EnableBlackListing turns on blacklisting for misbehaved peers
 	EnableBlackListing bool
+
+	// BlacklistTTL is the time misbehaved peers stay blacklisted. Zero value blacklists peers
+	// permanently.
+	BlacklistTTL time.Duration
 }
 
 type Option func(*Manager) error
@@ -42,6 +48,10 @@ func (p *Parameters) Validate() error {
 		return fmt.Errorf("peer-manager: garbage collection interval must be positive")
 	}
 
+	if p.BlacklistTTL < 0 {
+		return fmt.Errorf("peer-manager: blacklist ttl must not be negative")
+	}
+
 	return nil
 }
 
@@ -60,6 +70,9 @@ func DefaultParameters() Parameters {
 		// blacklisting is off by default //TODO(@walldiss): enable blacklisting once all related issues
 		// are resolved
 		EnableBlackListing: false,
+		// BlacklistTTL's default value gives misbehaved peers a chance to recover, in case they were
+		// blacklisted by mistake.
+		BlacklistTTL: 24 * time.Hour,
 	}
 }
 
@@ -73,6 +86,33 @@ func WithShrexSubPools(shrexSub *shrexsub.PubSub, headerSub libhead.Subscriber[*
 	}
 }
 
+// WithBlacklist passes a Blacklist instance to persist blacklisted peers, so they stay blocked
+// across restarts until their entries expire.
+func WithBlacklist(blacklist *Blacklist) Option {
+	return func(m *Manager) error {
+		m.blacklist = blacklist
+		return nil
+	}
+}
+
+// WithTrustedPeers configures peers that are tried before any other source, are kept connected
+// and are never blacklisted.
+func WithTrustedPeers(peers ...peer.AddrInfo) Option {
+	return func(m *Manager) error {
+		m.trustedPeers = append(m.trustedPeers, peers...)
+		return nil
+	}
+}
+
+// WithPreferredPeers configures peers that are kept connected and tried before nodes found via
+// discovery.
+func WithPreferredPeers(peers ...peer.AddrInfo) Option {
+	return func(m *Manager) error {
+		m.preferredPeers = append(m.preferredPeers, peers...)
+		return nil
+	}
+}
+
 // WithMetrics turns on metric collection in peer manager.
 func (m *Manager) WithMetrics() error {
 	metrics, err := initMetrics(m)
@@ -2,15 +2,23 @@ package peers
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-const defaultCleanupThreshold = 2
+const (
+	defaultCleanupThreshold = 2
+
+	// filterRecheckInterval is how often a filtered wait rechecks active peers that were not
+	// accepted, since the filter may accept them later.
+	filterRecheckInterval = time.Second
+)
 
-// pool stores peers and provides methods for simple round-robin access.
+// pool stores peers and provides methods for simple round-robin access. If weight is set,
+// active peers are instead picked at random proportionally to their weight.
 type pool struct {
 	m           sync.RWMutex
 	peersList   []peer.ID
@@ -19,6 +27,8 @@ type pool struct {
 	activeCount int
 	nextIdx     int
 
+	weight func(peer.ID) float64
+
 	hasPeer   bool
 	hasPeerCh chan struct{}
 
@@ -47,6 +57,12 @@ func newPool(peerCooldownTime time.Duration) *pool {
 
 // tryGet returns peer along with bool flag indicating success of operation.
 func (p *pool) tryGet() (peer.ID, bool) {
+	return p.tryGetIf(nil)
+}
+
+// tryGetIf returns an active peer accepted by the filter along with bool flag indicating success
+// of operation. Nil filter accepts any peer.
+func (p *pool) tryGetIf(accept func(peer.ID) bool) (peer.ID, bool) {
 	p.m.Lock()
 	defer p.m.Unlock()
 
@@ -54,6 +70,10 @@ func (p *pool) tryGet() (peer.ID, bool) {
 		return "", false
 	}
 
+	if p.weight != nil {
+		return p.weightedGet(accept)
+	}
+
 	// if pointer is out of range, point to first element
 	if p.nextIdx > len(p.peersList)-1 {
 		p.nextIdx = 0
@@ -68,7 +88,7 @@ func (p *pool) tryGet() (peer.ID, bool) {
 			p.nextIdx = 0
 		}
 
-		if p.statuses[peerID] == active {
+		if p.statuses[peerID] == active && (accept == nil || accept(peerID)) {
 			return peerID, true
 		}
 
@@ -79,19 +99,66 @@ func (p *pool) tryGet() (peer.ID, bool) {
 	}
 }
 
+// weightedGet picks an active peer accepted by the filter with probability proportional to its
+// weight. Must be called under lock.
+func (p *pool) weightedGet(accept func(peer.ID) bool) (peer.ID, bool) {
+	var (
+		candidates = make([]peer.ID, 0, p.activeCount)
+		weights    = make([]float64, 0, p.activeCount)
+		total      float64
+	)
+	for _, peerID := range p.peersList {
+		if p.statuses[peerID] != active || (accept != nil && !accept(peerID)) {
+			continue
+		}
+		w := p.weight(peerID)
+		candidates = append(candidates, peerID)
+		weights = append(weights, w)
+		total += w
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	r := rand.Float64() * total //nolint:gosec
+	for i, w := range weights {
+		r -= w
+		if r < 0 {
+			return candidates[i], true
+		}
+	}
+	// guards against floating point rounding
+	return candidates[len(candidates)-1], true
+}
+
 // next sends a peer to the returned channel when it becomes available.
 func (p *pool) next(ctx context.Context) <-chan peer.ID {
+	return p.nextIf(ctx, nil)
+}
+
+// nextIf sends a peer accepted by the filter to the returned channel when it becomes available.
+// Nil filter accepts any peer.
+func (p *pool) nextIf(ctx context.Context, accept func(peer.ID) bool) <-chan peer.ID {
 	peerCh := make(chan peer.ID, 1)
 	go func() {
 		for {
-			if peerID, ok := p.tryGet(); ok {
+			if peerID, ok := p.tryGetIf(accept); ok {
 				peerCh <- peerID
 				return
 			}
 
 			p.m.RLock()
-			hasPeerCh := p.hasPeerCh
+			hasPeerCh, hasPeer := p.hasPeerCh, p.hasPeer
 			p.m.RUnlock()
+			if accept != nil && hasPeer {
+				// none of the active peers is accepted yet
+				select {
+				case <-time.After(filterRecheckInterval):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
 			select {
 			case <-hasPeerCh:
 			case <-ctx.Done():
@@ -181,4 +181,32 @@ func TestPool(t *testing.T) {
 		_, ok := p.tryGet()
 		require.False(t, ok)
 	})
+
+	t.Run("weighted", func(t *testing.T) {
+		p := newPool(time.Second)
+		p.weight = func(peerID peer.ID) float64 {
+			if peerID == "good" {
+				return 0.9
+			}
+			return 0.1
+		}
+		p.add("good", "bad")
+
+		picked := make(map[peer.ID]int)
+		for range 1000 {
+			peerID, ok := p.tryGet()
+			require.True(t, ok)
+			picked[peerID]++
+		}
+		require.Greater(t, picked["good"], picked["bad"]*4)
+		require.NotZero(t, picked["bad"])
+
+		// cooldown peers are never picked regardless of their weight
+		p.putOnCooldown("good")
+		for range 10 {
+			peerID, ok := p.tryGet()
+			require.True(t, ok)
+			require.Equal(t, peer.ID("bad"), peerID)
+		}
+	})
 }
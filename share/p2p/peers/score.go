@@ -0,0 +1,229 @@
+package peers
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// scoreHalfLife is the time after which the weight of observed request outcomes is halved.
+	// Decaying lets peers that misbehaved in the past recover and keeps stale good results from
+	// protecting peers that became slow.
+	scoreHalfLife = 10 * time.Minute
+
+	// targetLatency and targetThroughput are the reference values at which the latency and
+	// throughput components of the score equal 0.5. Peers without measurements are assumed to
+	// perform exactly at the reference.
+	targetLatency    = time.Second
+	targetThroughput = 1 << 20 // 1 MiB/s
+
+	// ewmaAlpha is the smoothing factor applied to new latency and throughput samples.
+	ewmaAlpha = 0.3
+
+	// minScore guarantees that even the worst scored peer is still picked from time to time, so
+	// it has a chance to recover its reputation.
+	minScore = 0.01
+)
+
+// PeerScore is a snapshot of the reputation the Manager keeps for a peer based on the outcomes
+// of shrex requests made to it.
+type PeerScore struct {
+	ID peer.ID `json:"id"`
+	// Score is the weight used for peer selection in range (0, 1]. Higher is better.
+	Score float64 `json:"score"`
+	// Latency is the smoothed duration of requests to the peer.
+	Latency time.Duration `json:"latency"`
+	// Throughput is the smoothed amount of bytes per second received from the peer.
+	Throughput float64 `json:"throughput"`
+	// SuccessRate is the decayed ratio of successful requests.
+	SuccessRate float64 `json:"success_rate"`
+	// NotFoundRatio is the decayed ratio of requests the peer did not have data for.
+	NotFoundRatio float64 `json:"not_found_ratio"`
+}
+
+// peerStats accumulates request outcomes for a single peer.
+type peerStats struct {
+	// latency is measured in seconds and throughput in bytes per second.
+	latency    float64
+	throughput float64
+	// successes, failures and notFound are counters decayed over time.
+	successes float64
+	failures  float64
+	notFound  float64
+
+	updatedAt time.Time
+}
+
+// scorer keeps track of peer stats and computes selection weights out of them.
+type scorer struct {
+	lock  sync.Mutex
+	stats map[peer.ID]*peerStats
+
+	halfLife time.Duration
+	now      func() time.Time
+}
+
+func newScorer(halfLife time.Duration) *scorer {
+	return &scorer{
+		stats:    make(map[peer.ID]*peerStats),
+		halfLife: halfLife,
+		now:      time.Now,
+	}
+}
+
+// observe records the outcome of a request to the peer. Size is the amount of bytes received and
+// is only taken into account for successful requests.
+func (s *scorer) observe(peerID peer.ID, result result, size int, dur time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.decayed(peerID)
+	if !ok {
+		st = &peerStats{updatedAt: s.now()}
+		s.stats[peerID] = st
+	}
+	switch result {
+	case ResultNoop:
+		st.successes++
+		st.latency = ewma(st.latency, dur.Seconds())
+		if size > 0 && dur > 0 {
+			st.throughput = ewma(st.throughput, float64(size)/dur.Seconds())
+		}
+	case ResultNotFound:
+		st.notFound++
+	default:
+		st.failures++
+		// failed requests are usually timeouts, so they still tell us how slow the peer is
+		st.latency = ewma(st.latency, dur.Seconds())
+	}
+}
+
+// score returns the selection weight of the peer.
+func (s *scorer) score(peerID peer.ID) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.decayed(peerID)
+	if !ok {
+		// peers without history are scored by the reference values
+		return (&peerStats{}).score()
+	}
+	return st.score()
+}
+
+// remove forgets all the stats of the peer.
+func (s *scorer) remove(peerID peer.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.stats, peerID)
+}
+
+// scores returns a snapshot of all tracked peers sorted from best to worst.
+func (s *scorer) scores() []PeerScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scores := make([]PeerScore, 0, len(s.stats))
+	for peerID := range s.stats {
+		st, _ := s.decayed(peerID)
+		scores = append(scores, PeerScore{
+			ID:            peerID,
+			Score:         st.score(),
+			Latency:       time.Duration(st.latencyOrTarget() * float64(time.Second)),
+			Throughput:    st.throughputOrTarget(),
+			SuccessRate:   st.successRate(),
+			NotFoundRatio: st.notFoundRatio(),
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
+
+// gc removes stats that decayed to the point where they no longer carry information.
+func (s *scorer) gc() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for peerID := range s.stats {
+		st, _ := s.decayed(peerID)
+		if st.successes+st.failures+st.notFound < minScore {
+			delete(s.stats, peerID)
+		}
+	}
+}
+
+// decayed returns stats of the peer with the decay applied up to now along with bool flag
+// indicating whether the peer is tracked. Must be called under lock.
+func (s *scorer) decayed(peerID peer.ID) (*peerStats, bool) {
+	st, ok := s.stats[peerID]
+	if !ok {
+		return nil, false
+	}
+
+	now := s.now()
+	elapsed := now.Sub(st.updatedAt)
+	if elapsed <= 0 {
+		return st, true
+	}
+	factor := math.Pow(0.5, float64(elapsed)/float64(s.halfLife))
+	st.successes *= factor
+	st.failures *= factor
+	st.notFound *= factor
+	// measurements drift back to the reference values, so that old samples stop dominating
+	if st.latency != 0 {
+		st.latency = targetLatency.Seconds() + (st.latency-targetLatency.Seconds())*factor
+	}
+	if st.throughput != 0 {
+		st.throughput = targetThroughput + (st.throughput-targetThroughput)*factor
+	}
+	st.updatedAt = now
+	return st, true
+}
+
+func (st *peerStats) score() float64 {
+	latency := targetLatency.Seconds() / (targetLatency.Seconds() + st.latencyOrTarget())
+	throughput := st.throughputOrTarget() / (st.throughputOrTarget() + targetThroughput)
+	score := st.successRate() * (1 - st.notFoundRatio()/2) * latency * throughput
+	return max(score, minScore)
+}
+
+// successRate uses Laplace smoothing, so that peers without history start from 0.5.
+func (st *peerStats) successRate() float64 {
+	total := st.successes + st.failures + st.notFound
+	return (st.successes + 1) / (total + 2)
+}
+
+func (st *peerStats) notFoundRatio() float64 {
+	total := st.successes + st.failures + st.notFound
+	if total == 0 {
+		return 0
+	}
+	return st.notFound / total
+}
+
+func (st *peerStats) latencyOrTarget() float64 {
+	if st.latency == 0 {
+		return targetLatency.Seconds()
+	}
+	return st.latency
+}
+
+func (st *peerStats) throughputOrTarget() float64 {
+	if st.throughput == 0 {
+		return targetThroughput
+	}
+	return st.throughput
+}
+
+func ewma(prev, sample float64) float64 {
+	if prev == 0 {
+		return sample
+	}
+	return prev + ewmaAlpha*(sample-prev)
+}
@@ -0,0 +1,63 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorer(t *testing.T) {
+	t.Run("outcomes affect score", func(t *testing.T) {
+		s := newScorer(time.Minute)
+		unknown := s.score("unknown")
+
+		for range 10 {
+			s.observe("good", ResultNoop, 4<<20, time.Second/2)
+			s.observe("slow", ResultNoop, 4<<20, 5*time.Second)
+			s.observe("flaky", ResultCooldownPeer, 0, time.Second)
+			s.observe("empty", ResultNotFound, 0, time.Second/2)
+		}
+
+		require.Greater(t, s.score("good"), unknown)
+		require.Less(t, s.score("slow"), s.score("good"))
+		require.Less(t, s.score("flaky"), unknown)
+		require.Less(t, s.score("empty"), unknown)
+		// not found is penalized stronger than failure, as the peer is unlikely to get the data soon
+		require.Less(t, s.score("empty"), s.score("flaky"))
+
+		scores := s.scores()
+		require.Len(t, scores, 4)
+		require.Equal(t, peer.ID("good"), scores[0].ID)
+		require.Equal(t, 1.0, scores[3].NotFoundRatio)
+	})
+
+	t.Run("scores decay", func(t *testing.T) {
+		now := time.Now()
+		s := newScorer(time.Minute)
+		s.now = func() time.Time { return now }
+
+		for range 10 {
+			s.observe("flaky", ResultCooldownPeer, 0, 10*time.Second)
+		}
+		bad := s.score("flaky")
+
+		now = now.Add(10 * time.Minute)
+		require.Greater(t, s.score("flaky"), bad)
+		require.InDelta(t, s.score("unknown"), s.score("flaky"), 0.01)
+
+		// fully decayed stats are collected
+		s.gc()
+		require.Empty(t, s.scores())
+	})
+
+	t.Run("blacklisted peers are forgotten", func(t *testing.T) {
+		s := newScorer(time.Minute)
+		s.observe("peer", ResultBlacklistPeer, 0, time.Second)
+		require.Len(t, s.scores(), 1)
+
+		s.remove("peer")
+		require.Empty(t, s.scores())
+	})
+}
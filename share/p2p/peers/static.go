@@ -0,0 +1,84 @@
+package peers
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+)
+
+// staticPeersReconnectInterval is the interval at which the manager reconnects to static peers
+// it lost connection with.
+var staticPeersReconnectInterval = 30 * time.Second
+
+// staticPeers returns trusted and preferred peers configured for the manager.
+func (m *Manager) staticPeers() []peer.AddrInfo {
+	peers := make([]peer.AddrInfo, 0, len(m.trustedPeers)+len(m.preferredPeers))
+	peers = append(peers, m.trustedPeers...)
+	return append(peers, m.preferredPeers...)
+}
+
+// staticPeersTag is the tag static peers are protected with in the connection manager.
+func (m *Manager) staticPeersTag() string {
+	return "shrex-static-" + m.tag
+}
+
+// isTrustedPeer reports whether the peer is one of the configured trusted peers.
+func (m *Manager) isTrustedPeer(peerID peer.ID) bool {
+	for _, info := range m.trustedPeers {
+		if info.ID == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// keepStaticPeersConnected protects static peers from being trimmed by the connection manager and
+// keeps reconnecting to them until the context is canceled.
+func (m *Manager) keepStaticPeersConnected(ctx context.Context) {
+	defer close(m.staticPeersDone)
+
+	peers := m.staticPeers()
+	if len(peers) == 0 {
+		return
+	}
+
+	tag := m.staticPeersTag()
+	for _, info := range peers {
+		m.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		m.host.ConnManager().Protect(info.ID, tag)
+	}
+	defer func() {
+		for _, info := range peers {
+			m.host.ConnManager().Unprotect(info.ID, tag)
+		}
+	}()
+
+	ticker := time.NewTicker(staticPeersReconnectInterval)
+	defer ticker.Stop()
+	for {
+		for _, info := range peers {
+			if m.host.Network().Connectedness(info.ID) == network.Connected {
+				continue
+			}
+			if m.isBlacklistedPeer(info.ID) {
+				log.Debugw("static peer is blacklisted, skipping reconnect", "peer", info.ID.String())
+				continue
+			}
+			if err := m.host.Connect(ctx, info); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warnw("connecting to static peer", "peer", info.ID.String(), "err", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
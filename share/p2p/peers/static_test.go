@@ -0,0 +1,90 @@
+package peers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
+)
+
+func TestStaticPeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	hosts := net.Hosts()
+	trusted := peer.AddrInfo{ID: hosts[1].ID(), Addrs: hosts[1].Addrs()}
+	preferred := peer.AddrInfo{ID: hosts[2].ID(), Addrs: hosts[2].Addrs()}
+
+	h := testHeader()
+	headerSub := newSubLock(h, nil)
+	shrexSub, err := shrexsub.NewPubSub(ctx, hosts[0], "test")
+	require.NoError(t, err)
+	connGater, err := conngater.NewBasicConnectionGater(dssync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+
+	params := DefaultParameters()
+	params.EnableBlackListing = true
+	manager, err := NewManager(
+		params,
+		hosts[0],
+		connGater,
+		"test",
+		WithShrexSubPools(shrexSub, headerSub),
+		WithTrustedPeers(trusted),
+		WithPreferredPeers(preferred),
+	)
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(ctx))
+	t.Cleanup(func() {
+		stopManager(t, manager)
+	})
+
+	// static peers are connected to
+	require.Eventually(t, func() bool {
+		return hosts[0].Network().Connectedness(trusted.ID) == network.Connected &&
+			hosts[0].Network().Connectedness(preferred.ID) == network.Connected
+	}, time.Second, time.Millisecond*10)
+
+	// trusted peer is tried first
+	shrexSubPeer := peer.ID("shrexsub")
+	result := manager.Validate(ctx, shrexSubPeer, newShrexSubMsg(h))
+	require.Equal(t, pubsub.ValidationIgnore, result)
+	manager.nodes.add("discovered")
+
+	peerID, done, err := manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+	require.NoError(t, err)
+	require.Equal(t, trusted.ID, peerID)
+
+	// trusted peers are never blacklisted, but can be put on cooldown
+	manager.blacklistPeers(reasonMisbehave, trusted.ID)
+	require.False(t, manager.isBlacklistedPeer(trusted.ID))
+	done(ResultCooldownPeer, 0)
+
+	// shrexsub peers are tried next
+	peerID, done, err = manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+	require.NoError(t, err)
+	require.Equal(t, shrexSubPeer, peerID)
+	done(ResultCooldownPeer, 0)
+
+	// preferred peers are tried before discovered ones
+	peerID, done, err = manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+	require.NoError(t, err)
+	require.Equal(t, preferred.ID, peerID)
+	done(ResultCooldownPeer, 0)
+
+	peerID, _, err = manager.Peer(ctx, h.DataHash.Bytes(), h.Height())
+	require.NoError(t, err)
+	require.Contains(t, []peer.ID{"discovered", shrexSubPeer}, peerID)
+}
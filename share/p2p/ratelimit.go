@@ -0,0 +1,137 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// peerLimiterGCInterval is the interval at which budgets of idle peers are dropped.
+const peerLimiterGCInterval = time.Minute
+
+// PeerLimiter enforces per-peer request rate and bandwidth budgets using token buckets. Bandwidth
+// is charged once the response is served, so a single request may exceed the budget, but the peer
+// is then rate limited until the budget recovers.
+type PeerLimiter struct {
+	requests  bucket
+	bandwidth bucket
+
+	lock    sync.Mutex
+	budgets map[peer.ID]*peerBudget
+	lastGC  time.Time
+}
+
+// bucket configures a token bucket. Zero rate disables it.
+type bucket struct {
+	rate  float64
+	burst float64
+}
+
+func (b bucket) enabled() bool {
+	return b.rate > 0
+}
+
+// refill returns the amount of tokens after the given time has elapsed.
+func (b bucket) refill(tokens float64, elapsed time.Duration) float64 {
+	return min(b.burst, tokens+b.rate*elapsed.Seconds())
+}
+
+type peerBudget struct {
+	requests  float64
+	bandwidth float64
+	updatedAt time.Time
+}
+
+// NewPeerLimiter creates a PeerLimiter out of the per-peer limits in params. It returns nil if
+// the limits are disabled.
+func NewPeerLimiter(params *Parameters) *PeerLimiter {
+	if params.PeerRequestsPerSecond <= 0 && params.PeerBandwidth == 0 {
+		return nil
+	}
+	return &PeerLimiter{
+		requests: bucket{
+			rate:  params.PeerRequestsPerSecond,
+			burst: float64(params.PeerRequestsBurst),
+		},
+		bandwidth: bucket{
+			rate:  float64(params.PeerBandwidth),
+			burst: float64(params.PeerBandwidthBurst),
+		},
+		budgets: make(map[peer.ID]*peerBudget),
+		lastGC:  time.Now(),
+	}
+}
+
+// Allow reports whether the peer has enough budget left for another request and takes a request
+// from its budget if so. Nil PeerLimiter allows all requests.
+func (l *PeerLimiter) Allow(peerID peer.ID) bool {
+	if l == nil {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	l.gc(now)
+	budget := l.budget(peerID, now)
+	if l.requests.enabled() && budget.requests < 1 {
+		return false
+	}
+	if l.bandwidth.enabled() && budget.bandwidth <= 0 {
+		return false
+	}
+	if l.requests.enabled() {
+		budget.requests--
+	}
+	return true
+}
+
+// Consume charges the bytes served to the peer from its bandwidth budget.
+func (l *PeerLimiter) Consume(peerID peer.ID, bytes int) {
+	if l == nil || !l.bandwidth.enabled() {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.budget(peerID, time.Now()).bandwidth -= float64(bytes)
+}
+
+// budget returns the refilled budget of the peer. Must be called under lock.
+func (l *PeerLimiter) budget(peerID peer.ID, now time.Time) *peerBudget {
+	budget, ok := l.budgets[peerID]
+	if !ok {
+		budget = &peerBudget{
+			requests:  l.requests.burst,
+			bandwidth: l.bandwidth.burst,
+			updatedAt: now,
+		}
+		l.budgets[peerID] = budget
+		return budget
+	}
+
+	elapsed := now.Sub(budget.updatedAt)
+	budget.requests = l.requests.refill(budget.requests, elapsed)
+	budget.bandwidth = l.bandwidth.refill(budget.bandwidth, elapsed)
+	budget.updatedAt = now
+	return budget
+}
+
+// gc drops the budgets that are fully recovered, as they are equal to the budgets of new peers.
+// Must be called under lock.
+func (l *PeerLimiter) gc(now time.Time) {
+	if now.Sub(l.lastGC) < peerLimiterGCInterval {
+		return
+	}
+	l.lastGC = now
+
+	for peerID, budget := range l.budgets {
+		elapsed := now.Sub(budget.updatedAt)
+		if l.requests.refill(budget.requests, elapsed) >= l.requests.burst &&
+			l.bandwidth.refill(budget.bandwidth, elapsed) >= l.bandwidth.burst {
+			delete(l.budgets, peerID)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerLimiter(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		limiter := NewPeerLimiter(DefaultParameters())
+		require.Nil(t, limiter)
+		require.True(t, limiter.Allow("peer"))
+		limiter.Consume("peer", 1)
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		params := DefaultParameters()
+		params.PeerRequestsPerSecond = 100
+		params.PeerRequestsBurst = 2
+		limiter := NewPeerLimiter(params)
+
+		require.True(t, limiter.Allow("peer"))
+		require.True(t, limiter.Allow("peer"))
+		require.False(t, limiter.Allow("peer"))
+		// budgets are per peer
+		require.True(t, limiter.Allow("other"))
+
+		// budget recovers over time
+		require.Eventually(t, func() bool {
+			return limiter.Allow("peer")
+		}, time.Second, time.Millisecond*5)
+	})
+
+	t.Run("bandwidth", func(t *testing.T) {
+		params := DefaultParameters()
+		params.PeerBandwidth = 1000
+		params.PeerBandwidthBurst = 100
+		limiter := NewPeerLimiter(params)
+
+		// a single request may exceed the budget
+		require.True(t, limiter.Allow("peer"))
+		limiter.Consume("peer", 150)
+		require.False(t, limiter.Allow("peer"))
+
+		require.Eventually(t, func() bool {
+			return limiter.Allow("peer")
+		}, time.Second, time.Millisecond*5)
+	})
+
+	t.Run("gc", func(t *testing.T) {
+		params := DefaultParameters()
+		params.PeerRequestsPerSecond = 1000
+		params.PeerRequestsBurst = 1
+		limiter := NewPeerLimiter(params)
+
+		require.True(t, limiter.Allow("peer"))
+		require.Len(t, limiter.budgets, 1)
+
+		// idle peers with recovered budgets are dropped
+		limiter.gc(time.Now().Add(peerLimiterGCInterval))
+		require.Empty(t, limiter.budgets)
+	})
+}
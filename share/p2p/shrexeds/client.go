@@ -17,16 +17,19 @@ import (
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/share"
-	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p"
 	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexeds/pb"
 )
 
 // Client is responsible for requesting EDSs for blocksync over the ShrEx/EDS protocol.
 type Client struct {
-	params     *Parameters
-	protocolID protocol.ID
-	host       host.Host
+	params          *Parameters
+	protocolIDs     []protocol.ID
+	rowsProtocolIDs []protocol.ID
+	host            host.Host
+
+	// partials keeps verified progress of interrupted transfers to resume them from other peers.
+	partials *partials
 
 	metrics *p2p.Metrics
 }
@@ -38,13 +41,16 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 	}
 
 	return &Client{
-		params:     params,
-		host:       host,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
+		params:          params,
+		host:            host,
+		protocolIDs:     p2p.ProtocolIDs(params.NetworkID(), protocolName, protocolVersions...),
+		rowsProtocolIDs: p2p.ProtocolIDs(params.NetworkID(), rowsProtocolName, rowsProtocolVersions...),
+		partials:        newPartials(),
 	}, nil
 }
 
-// RequestEDS requests the ODS from the given peers and returns the EDS upon success.
+// RequestEDS requests the ODS from the given peers and returns the EDS upon success. If a previous
+// request for the same EDS was interrupted, the transfer is resumed after the rows received so far.
 func (c *Client) RequestEDS(
 	ctx context.Context,
 	dataHash share.DataHash,
@@ -55,9 +61,41 @@ func (c *Client) RequestEDS(
 		return eds, nil
 	}
 	log.Debugw("client: eds request to peer failed", "peer", peer.String(), "hash", dataHash.String(), "error", err)
+	return nil, c.handleErr(ctx, err, peer, dataHash)
+}
+
+// RequestRows requests the EDS rows in range [from, to) from the given peer. Only the left half of
+// each row is transferred, the right half is recomputed locally, and every row is verified against
+// the matching row root of the given DAH.
+func (c *Client) RequestRows(
+	ctx context.Context,
+	root *share.Root,
+	from, to int,
+	peer peer.ID,
+) ([][]share.Share, error) {
+	if from < 0 || from >= to || to > len(root.RowRoots) {
+		return nil, fmt.Errorf("invalid row range [%d, %d) for square of width %d", from, to, len(root.RowRoots))
+	}
+
+	rows, err := c.doRowsRequest(ctx, root, from, to, peer)
+	if err == nil {
+		return rows, nil
+	}
+	log.Debugw("client: eds rows request to peer failed",
+		"peer", peer.String(),
+		"hash", root.String(),
+		"from", from,
+		"to", to,
+		"error", err)
+	return nil, c.handleErr(ctx, err, peer, root.Hash())
+}
+
+// handleErr records the failed request and converts timeouts that are not reported as context
+// errors into context.DeadlineExceeded.
+func (c *Client) handleErr(ctx context.Context, err error, peer peer.ID, dataHash share.DataHash) error {
 	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
-		return nil, err
+		return err
 	}
 	// some net.Errors also mean the context deadline was exceeded, but yamux/mocknet do not
 	// unwrap to a ctx err
@@ -65,17 +103,17 @@ func (c *Client) RequestEDS(
 	if errors.As(err, &ne) && ne.Timeout() {
 		if deadline, _ := ctx.Deadline(); deadline.Before(time.Now()) {
 			c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
-			return nil, context.DeadlineExceeded
+			return context.DeadlineExceeded
 		}
 	}
-	if !errors.Is(err, p2p.ErrNotFound) {
+	if !errors.Is(err, p2p.ErrNotFound) && !errors.Is(err, p2p.ErrRateLimited) {
 		log.Warnw("client: eds request to peer failed",
 			"peer", peer.String(),
 			"hash", dataHash.String(),
 			"err", err)
 	}
 
-	return nil, err
+	return err
 }
 
 func (c *Client) doRequest(
@@ -85,7 +123,7 @@ func (c *Client) doRequest(
 ) (*rsmt2d.ExtendedDataSquare, error) {
 	streamOpenCtx, cancel := context.WithTimeout(ctx, c.params.ServerReadTimeout)
 	defer cancel()
-	stream, err := c.host.NewStream(streamOpenCtx, to, c.protocolID)
+	stream, err := c.host.NewStream(streamOpenCtx, to, c.protocolIDs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open stream: %w", err)
 	}
@@ -93,10 +131,17 @@ func (c *Client) doRequest(
 
 	c.setStreamDeadlines(ctx, stream)
 
+	// resume the transfer after the already verified rows, if there are any and the server supports
+	// it. Otherwise, the received shares are skipped while the whole ODS is transferred again.
+	partial := c.partials.take(dataHash)
+	defer c.partials.put(dataHash, partial)
 	req := &pb.EDSRequest{Hash: dataHash}
+	if p2p.IsVersionAtLeast(stream.Protocol(), latestVersion, protocolVersions) {
+		req.Offset = uint32(len(partial.shares))
+	}
 
 	// request ODS
-	log.Debugw("client: requesting ods", "hash", dataHash.String(), "peer", to.String())
+	log.Debugw("client: requesting ods", "hash", dataHash.String(), "peer", to.String(), "offset", req.Offset)
 	_, err = serde.Write(stream, req)
 	if err != nil {
 		stream.Reset() //nolint:errcheck
@@ -129,15 +174,95 @@ func (c *Client) doRequest(
 		// reset stream deadlines to original values, since read deadline was changed during status read
 		c.setStreamDeadlines(ctx, stream)
 		// use header and ODS bytes to construct EDS and verify it against dataHash
-		eds, err := eds.ReadEDS(ctx, stream, dataHash)
+		eds, err := readODS(ctx, stream, dataHash, partial, int(resp.Offset))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read eds from ods bytes: %w", err)
 		}
+		// the transfer is complete, so there is nothing to resume
+		partial.shares = nil
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusSuccess)
 		return eds, nil
 	case pb.Status_NOT_FOUND:
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
 		return nil, p2p.ErrNotFound
+	case pb.Status_RATE_LIMITED:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
+		return nil, p2p.ErrRateLimited
+	case pb.Status_INVALID:
+		log.Debug("client: invalid request")
+		fallthrough
+	case pb.Status_INTERNAL:
+		fallthrough
+	default:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusInternalErr)
+		return nil, p2p.ErrInvalidResponse
+	}
+}
+
+func (c *Client) doRowsRequest(
+	ctx context.Context,
+	root *share.Root,
+	from, to int,
+	peerID peer.ID,
+) ([][]share.Share, error) {
+	streamOpenCtx, cancel := context.WithTimeout(ctx, c.params.ServerReadTimeout)
+	defer cancel()
+	stream, err := c.host.NewStream(streamOpenCtx, peerID, c.rowsProtocolIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer stream.Close()
+
+	c.setStreamDeadlines(ctx, stream)
+
+	req := &pb.RowsRequest{
+		Hash: root.Hash(),
+		From: uint32(from),
+		To:   uint32(to),
+	}
+
+	log.Debugw("client: requesting eds rows", "hash", root.String(), "peer", peerID.String(), "from", from, "to", to)
+	_, err = serde.Write(stream, req)
+	if err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, fmt.Errorf("failed to write request to stream: %w", err)
+	}
+	err = stream.CloseWrite()
+	if err != nil {
+		log.Debugw("client: error closing write", "err", err)
+	}
+
+	resp := new(pb.EDSResponse)
+	err = stream.SetReadDeadline(time.Now().Add(c.params.ServerReadTimeout))
+	if err != nil {
+		log.Debugw("client: failed to set read deadline for reading status", "err", err)
+	}
+	_, err = serde.Read(stream, resp)
+	if err != nil {
+		// server closes the stream here if we are rate limited
+		if errors.Is(err, io.EOF) {
+			c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
+			return nil, p2p.ErrNotFound
+		}
+		stream.Reset() //nolint:errcheck
+		return nil, fmt.Errorf("failed to read status from stream: %w", err)
+	}
+
+	switch resp.Status {
+	case pb.Status_OK:
+		c.setStreamDeadlines(ctx, stream)
+		rows, err := readRows(stream, root, from, to)
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusSuccess)
+		return rows, nil
+	case pb.Status_NOT_FOUND:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
+		return nil, p2p.ErrNotFound
+	case pb.Status_RATE_LIMITED:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
+		return nil, p2p.ErrRateLimited
 	case pb.Status_INVALID:
 		log.Debug("client: invalid request")
 		fallthrough
@@ -149,6 +274,30 @@ func (c *Client) doRequest(
 	}
 }
 
+// readRows reads the left halves of the requested rows, extends them and verifies each extended
+// row against its root.
+func readRows(r io.Reader, root *share.Root, from, to int) ([][]share.Share, error) {
+	odsWidth := len(root.RowRoots) / 2
+
+	rows := make([][]share.Share, 0, to-from)
+	for rowIdx := from; rowIdx < to; rowIdx++ {
+		half := make([]share.Share, odsWidth)
+		for i := range half {
+			half[i] = make(share.Share, share.Size)
+			if _, err := io.ReadFull(r, half[i]); err != nil {
+				return nil, fmt.Errorf("failed to read row %d: %w", rowIdx, err)
+			}
+		}
+
+		row, err := extendRow(half, rowIdx, root)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 func (c *Client) setStreamDeadlines(ctx context.Context, stream network.Stream) {
 	// set read/write deadline to use context deadline if it exists
 	if dl, ok := ctx.Deadline(); ok {
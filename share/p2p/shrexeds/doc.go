@@ -7,7 +7,11 @@
 //
 // The streams are established using the protocol ID:
 //
-//   - "{networkID}/shrex/eds/v0.0.1" where networkID is the network ID of the network. (e.g. "arabica")
+//   - "{networkID}/shrex/eds/v0.0.2" where networkID is the network ID of the network. (e.g. "arabica")
+//   - "{networkID}/shrex/eds-rows/v0.0.1" for requests of row ranges.
+//
+// Servers also handle version v0.0.1 of shrex/eds, which defines neither the offsets of the
+// requests and responses nor the RATE_LIMITED status.
 //
 // When a peer receives a request for extended data squares, it will read
 // the original data square from the EDS store by retrieving the underlying
@@ -16,6 +20,17 @@
 // The client on the other hand will take care of computing the extended data squares from
 // the original data square on receipt.
 //
+// The client verifies every original data square row as soon as it is received. If the transfer
+// is interrupted, the verified rows are kept and the next request for the same data root carries
+// the offset of the first missing share, so the transfer is resumed from another peer. The server
+// responds with the offset it actually starts from, letting the client skip the shares it already
+// has.
+//
+// A row range request asks for the rows [from, to) of the extended data square. Only the left half
+// of each row is sent, while the client extends it and verifies the row against the row root,
+// so that an extended data square can be downloaded from several peers at once and repaired out of
+// any half of its rows.
+//
 // # Usage
 //
 // To use a shrexeds client to request extended data squares from a peer, you must
@@ -34,6 +49,12 @@
 //   - `dataHash` is the data root of the extended data square and
 //   - `peer` is the peer ID of the peer to request the extended data square from.
 //
+// To request a range of rows, call `Client.RequestRows`:
+//
+//	rows, err := client.RequestRows(ctx, root, from, to, peer)
+//
+// where `root` is the data availability header of the extended data square.
+//
 // To use a shrexeds server to respond to requests for extended data squares from peers
 // you must first create a new `shrexeds.Server` instance by:
 //
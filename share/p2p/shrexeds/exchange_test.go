@@ -2,6 +2,7 @@ package shrexeds
 
 import (
 	"context"
+	"io"
 	"sync"
 	"testing"
 	"time"
@@ -14,10 +15,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/eds/edstest"
 	"github.com/celestiaorg/celestia-node/share/p2p"
+	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexeds/pb"
 )
 
 func TestExchange_RequestEDS(t *testing.T) {
@@ -116,7 +120,7 @@ func TestExchange_RequestEDS(t *testing.T) {
 			}
 		}
 		middleware := p2p.NewMiddleware(rateLimit)
-		server.host.SetStreamHandler(server.protocolID,
+		server.host.SetStreamHandler(server.protocolIDs[0],
 			middleware.RateLimitHandler(mockHandler))
 
 		// take server concurrency slots with blocked requests
@@ -131,6 +135,218 @@ func TestExchange_RequestEDS(t *testing.T) {
 		_, err = client.RequestEDS(ctx, nil, server.host.ID())
 		require.ErrorIs(t, err, p2p.ErrNotFound)
 	})
+
+	t.Run("EDS_peer_rate_limit", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		t.Cleanup(cancel)
+
+		store := newStore(t)
+		require.NoError(t, store.Start(ctx))
+		hosts := createMocknet(t, 2)
+
+		params := DefaultParameters()
+		params.PeerRequestsPerSecond = 0.001
+		params.PeerRequestsBurst = 1
+		client, err := NewClient(params, hosts[0])
+		require.NoError(t, err)
+		server, err := NewServer(params, hosts[1], store)
+		require.NoError(t, err)
+		require.NoError(t, server.Start(ctx))
+
+		eds := edstest.RandEDS(t, 4)
+		dah, err := share.NewRoot(eds)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, dah.Hash(), eds))
+
+		_, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+		require.NoError(t, err)
+
+		// the burst is spent, so the server responds with the rate limited status
+		_, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+		require.ErrorIs(t, err, p2p.ErrRateLimited)
+
+		// the older version doesn't define the status, so the stream is closed instead
+		stream, err := hosts[0].NewStream(ctx, server.host.ID(),
+			p2p.ProtocolIDs(params.NetworkID(), protocolName, "v0.0.1")...)
+		require.NoError(t, err)
+		_, err = serde.Write(stream, &pb.EDSRequest{Hash: dah.Hash()})
+		require.NoError(t, err)
+		require.NoError(t, stream.CloseWrite())
+		_, err = serde.Read(stream, &pb.EDSResponse{})
+		require.ErrorIs(t, err, io.EOF)
+	})
+}
+
+func TestExchange_RequestRows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	store, client, server := makeExchange(t)
+
+	require.NoError(t, store.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	eds := edstest.RandEDS(t, 4)
+	dah, err := share.NewRoot(eds)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, dah.Hash(), eds))
+
+	t.Run("ODS_and_parity_rows", func(t *testing.T) {
+		for _, rng := range [][2]int{{0, 4}, {1, 3}, {3, 6}, {5, 8}} {
+			rows, err := client.RequestRows(ctx, dah, rng[0], rng[1], server.host.ID())
+			require.NoError(t, err)
+			require.Len(t, rows, rng[1]-rng[0])
+			for i, row := range rows {
+				require.Equal(t, eds.Row(uint(rng[0]+i)), row)
+			}
+		}
+	})
+
+	t.Run("invalid_range", func(t *testing.T) {
+		_, err := client.RequestRows(ctx, dah, 2, 2, server.host.ID())
+		require.Error(t, err)
+		_, err = client.RequestRows(ctx, dah, 0, 9, server.host.ID())
+		require.Error(t, err)
+	})
+
+	t.Run("root_mismatch", func(t *testing.T) {
+		// the hash stays cached, so the server finds the square, but rows do not match the roots
+		corrupted := *dah
+		corrupted.RowRoots = append([][]byte{dah.RowRoots[1]}, dah.RowRoots[1:]...)
+		_, err := client.RequestRows(ctx, &corrupted, 0, 2, server.host.ID())
+		require.ErrorIs(t, err, p2p.ErrInvalidResponse)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		eds := edstest.RandEDS(t, 4)
+		dah, err := share.NewRoot(eds)
+		require.NoError(t, err)
+		_, err = client.RequestRows(ctx, dah, 0, 2, server.host.ID())
+		require.ErrorIs(t, err, p2p.ErrNotFound)
+	})
+}
+
+func TestExchange_RequestEDS_Resume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	store, client, server := makeExchange(t)
+
+	require.NoError(t, store.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	square := edstest.RandEDS(t, 8)
+	dah, err := share.NewRoot(square)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, dah.Hash(), square))
+
+	carReader, err := store.GetCAR(ctx, dah.Hash())
+	require.NoError(t, err)
+	odsReader, err := eds.ODSReader(carReader)
+	require.NoError(t, err)
+	odsBytes, err := io.ReadAll(odsReader)
+	require.NoError(t, err)
+	require.NoError(t, carReader.Close())
+
+	// serve half of the ODS and end the stream
+	server.host.SetStreamHandler(server.protocolIDs[0], func(stream network.Stream) {
+		_, err := serde.Read(stream, new(pb.EDSRequest))
+		require.NoError(t, err)
+		_, err = serde.Write(stream, &pb.EDSResponse{Status: pb.Status_OK})
+		require.NoError(t, err)
+		_, err = stream.Write(odsBytes[:len(odsBytes)/2])
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+	})
+
+	_, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+	require.Error(t, err)
+	partial := client.partials.take(dah.Hash())
+	require.NotEmpty(t, partial.shares)
+	// only complete rows are kept
+	require.Zero(t, len(partial.shares)%8)
+	received := partial.shares
+	client.partials.put(dah.Hash(), partial)
+
+	// record the offset the transfer is resumed from
+	var offset uint32
+	server.host.SetStreamHandler(server.protocolIDs[0], func(stream network.Stream) {
+		req := new(pb.EDSRequest)
+		_, err := serde.Read(stream, req)
+		require.NoError(t, err)
+		offset = req.Offset
+
+		odsReader, err := store.GetCAR(ctx, dah.Hash())
+		require.NoError(t, err)
+		defer odsReader.Close()
+		odsR, err := eds.ODSReaderFrom(odsReader, int(req.Offset))
+		require.NoError(t, err)
+		require.NoError(t, server.writeResponse(&log.SugaredLogger, &pb.EDSResponse{Status: pb.Status_OK, Offset: req.Offset}, stream))
+		_, err = server.writeODS(&log.SugaredLogger, odsR, stream)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+	})
+
+	requestedEDS, err := client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+	require.NoError(t, err)
+	require.Equal(t, square.Flattened(), requestedEDS.Flattened())
+	require.EqualValues(t, len(received), offset)
+	require.Empty(t, client.partials.take(dah.Hash()).shares)
+
+	// servers ignoring the offset are handled by skipping the shares received before
+	partial.shares = received
+	client.partials.put(dah.Hash(), partial)
+	server.host.SetStreamHandler(server.protocolIDs[0], func(stream network.Stream) {
+		_, err := serde.Read(stream, new(pb.EDSRequest))
+		require.NoError(t, err)
+		_, err = serde.Write(stream, &pb.EDSResponse{Status: pb.Status_OK})
+		require.NoError(t, err)
+		_, err = stream.Write(odsBytes)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+	})
+
+	requestedEDS, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+	require.NoError(t, err)
+	require.Equal(t, square.Flattened(), requestedEDS.Flattened())
+
+	// the older version doesn't define the offset, so it is not sent to the servers of it
+	partial.shares = received
+	client.partials.put(dah.Hash(), partial)
+	hosts := createMocknet(t, 2)
+	oldClient, err := NewClient(DefaultParameters(), hosts[0])
+	require.NoError(t, err)
+	oldClient.partials = client.partials
+	offset = 1
+	hosts[1].SetStreamHandler(server.protocolIDs[1], func(stream network.Stream) {
+		req := new(pb.EDSRequest)
+		_, err := serde.Read(stream, req)
+		require.NoError(t, err)
+		offset = req.Offset
+		_, err = serde.Write(stream, &pb.EDSResponse{Status: pb.Status_OK})
+		require.NoError(t, err)
+		_, err = stream.Write(odsBytes)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+	})
+
+	requestedEDS, err = oldClient.RequestEDS(ctx, dah.Hash(), hosts[1].ID())
+	require.NoError(t, err)
+	require.Equal(t, square.Flattened(), requestedEDS.Flattened())
+	require.Zero(t, offset)
+
+	// and the servers ignore the offset requested over the older version
+	stream, err := client.host.NewStream(ctx, server.host.ID(), server.protocolIDs[1])
+	require.NoError(t, err)
+	_, err = serde.Write(stream, &pb.EDSRequest{Hash: dah.Hash(), Offset: uint32(len(received))})
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseWrite())
+	resp := new(pb.EDSResponse)
+	_, err = serde.Read(stream, resp)
+	require.NoError(t, err)
+	require.Equal(t, pb.Status_OK, resp.Status)
+	require.Zero(t, resp.Offset)
+	requestedEDS, err = readODS(ctx, stream, dah.Hash(), &partialEDS{}, int(resp.Offset))
+	require.NoError(t, err)
+	require.Equal(t, square.Flattened(), requestedEDS.Flattened())
 }
 
 func newStore(t *testing.T) *eds.Store {
@@ -2,13 +2,32 @@ package shrexeds
 
 import (
 	"fmt"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/share/p2p"
 )
 
-const protocolString = "/shrex/eds/v0.0.1"
+const (
+	protocolName = "/shrex/eds"
+	// rowsProtocolName identifies the protocol serving ranges of EDS rows. It is separate from
+	// protocolName, so that peers not supporting row ranges are skipped on stream negotiation.
+	rowsProtocolName = "/shrex/eds-rows"
+
+	// latestVersion is the version of the protocol defining the offsets of EDSRequest and
+	// EDSResponse and the RATE_LIMITED status. Servers always transfer the whole ODS and close the
+	// streams of rate limited requests of the older version instead.
+	latestVersion = "v0.0.2"
+)
+
+// protocolVersions and rowsProtocolVersions list the supported versions of the protocols from the
+// newest to the oldest. Servers handle all of them, while clients negotiate the newest version
+// known to the server.
+var (
+	protocolVersions     = []string{latestVersion, "v0.0.1"}
+	rowsProtocolVersions = []string{"v0.0.1"}
+)
 
 var log = logging.Logger("shrex/eds")
 
@@ -18,12 +37,22 @@ type Parameters struct {
 
 	// BufferSize defines the size of the buffer used for writing an ODS over the stream.
 	BufferSize uint64
+
+	// ParallelPeers is the amount of peers the ODS is split across by rows when an EDS is requested
+	// through the ShrexGetter. Values below 2 disable parallel download, so the whole ODS is
+	// requested from a single peer.
+	ParallelPeers int
+
+	// StallTimeout is the time after which the rows that were not received yet during parallel
+	// download are recovered by requesting the matching parity rows from other peers.
+	StallTimeout time.Duration
 }
 
 func DefaultParameters() *Parameters {
 	return &Parameters{
-		Parameters: p2p.DefaultParameters(),
-		BufferSize: 32 * 1024,
+		Parameters:   p2p.DefaultParameters(),
+		BufferSize:   32 * 1024,
+		StallTimeout: 10 * time.Second,
 	}
 }
 
@@ -31,6 +60,12 @@ func (p *Parameters) Validate() error {
 	if p.BufferSize <= 0 {
 		return fmt.Errorf("invalid buffer size: %v, value should be positive and non-zero", p.BufferSize)
 	}
+	if p.ParallelPeers < 0 {
+		return fmt.Errorf("invalid parallel peers: %v, value should not be negative", p.ParallelPeers)
+	}
+	if p.ParallelPeers > 1 && p.StallTimeout <= 0 {
+		return fmt.Errorf("invalid stall timeout: %v, value should be positive and non-zero", p.StallTimeout)
+	}
 
 	return p.Parameters.Validate()
 }
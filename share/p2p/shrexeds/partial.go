@@ -0,0 +1,215 @@
+package shrexeds
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ipld/go-car"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/share/p2p"
+)
+
+// maxPartialTransfers limits the amount of interrupted transfers kept for resumption. The oldest
+// one is dropped once the limit is reached.
+const maxPartialTransfers = 8
+
+// partialEDS is the progress of an ODS transfer. It only holds rows that were verified against
+// the row roots, so the transfer can be resumed from another peer without trusting the previous one.
+type partialEDS struct {
+	// root is set once the roots received in the CAR header are verified against the data hash.
+	root   *share.Root
+	shares [][]byte
+
+	updatedAt time.Time
+}
+
+// partials keeps interrupted transfers by data hash.
+type partials struct {
+	lock      sync.Mutex
+	transfers map[string]*partialEDS
+}
+
+func newPartials() *partials {
+	return &partials{transfers: make(map[string]*partialEDS)}
+}
+
+// take removes and returns the progress of the transfer for the data hash, so that concurrent
+// requests for the same EDS do not share it. A new empty transfer is returned if none is kept.
+func (p *partials) take(dataHash share.DataHash) *partialEDS {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	partial, ok := p.transfers[dataHash.String()]
+	if !ok {
+		return &partialEDS{}
+	}
+	delete(p.transfers, dataHash.String())
+	return partial
+}
+
+// put stores the progress of an interrupted transfer, if there is any.
+func (p *partials) put(dataHash share.DataHash, partial *partialEDS) {
+	if len(partial.shares) == 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.transfers) >= maxPartialTransfers {
+		var oldest string
+		for hash, transfer := range p.transfers {
+			if oldest == "" || transfer.updatedAt.Before(p.transfers[oldest].updatedAt) {
+				oldest = hash
+			}
+		}
+		delete(p.transfers, oldest)
+	}
+	partial.updatedAt = time.Now()
+	p.transfers[dataHash.String()] = partial
+}
+
+// readODS reads the CAR header and the ODS shares from offset onwards, verifying every complete row
+// as soon as it is received and appending it to the partial progress. Leaves before the progress
+// are skipped, in case the peer started earlier than requested. The EDS is computed and verified
+// once all the rows are received.
+func readODS(
+	ctx context.Context,
+	r io.Reader,
+	dataHash share.DataHash,
+	partial *partialEDS,
+	offset int,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	carReader, err := car.NewCarReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading car header: %w", err)
+	}
+
+	// car header includes both row and col roots in header
+	roots := carReader.Header.Roots
+	if len(roots) == 0 || len(roots)%4 != 0 {
+		return nil, fmt.Errorf("%w: unexpected amount of roots: %d", p2p.ErrInvalidResponse, len(roots))
+	}
+	if partial.root == nil {
+		root := &share.Root{
+			RowRoots:    make([][]byte, len(roots)/2),
+			ColumnRoots: make([][]byte, len(roots)/2),
+		}
+		for i, cid := range roots {
+			if i < len(roots)/2 {
+				root.RowRoots[i] = ipld.NamespacedSha256FromCID(cid)
+				continue
+			}
+			root.ColumnRoots[i-len(roots)/2] = ipld.NamespacedSha256FromCID(cid)
+		}
+		if !bytes.Equal(root.Hash(), dataHash) {
+			return nil, fmt.Errorf("%w: roots do not match data hash", p2p.ErrInvalidResponse)
+		}
+		partial.root = root
+	}
+
+	odsWidth := len(partial.root.RowRoots) / 2
+	if len(roots) != 4*odsWidth {
+		return nil, fmt.Errorf("%w: square size changed", p2p.ErrInvalidResponse)
+	}
+	if offset > len(partial.shares) {
+		return nil, fmt.Errorf("%w: offset %d is ahead of the progress", p2p.ErrInvalidResponse, offset)
+	}
+
+	for ; offset < len(partial.shares); offset++ {
+		if _, err := carReader.Next(); err != nil {
+			return nil, fmt.Errorf("skipping received share: %w", err)
+		}
+	}
+
+	for len(partial.shares) < odsWidth*odsWidth {
+		rowIdx := len(partial.shares) / odsWidth
+		row := make([]share.Share, odsWidth)
+		for i := range row {
+			block, err := carReader.Next()
+			if err != nil {
+				return nil, fmt.Errorf("reading share of row %d: %w", rowIdx, err)
+			}
+			// the stored first quadrant shares are wrapped with the namespace twice.
+			row[i] = share.GetData(block.RawData())
+		}
+
+		if _, err := extendRow(row, rowIdx, partial.root); err != nil {
+			return nil, err
+		}
+		partial.shares = append(partial.shares, row...)
+	}
+
+	// use proofs adder if provided, to cache collected proofs while recomputing the eds
+	var opts []nmt.Option
+	visitor := ipld.ProofsAdderFromCtx(ctx).VisitFn()
+	if visitor != nil {
+		opts = append(opts, nmt.NodeVisitor(visitor))
+	}
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(
+		partial.shares,
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(odsWidth), opts...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("computing eds: %w", err)
+	}
+
+	newDah, err := share.NewRoot(eds)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(newDah.Hash(), dataHash) {
+		// the received rows can not be trusted anymore
+		partial.shares = nil
+		return nil, fmt.Errorf(
+			"%w: content integrity mismatch: imported root %s doesn't match expected root %s",
+			p2p.ErrInvalidResponse,
+			newDah.Hash(),
+			dataHash,
+		)
+	}
+	return eds, nil
+}
+
+// extendRow extends the left half of the row and verifies the extended row against its root.
+func extendRow(half []share.Share, rowIdx int, root *share.Root) ([]share.Share, error) {
+	odsWidth := len(root.RowRoots) / 2
+	parity, err := share.DefaultRSMT2DCodec().Encode(half)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend row %d: %w", rowIdx, err)
+	}
+	row := make([]share.Share, 0, 2*odsWidth)
+	row = append(row, half...)
+	row = append(row, parity...)
+
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(odsWidth), uint(rowIdx))
+	for _, shr := range row {
+		if err := tree.Push(shr); err != nil {
+			// the peer sent shares that are not properly ordered by namespace
+			log.Debugw("client: building row tree", "row", rowIdx, "err", err)
+			return nil, p2p.ErrInvalidResponse
+		}
+	}
+	rowRoot, err := tree.Root()
+	if err != nil {
+		log.Debugw("client: computing row root", "row", rowIdx, "err", err)
+		return nil, p2p.ErrInvalidResponse
+	}
+	if !bytes.Equal(rowRoot, root.RowRoots[rowIdx]) {
+		log.Debugw("client: row root mismatch", "row", rowIdx)
+		return nil, p2p.ErrInvalidResponse
+	}
+	return row, nil
+}
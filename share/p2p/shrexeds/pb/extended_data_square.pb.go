@@ -25,10 +25,11 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Status int32
 
 const (
-	Status_INVALID   Status = 0
-	Status_OK        Status = 1
-	Status_NOT_FOUND Status = 2
-	Status_INTERNAL  Status = 3
+	Status_INVALID      Status = 0
+	Status_OK           Status = 1
+	Status_NOT_FOUND    Status = 2
+	Status_INTERNAL     Status = 3
+	Status_RATE_LIMITED Status = 4
 )
 
 var Status_name = map[int32]string{
@@ -36,13 +37,15 @@ var Status_name = map[int32]string{
 	1: "OK",
 	2: "NOT_FOUND",
 	3: "INTERNAL",
+	4: "RATE_LIMITED",
 }
 
 var Status_value = map[string]int32{
-	"INVALID":   0,
-	"OK":        1,
-	"NOT_FOUND": 2,
-	"INTERNAL":  3,
+	"INVALID":      0,
+	"OK":           1,
+	"NOT_FOUND":    2,
+	"INTERNAL":     3,
+	"RATE_LIMITED": 4,
 }
 
 func (x Status) String() string {
@@ -54,7 +57,8 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 }
 
 type EDSRequest struct {
-	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 }
 
 func (m *EDSRequest) Reset()         { *m = EDSRequest{} }
@@ -97,8 +101,16 @@ func (m *EDSRequest) GetHash() []byte {
 	return nil
 }
 
+func (m *EDSRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 type EDSResponse struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=Status" json:"status,omitempty"`
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 }
 
 func (m *EDSResponse) Reset()         { *m = EDSResponse{} }
@@ -141,10 +153,78 @@ func (m *EDSResponse) GetStatus() Status {
 	return Status_INVALID
 }
 
+func (m *EDSResponse) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type RowsRequest struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	From uint32 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint32 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (m *RowsRequest) Reset()         { *m = RowsRequest{} }
+func (m *RowsRequest) String() string { return proto.CompactTextString(m) }
+func (*RowsRequest) ProtoMessage()    {}
+func (*RowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_49d42aa96098056e, []int{2}
+}
+func (m *RowsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RowsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowsRequest.Merge(m, src)
+}
+func (m *RowsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowsRequest proto.InternalMessageInfo
+
+func (m *RowsRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RowsRequest) GetFrom() uint32 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *RowsRequest) GetTo() uint32 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("Status", Status_name, Status_value)
 	proto.RegisterType((*EDSRequest)(nil), "EDSRequest")
 	proto.RegisterType((*EDSResponse)(nil), "EDSResponse")
+	proto.RegisterType((*RowsRequest)(nil), "RowsRequest")
 }
 
 func init() {
@@ -152,22 +232,25 @@ func init() {
 }
 
 var fileDescriptor_49d42aa96098056e = []byte{
-	// 227 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x28, 0xce, 0x48, 0x2c,
-	0x4a, 0xd5, 0x2f, 0x30, 0x2a, 0xd0, 0x2f, 0xce, 0x28, 0x4a, 0xad, 0x48, 0x4d, 0x29, 0xd6, 0x2f,
-	0x48, 0xd2, 0x4f, 0xad, 0x28, 0x49, 0xcd, 0x4b, 0x49, 0x4d, 0x89, 0x4f, 0x49, 0x2c, 0x49, 0x8c,
-	0x2f, 0x2e, 0x2c, 0x4d, 0x2c, 0x4a, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x57, 0x52, 0xe0, 0xe2,
-	0x72, 0x75, 0x09, 0x0e, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x12, 0xe2, 0x62, 0xc9, 0x48,
-	0x2c, 0xce, 0x90, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x02, 0xb3, 0x95, 0xf4, 0xb8, 0xb8, 0xc1,
-	0x2a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xe4, 0xb9, 0xd8, 0x8a, 0x4b, 0x12, 0x4b, 0x4a,
-	0x8b, 0xc1, 0x8a, 0xf8, 0x8c, 0xd8, 0xf5, 0x82, 0xc1, 0xdc, 0x20, 0xa8, 0xb0, 0x96, 0x15, 0x17,
-	0x1b, 0x44, 0x44, 0x88, 0x9b, 0x8b, 0xdd, 0xd3, 0x2f, 0xcc, 0xd1, 0xc7, 0xd3, 0x45, 0x80, 0x41,
-	0x88, 0x8d, 0x8b, 0xc9, 0xdf, 0x5b, 0x80, 0x51, 0x88, 0x97, 0x8b, 0xd3, 0xcf, 0x3f, 0x24, 0xde,
-	0xcd, 0x3f, 0xd4, 0xcf, 0x45, 0x80, 0x49, 0x88, 0x87, 0x8b, 0xc3, 0xd3, 0x2f, 0xc4, 0x35, 0xc8,
-	0xcf, 0xd1, 0x47, 0x80, 0xd9, 0x49, 0xe2, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f,
-	0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18,
-	0x92, 0xd8, 0xc0, 0xce, 0x35, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x7b, 0x1d, 0xd4, 0xa7, 0xe2,
-	0x00, 0x00, 0x00,
+	// 287 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x90, 0x3f, 0x6b, 0xf3, 0x30,
+	0x10, 0xc6, 0x2d, 0x27, 0x38, 0xef, 0x7b, 0xf9, 0x83, 0xd0, 0x50, 0x3c, 0xa9, 0x21, 0x53, 0xe8,
+	0x10, 0x97, 0x74, 0xe9, 0x9a, 0x62, 0x07, 0x4c, 0x5d, 0x07, 0x14, 0xb7, 0xab, 0x51, 0xb0, 0x82,
+	0x97, 0x46, 0x8e, 0x4f, 0xa1, 0xf9, 0x18, 0xfd, 0x58, 0x1d, 0x33, 0x76, 0x2c, 0xf6, 0x17, 0x29,
+	0xa8, 0x5e, 0xdb, 0xed, 0x79, 0x7e, 0xf0, 0xbb, 0x3b, 0x0e, 0x6e, 0xb1, 0x94, 0xb5, 0x0a, 0xaa,
+	0x65, 0x15, 0x60, 0x59, 0xab, 0xb3, 0x2a, 0x30, 0xa8, 0x76, 0x81, 0x3a, 0x1b, 0x75, 0x28, 0x54,
+	0x91, 0x17, 0xd2, 0xc8, 0x1c, 0x8f, 0x27, 0x59, 0xab, 0x45, 0x55, 0x6b, 0xa3, 0x67, 0xf7, 0x00,
+	0x51, 0xb8, 0x15, 0xea, 0x78, 0x52, 0x68, 0x18, 0x83, 0x7e, 0x29, 0xb1, 0xf4, 0xc9, 0x94, 0xcc,
+	0x47, 0xc2, 0x66, 0x76, 0x05, 0x9e, 0xde, 0xef, 0x51, 0x19, 0xdf, 0x9d, 0x92, 0xf9, 0x58, 0x74,
+	0x6d, 0xb6, 0x86, 0xa1, 0x35, 0xb1, 0xd2, 0x07, 0x54, 0xec, 0x1a, 0x3c, 0x34, 0xd2, 0x9c, 0xd0,
+	0xca, 0x93, 0xe5, 0x60, 0xb1, 0xb5, 0x55, 0x74, 0xf8, 0xd7, 0x39, 0x11, 0x0c, 0x85, 0x7e, 0xc3,
+	0xbf, 0x4e, 0x60, 0xd0, 0xdf, 0xd7, 0xfa, 0xb5, 0x13, 0x6d, 0x66, 0x13, 0x70, 0x8d, 0xf6, 0x7b,
+	0x96, 0xb8, 0x46, 0xdf, 0x24, 0xe0, 0xfd, 0x2c, 0x64, 0x43, 0x18, 0xc4, 0xe9, 0xcb, 0x2a, 0x89,
+	0x43, 0xea, 0x30, 0x0f, 0xdc, 0xcd, 0x23, 0x25, 0x6c, 0x0c, 0xff, 0xd3, 0x4d, 0x96, 0xaf, 0x37,
+	0xcf, 0x69, 0x48, 0x5d, 0x36, 0x82, 0x7f, 0x71, 0x9a, 0x45, 0x22, 0x5d, 0x25, 0xb4, 0xc7, 0x28,
+	0x8c, 0xc4, 0x2a, 0x8b, 0xf2, 0x24, 0x7e, 0x8a, 0xb3, 0x28, 0xa4, 0xfd, 0x07, 0xff, 0xa3, 0xe1,
+	0xe4, 0xd2, 0x70, 0xf2, 0xd5, 0x70, 0xf2, 0xde, 0x72, 0xe7, 0xd2, 0x72, 0xe7, 0xb3, 0xe5, 0xce,
+	0xce, 0xb3, 0x7f, 0xbb, 0xfb, 0x1e, 0x00, 0xc9, 0xef, 0x75, 0x69, 0x6b, 0x01, 0x00, 0x00,
 }
 
 func (m *EDSRequest) Marshal() (dAtA []byte, err error) {
@@ -190,6 +273,11 @@ func (m *EDSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Offset != 0 {
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Hash) > 0 {
 		i -= len(m.Hash)
 		copy(dAtA[i:], m.Hash)
@@ -220,6 +308,11 @@ func (m *EDSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Offset != 0 {
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Status != 0 {
 		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.Status))
 		i--
@@ -228,6 +321,46 @@ func (m *EDSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RowsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.To != 0 {
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.To))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.From != 0 {
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.From))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintExtendedDataSquare(dAtA []byte, offset int, v uint64) int {
 	offset -= sovExtendedDataSquare(v)
 	base := offset
@@ -249,6 +382,9 @@ func (m *EDSRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovExtendedDataSquare(uint64(l))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovExtendedDataSquare(uint64(m.Offset))
+	}
 	return n
 }
 
@@ -261,6 +397,28 @@ func (m *EDSResponse) Size() (n int) {
 	if m.Status != 0 {
 		n += 1 + sovExtendedDataSquare(uint64(m.Status))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovExtendedDataSquare(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *RowsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovExtendedDataSquare(uint64(l))
+	}
+	if m.From != 0 {
+		n += 1 + sovExtendedDataSquare(uint64(m.From))
+	}
+	if m.To != 0 {
+		n += 1 + sovExtendedDataSquare(uint64(m.To))
+	}
 	return n
 }
 
@@ -333,6 +491,25 @@ func (m *EDSRequest) Unmarshal(dAtA []byte) error {
 				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExtendedDataSquare(dAtA[iNdEx:])
@@ -402,6 +579,147 @@ func (m *EDSResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExtendedDataSquare(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExtendedDataSquare
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RowsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExtendedDataSquare
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExtendedDataSquare
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExtendedDataSquare
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+			}
+			m.To = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.To |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExtendedDataSquare(dAtA[iNdEx:])
@@ -1,12 +1,15 @@
 package shrexeds
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
+	"github.com/ipld/go-car"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -25,14 +28,17 @@ type Server struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	host       host.Host
-	protocolID protocol.ID
+	host            host.Host
+	protocolIDs     []protocol.ID
+	rowsProtocolIDs []protocol.ID
 
 	store *eds.Store
 
 	params     *Parameters
 	middleware *p2p.Middleware
-	metrics    *p2p.Metrics
+	// middlewares run around every request before the rate limiting
+	middlewares []p2p.StreamMiddleware
+	metrics     *p2p.Metrics
 }
 
 // NewServer creates a new ShrEx/EDS server.
@@ -41,30 +47,98 @@ func NewServer(params *Parameters, host host.Host, store *eds.Store) (*Server, e
 		return nil, fmt.Errorf("shrex-eds: server creation failed: %w", err)
 	}
 
-	return &Server{
-		host:       host,
-		store:      store,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
-		params:     params,
-		middleware: p2p.NewMiddleware(params.ConcurrencyLimit),
-	}, nil
+	s := &Server{
+		host:            host,
+		store:           store,
+		protocolIDs:     p2p.ProtocolIDs(params.NetworkID(), protocolName, protocolVersions...),
+		rowsProtocolIDs: p2p.ProtocolIDs(params.NetworkID(), rowsProtocolName, rowsProtocolVersions...),
+		params:          params,
+	}
+	s.middleware = p2p.NewMiddleware(
+		params.ConcurrencyLimit,
+		p2p.WithPeerLimiter(p2p.NewPeerLimiter(params.Parameters)),
+		p2p.WithRejectHandler(s.rejectStream),
+	)
+	return s, nil
+}
+
+// WithMiddlewares adds middlewares to run around every request. Middlewares are run in the given
+// order after the panic recovery and before the rate limiting. It must be called before Start.
+func (s *Server) WithMiddlewares(middlewares ...p2p.StreamMiddleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
 }
 
 func (s *Server) Start(context.Context) error {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
-	handler := s.handleStream
-	withRateLimit := s.middleware.RateLimitHandler(handler)
-	withRecovery := p2p.RecoveryMiddleware(withRateLimit)
-	s.host.SetStreamHandler(s.protocolID, withRecovery)
+	// all the versions are served, so that clients are not required to upgrade at once
+	handler := s.withMiddlewares(s.handleStream)
+	for _, id := range s.protocolIDs {
+		s.host.SetStreamHandler(id, handler)
+	}
+	// row ranges share the concurrency limit and peer budgets with full ODS requests
+	rowsHandler := s.withMiddlewares(s.handleRowsStream)
+	for _, id := range s.rowsProtocolIDs {
+		s.host.SetStreamHandler(id, rowsHandler)
+	}
 	return nil
 }
 
+// withMiddlewares wraps the handler with the panic recovery, the configured middlewares and the
+// rate limiting, in this order.
+func (s *Server) withMiddlewares(handler network.StreamHandler) network.StreamHandler {
+	middlewares := append([]p2p.StreamMiddleware{p2p.RecoveryMiddleware}, s.middlewares...)
+	return p2p.Chain(s.middleware.RateLimitHandler(handler), middlewares...)
+}
+
 func (s *Server) Stop(context.Context) error {
 	defer s.cancel()
-	s.host.RemoveStreamHandler(s.protocolID)
+	for _, id := range s.protocolIDs {
+		s.host.RemoveStreamHandler(id)
+	}
+	for _, id := range s.rowsProtocolIDs {
+		s.host.RemoveStreamHandler(id)
+	}
 	return nil
 }
 
+// rejectStream responds to the rate limited request with the RATE_LIMITED status, or closes the
+// stream if the negotiated version of the protocol doesn't define it.
+func (s *Server) rejectStream(stream network.Stream) {
+	logger := log.With("peer", stream.Conn().RemotePeer().String())
+	// all versions of the row range protocol define the status
+	rows := slices.Contains(s.rowsProtocolIDs, stream.Protocol())
+	if !rows && !p2p.IsVersionAtLeast(stream.Protocol(), latestVersion, protocolVersions) {
+		if err := stream.Close(); err != nil {
+			logger.Debugw("server: closing stream", "err", err)
+		}
+		return
+	}
+
+	// the request is read first, so that the client receives the status instead of a reset stream
+	var err error
+	timeout := min(s.params.ServerReadTimeout, p2p.RejectReadTimeout)
+	if rows {
+		_, err = s.readRowsRequest(logger, stream, timeout)
+	} else {
+		_, err = s.readRequest(logger, stream, timeout)
+	}
+	if err != nil {
+		logger.Debugw("server: reading rate limited request", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+
+	err = s.writeStatus(logger, p2p_pb.Status_RATE_LIMITED, stream)
+	if err != nil {
+		logger.Debugw("server: writing rate limited status", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	if err = stream.Close(); err != nil {
+		logger.Debugw("server: closing stream", "err", err)
+	}
+}
+
 func (s *Server) observeRateLimitedRequests() {
 	numRateLimited := s.middleware.DrainCounter()
 	if numRateLimited > 0 {
@@ -79,7 +153,7 @@ func (s *Server) handleStream(stream network.Stream) {
 	s.observeRateLimitedRequests()
 
 	// read request from stream to get the dataHash for store lookup
-	req, err := s.readRequest(logger, stream)
+	req, err := s.readRequest(logger, stream, s.params.ServerReadTimeout)
 	if err != nil {
 		logger.Warnw("server: reading request from stream", "err", err)
 		stream.Reset() //nolint:errcheck
@@ -94,35 +168,38 @@ func (s *Server) handleStream(stream network.Stream) {
 		stream.Reset() //nolint:errcheck
 		return
 	}
-	logger = logger.With("hash", hash.String())
+	// older versions of the protocol don't define the offset, so the whole ODS is sent
+	if !p2p.IsVersionAtLeast(stream.Protocol(), latestVersion, protocolVersions) {
+		req.Offset = 0
+	}
+	logger = logger.With("hash", hash.String(), "offset", req.Offset)
 
-	ctx, cancel := context.WithTimeout(s.ctx, s.params.HandleRequestTimeout)
+	ctx, cancel := context.WithTimeout(p2p.StreamContext(s.ctx, stream), s.params.HandleRequestTimeout)
 	defer cancel()
 
-	// determine whether the EDS is available in our store
-	// we do not close the reader, so that other requests will not need to re-open the file.
-	// closing is handled by the LRU cache.
-	edsReader, err := s.store.GetCAR(ctx, hash)
-	var status p2p_pb.Status
-	switch {
-	case err == nil:
+	edsReader, status := s.getCAR(ctx, logger, hash)
+	var odsReader io.Reader
+	if edsReader != nil {
 		defer func() {
 			if err := edsReader.Close(); err != nil {
 				log.Warnw("closing car reader", "err", err)
 			}
 		}()
-		status = p2p_pb.Status_OK
-	case errors.Is(err, eds.ErrNotFound):
-		logger.Warnw("server: request hash not found")
-		s.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
-		status = p2p_pb.Status_NOT_FOUND
-	case err != nil:
-		logger.Errorw("server: get CAR", "err", err)
-		status = p2p_pb.Status_INTERNAL
+
+		// skip the shares the client already has from a previously interrupted transfer
+		odsReader, err = eds.ODSReaderFrom(edsReader, int(req.Offset))
+		switch {
+		case errors.Is(err, eds.ErrOffsetOutOfBounds):
+			logger.Warnw("server: invalid offset", "err", err)
+			status = p2p_pb.Status_INVALID
+		case err != nil:
+			logger.Errorw("server: creating ODS reader", "err", err)
+			status = p2p_pb.Status_INTERNAL
+		}
 	}
 
 	// inform the client of our status
-	err = s.writeStatus(logger, status, stream)
+	err = s.writeResponse(logger, &p2p_pb.EDSResponse{Status: status, Offset: req.Offset}, stream)
 	if err != nil {
 		logger.Warnw("server: writing status to stream", "err", err)
 		stream.Reset() //nolint:errcheck
@@ -138,7 +215,8 @@ func (s *Server) handleStream(stream network.Stream) {
 	}
 
 	// start streaming the ODS to the client
-	err = s.writeODS(logger, edsReader, stream)
+	n, err := s.writeODS(logger, odsReader, stream)
+	s.middleware.Consume(stream.Conn().RemotePeer(), n)
 	if err != nil {
 		logger.Warnw("server: writing ods to stream", "err", err)
 		stream.Reset() //nolint:errcheck
@@ -152,8 +230,108 @@ func (s *Server) handleStream(stream network.Stream) {
 	}
 }
 
-func (s *Server) readRequest(logger *zap.SugaredLogger, stream network.Stream) (*p2p_pb.EDSRequest, error) {
-	err := stream.SetReadDeadline(time.Now().Add(s.params.ServerReadTimeout))
+func (s *Server) handleRowsStream(stream network.Stream) {
+	logger := log.With("peer", stream.Conn().RemotePeer().String())
+	logger.Debug("server: handling eds rows request")
+
+	s.observeRateLimitedRequests()
+
+	req, err := s.readRowsRequest(logger, stream, s.params.ServerReadTimeout)
+	if err != nil {
+		logger.Warnw("server: reading rows request from stream", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+
+	hash := share.DataHash(req.Hash)
+	err = hash.Validate()
+	if err != nil || req.From >= req.To {
+		logger.Warnw("server: invalid rows request", "err", err, "from", req.From, "to", req.To)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	logger = logger.With("hash", hash.String(), "from", req.From, "to", req.To)
+
+	ctx, cancel := context.WithTimeout(p2p.StreamContext(s.ctx, stream), s.params.HandleRequestTimeout)
+	defer cancel()
+
+	edsReader, status := s.getCAR(ctx, logger, hash)
+	var carReader *car.CarReader
+	if edsReader != nil {
+		defer func() {
+			if err := edsReader.Close(); err != nil {
+				log.Warnw("closing car reader", "err", err)
+			}
+		}()
+
+		carReader, err = car.NewCarReader(edsReader)
+		switch {
+		case err != nil:
+			logger.Errorw("server: reading CAR header", "err", err)
+			status = p2p_pb.Status_INTERNAL
+		case int(req.To) > len(carReader.Header.Roots)/2:
+			// car header includes both row and col roots, so there are half as many EDS rows
+			logger.Warnw("server: requested rows out of square bounds")
+			status = p2p_pb.Status_INVALID
+		}
+	}
+
+	err = s.writeStatus(logger, status, stream)
+	if err != nil {
+		logger.Warnw("server: writing status to stream", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	if status != p2p_pb.Status_OK {
+		err = stream.Close()
+		if err != nil {
+			logger.Debugw("server: closing stream", "err", err)
+		}
+		return
+	}
+
+	n, err := s.writeRows(logger, carReader, int(req.From), int(req.To), stream)
+	s.middleware.Consume(stream.Conn().RemotePeer(), n)
+	if err != nil {
+		logger.Warnw("server: writing rows to stream", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+
+	s.metrics.ObserveRequests(ctx, 1, p2p.StatusSuccess)
+	err = stream.Close()
+	if err != nil {
+		logger.Debugw("server: closing stream", "err", err)
+	}
+}
+
+// getCAR determines whether the EDS is available in our store and returns its CAR reader along with
+// the status to respond with. The reader is nil unless the status is OK.
+func (s *Server) getCAR(
+	ctx context.Context,
+	logger *zap.SugaredLogger,
+	hash share.DataHash,
+) (io.ReadCloser, p2p_pb.Status) {
+	edsReader, err := s.store.GetCAR(ctx, hash)
+	switch {
+	case err == nil:
+		return edsReader, p2p_pb.Status_OK
+	case errors.Is(err, eds.ErrNotFound):
+		logger.Warnw("server: request hash not found")
+		s.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
+		return nil, p2p_pb.Status_NOT_FOUND
+	default:
+		logger.Errorw("server: get CAR", "err", err)
+		return nil, p2p_pb.Status_INTERNAL
+	}
+}
+
+func (s *Server) readRequest(
+	logger *zap.SugaredLogger,
+	stream network.Stream,
+	timeout time.Duration,
+) (*p2p_pb.EDSRequest, error) {
+	err := stream.SetReadDeadline(time.Now().Add(timeout))
 	if err != nil {
 		logger.Debugw("server: set read deadline", "err", err)
 	}
@@ -171,32 +349,99 @@ func (s *Server) readRequest(logger *zap.SugaredLogger, stream network.Stream) (
 	return req, nil
 }
 
+func (s *Server) readRowsRequest(
+	logger *zap.SugaredLogger,
+	stream network.Stream,
+	timeout time.Duration,
+) (*p2p_pb.RowsRequest, error) {
+	err := stream.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		logger.Debugw("server: set read deadline", "err", err)
+	}
+
+	req := new(p2p_pb.RowsRequest)
+	_, err = serde.Read(stream, req)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.CloseRead()
+	if err != nil {
+		logger.Debugw("server: closing read", "err", err)
+	}
+
+	return req, nil
+}
+
 func (s *Server) writeStatus(logger *zap.SugaredLogger, status p2p_pb.Status, stream network.Stream) error {
+	return s.writeResponse(logger, &p2p_pb.EDSResponse{Status: status}, stream)
+}
+
+func (s *Server) writeResponse(logger *zap.SugaredLogger, resp *p2p_pb.EDSResponse, stream network.Stream) error {
 	err := stream.SetWriteDeadline(time.Now().Add(s.params.ServerWriteTimeout))
 	if err != nil {
 		logger.Debugw("server: set write deadline", "err", err)
 	}
 
-	resp := &p2p_pb.EDSResponse{Status: status}
 	_, err = serde.Write(stream, resp)
 	return err
 }
 
-func (s *Server) writeODS(logger *zap.SugaredLogger, edsReader io.Reader, stream network.Stream) error {
+// writeODS streams the ODS to the client and returns the amount of bytes written.
+func (s *Server) writeODS(logger *zap.SugaredLogger, odsReader io.Reader, stream network.Stream) (int, error) {
 	err := stream.SetWriteDeadline(time.Now().Add(s.params.ServerWriteTimeout))
 	if err != nil {
 		logger.Debugw("server: set read deadline", "err", err)
 	}
 
-	odsReader, err := eds.ODSReader(edsReader)
+	buf := make([]byte, s.params.BufferSize)
+	n, err := io.CopyBuffer(stream, odsReader, buf)
 	if err != nil {
-		return fmt.Errorf("creating ODS reader: %w", err)
+		return int(n), fmt.Errorf("writing ODS bytes: %w", err)
 	}
-	buf := make([]byte, s.params.BufferSize)
-	_, err = io.CopyBuffer(stream, odsReader, buf)
+
+	return int(n), nil
+}
+
+// writeRows writes the left half of every requested EDS row to the stream. The left half of ODS
+// rows is stored in the first quadrant of the CAR file, while the left half of parity rows is
+// stored in the third one, so the CAR file is read sequentially once, skipping the other blocks.
+// It returns the amount of bytes written.
+func (s *Server) writeRows(
+	logger *zap.SugaredLogger,
+	carReader *car.CarReader,
+	from, to int,
+	stream network.Stream,
+) (int, error) {
+	err := stream.SetWriteDeadline(time.Now().Add(s.params.ServerWriteTimeout))
 	if err != nil {
-		return fmt.Errorf("writing ODS bytes: %w", err)
+		logger.Debugw("server: set write deadline", "err", err)
 	}
 
-	return nil
+	odsWidth := len(carReader.Header.Roots) / 4
+	w := bufio.NewWriterSize(stream, int(s.params.BufferSize))
+	var read, written int
+	for row := from; row < to; row++ {
+		offset := row * odsWidth
+		if row >= odsWidth {
+			// skip second quadrant
+			offset += odsWidth * odsWidth
+		}
+
+		for ; read < offset+odsWidth; read++ {
+			block, err := carReader.Next()
+			if err != nil {
+				return written, fmt.Errorf("reading share from CAR: %w", err)
+			}
+			if read < offset {
+				continue
+			}
+			// the stored shares are wrapped with the namespace twice, so cut it off
+			n, err := w.Write(share.GetData(block.RawData()))
+			written += n
+			if err != nil {
+				return written, fmt.Errorf("writing row %d: %w", row, err)
+			}
+		}
+	}
+	return written, w.Flush()
 }
@@ -24,8 +24,8 @@ import (
 // Client implements client side of shrex/nd protocol to obtain namespaced shares data from remote
 // peers.
 type Client struct {
-	params     *Parameters
-	protocolID protocol.ID
+	params      *Parameters
+	protocolIDs []protocol.ID
 
 	host    host.Host
 	metrics *p2p.Metrics
@@ -38,9 +38,9 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 	}
 
 	return &Client{
-		host:       host,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
-		params:     params,
+		host:        host,
+		protocolIDs: p2p.ProtocolIDs(params.NetworkID(), protocolName, protocolVersions...),
+		params:      params,
 	}, nil
 }
 
@@ -85,7 +85,7 @@ func (c *Client) doRequest(
 	namespace share.Namespace,
 	peerID peer.ID,
 ) (share.NamespacedShares, error) {
-	stream, err := c.host.NewStream(ctx, peerID, c.protocolID)
+	stream, err := c.host.NewStream(ctx, peerID, c.protocolIDs...)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +212,9 @@ func (c *Client) convertStatusToErr(ctx context.Context, status pb.StatusCode) e
 	case pb.StatusCode_NOT_FOUND:
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
 		return p2p.ErrNotFound
+	case pb.StatusCode_RATE_LIMITED:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
+		return p2p.ErrRateLimited
 	case pb.StatusCode_INVALID:
 		log.Warn("client-nd: invalid request")
 		fallthrough
@@ -2,21 +2,28 @@ package shrexnd
 
 import (
 	"context"
+	"io"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p"
 	libhost "github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/eds/edstest"
 	"github.com/celestiaorg/celestia-node/share/p2p"
+	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexnd/pb"
 	"github.com/celestiaorg/celestia-node/share/sharetest"
 )
 
@@ -84,7 +91,7 @@ func TestExchange_RequestND(t *testing.T) {
 			}
 		}
 		middleware := p2p.NewMiddleware(rateLimit)
-		server.host.SetStreamHandler(server.protocolID,
+		server.host.SetStreamHandler(server.protocolIDs[0],
 			middleware.RateLimitHandler(mockHandler))
 
 		// take server concurrency slots with blocked requests
@@ -99,6 +106,131 @@ func TestExchange_RequestND(t *testing.T) {
 		_, err = client.RequestND(ctx, nil, sharetest.RandV0Namespace(), server.host.ID())
 		require.ErrorIs(t, err, p2p.ErrRateLimited)
 	})
+
+	t.Run("ND_peer_rate_limit", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		t.Cleanup(cancel)
+
+		store := newStore(t)
+		require.NoError(t, store.Start(ctx))
+		hosts := createMocknet(t, 2)
+
+		params := DefaultParameters()
+		params.PeerRequestsPerSecond = 0.001
+		params.PeerRequestsBurst = 1
+		client, err := NewClient(params, hosts[0])
+		require.NoError(t, err)
+		server, err := NewServer(params, hosts[1], store)
+		require.NoError(t, err)
+		require.NoError(t, server.Start(ctx))
+
+		eds := edstest.RandEDS(t, 4)
+		dah, err := share.NewRoot(eds)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, dah.Hash(), eds))
+
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.NoError(t, err)
+
+		// the burst is spent, so the server responds with the rate limited status
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.ErrorIs(t, err, p2p.ErrRateLimited)
+
+		// the older version doesn't define the status, so the stream is closed instead
+		stream, err := hosts[0].NewStream(ctx, server.host.ID(),
+			p2p.ProtocolIDs(params.NetworkID(), protocolName, "v0.0.3")...)
+		require.NoError(t, err)
+		req := &pb.GetSharesByNamespaceRequest{RootHash: dah.Hash(), Namespace: sharetest.RandV0Namespace()}
+		_, err = serde.Write(stream, req)
+		require.NoError(t, err)
+		require.NoError(t, stream.CloseWrite())
+		_, err = serde.Read(stream, &pb.GetSharesByNamespaceStatusResponse{})
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("ND_silent_rate_limited_client", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		t.Cleanup(cancel)
+
+		store := newStore(t)
+		require.NoError(t, store.Start(ctx))
+		// mocknet streams don't support deadlines, so the hosts are connected over TCP
+		hosts := make([]libhost.Host, 2)
+		for i := range hosts {
+			host, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, host.Close())
+			})
+			hosts[i] = host
+		}
+		require.NoError(t, hosts[0].Connect(ctx, peer.AddrInfo{ID: hosts[1].ID(), Addrs: hosts[1].Addrs()}))
+
+		params := DefaultParameters()
+		params.ServerReadTimeout = time.Minute
+		params.PeerRequestsPerSecond = 0.001
+		params.PeerRequestsBurst = 1
+		client, err := NewClient(params, hosts[0])
+		require.NoError(t, err)
+		server, err := NewServer(params, hosts[1], store)
+		require.NoError(t, err)
+		require.NoError(t, server.Start(ctx))
+
+		eds := edstest.RandEDS(t, 4)
+		dah, err := share.NewRoot(eds)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, dah.Hash(), eds))
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.NoError(t, err)
+
+		// the rate limited client never writes its request, but the server doesn't wait for it
+		// for the whole read timeout
+		start := time.Now()
+		stream, err := hosts[0].NewStream(ctx, server.host.ID(), server.protocolIDs[0])
+		require.NoError(t, err)
+		require.NoError(t, stream.SetReadDeadline(time.Now().Add(5*p2p.RejectReadTimeout)))
+		_, err = serde.Read(stream, &pb.GetSharesByNamespaceStatusResponse{})
+		require.Error(t, err)
+		require.Less(t, time.Since(start), 2*p2p.RejectReadTimeout)
+	})
+
+	t.Run("ND_version_negotiation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		t.Cleanup(cancel)
+
+		store, client, server := makeExchange(t)
+		require.NoError(t, store.Start(ctx))
+
+		networkID := DefaultParameters().NetworkID()
+		// the server handles an older version in addition to the current ones
+		serverVersions := append(slices.Clone(protocolVersions), "v0.0.1")
+		server.protocolIDs = p2p.ProtocolIDs(networkID, protocolName, serverVersions...)
+		require.NoError(t, server.Start(ctx))
+		t.Cleanup(func() {
+			require.NoError(t, server.Stop(ctx))
+		})
+
+		eds := edstest.RandEDS(t, 4)
+		dah, err := share.NewRoot(eds)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, dah.Hash(), eds))
+
+		// a newer client falls back to the newest version known to the server
+		clientVersions := append([]string{"v1.0.0"}, protocolVersions...)
+		client.protocolIDs = p2p.ProtocolIDs(networkID, protocolName, clientVersions...)
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.NoError(t, err)
+
+		// an older client is still served
+		client.protocolIDs = p2p.ProtocolIDs(networkID, protocolName, "v0.0.1")
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.NoError(t, err)
+
+		// a client without common versions can not open the stream
+		client.protocolIDs = p2p.ProtocolIDs(networkID, protocolName, "v1.0.0")
+		_, err = client.RequestND(ctx, dah, sharetest.RandV0Namespace(), server.host.ID())
+		require.Error(t, err)
+	})
 }
 
 func newStore(t *testing.T) *eds.Store {
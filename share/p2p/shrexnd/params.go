@@ -8,7 +8,16 @@ import (
 	"github.com/celestiaorg/celestia-node/share/p2p"
 )
 
-const protocolString = "/shrex/nd/v0.0.3"
+const (
+	protocolName = "/shrex/nd"
+	// rateLimitedVersion is the first version defining the RATE_LIMITED status. Servers close the
+	// streams of rate limited requests of the older versions instead.
+	rateLimitedVersion = "v0.0.4"
+)
+
+// protocolVersions lists the supported versions of the protocol from the newest to the oldest.
+// Servers handle all of them, while clients negotiate the newest version known to the server.
+var protocolVersions = []string{rateLimitedVersion, "v0.0.3"}
 
 var log = logging.Logger("shrex/nd")
 
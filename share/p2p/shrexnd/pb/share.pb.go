@@ -26,10 +26,11 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type StatusCode int32
 
 const (
-	StatusCode_INVALID   StatusCode = 0
-	StatusCode_OK        StatusCode = 1
-	StatusCode_NOT_FOUND StatusCode = 2
-	StatusCode_INTERNAL  StatusCode = 3
+	StatusCode_INVALID      StatusCode = 0
+	StatusCode_OK           StatusCode = 1
+	StatusCode_NOT_FOUND    StatusCode = 2
+	StatusCode_INTERNAL     StatusCode = 3
+	StatusCode_RATE_LIMITED StatusCode = 4
 )
 
 var StatusCode_name = map[int32]string{
@@ -37,13 +38,15 @@ var StatusCode_name = map[int32]string{
 	1: "OK",
 	2: "NOT_FOUND",
 	3: "INTERNAL",
+	4: "RATE_LIMITED",
 }
 
 var StatusCode_value = map[string]int32{
-	"INVALID":   0,
-	"OK":        1,
-	"NOT_FOUND": 2,
-	"INTERNAL":  3,
+	"INVALID":      0,
+	"OK":           1,
+	"NOT_FOUND":    2,
+	"INTERNAL":     3,
+	"RATE_LIMITED": 4,
 }
 
 func (x StatusCode) String() string {
@@ -212,28 +215,28 @@ func init() {
 func init() { proto.RegisterFile("share/p2p/shrexnd/pb/share.proto", fileDescriptor_ed9f13149b0de397) }
 
 var fileDescriptor_ed9f13149b0de397 = []byte{
-	// 326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x4f, 0x4b, 0xf3, 0x40,
-	0x10, 0xc6, 0x93, 0x96, 0x37, 0x6f, 0x3b, 0xad, 0x35, 0x2c, 0x22, 0xc5, 0xca, 0x52, 0x02, 0x42,
-	0xf1, 0xb0, 0x81, 0x08, 0x1e, 0x85, 0xd6, 0xfa, 0xa7, 0x58, 0x52, 0xd9, 0xb6, 0xe2, 0x41, 0x28,
-	0x1b, 0xbb, 0x92, 0x8b, 0xd9, 0x35, 0xbb, 0x45, 0xfd, 0x16, 0x7e, 0x2c, 0x8f, 0x3d, 0x7a, 0x94,
-	0xf6, 0x8b, 0x48, 0xb6, 0xd1, 0x1c, 0xf4, 0xb6, 0xf3, 0xcc, 0x33, 0xbf, 0x7d, 0x66, 0xa0, 0xad,
-	0x62, 0x96, 0x72, 0x5f, 0x06, 0xd2, 0x57, 0x71, 0xca, 0x5f, 0x92, 0xb9, 0x2f, 0x23, 0xdf, 0x88,
-	0x44, 0xa6, 0x42, 0x0b, 0x84, 0xf2, 0x22, 0x90, 0xc4, 0x38, 0x48, 0x32, 0xdf, 0x6b, 0xc8, 0xc8,
-	0x97, 0xa9, 0x10, 0x0f, 0x1b, 0x8f, 0x77, 0x0b, 0xad, 0x0b, 0xae, 0xc7, 0x99, 0x51, 0xf5, 0x5e,
-	0x43, 0xf6, 0xc8, 0x95, 0x64, 0xf7, 0x9c, 0xf2, 0xa7, 0x05, 0x57, 0x1a, 0xb5, 0xa0, 0x9a, 0x0a,
-	0xa1, 0x67, 0x31, 0x53, 0x71, 0xd3, 0x6e, 0xdb, 0x9d, 0x3a, 0xad, 0x64, 0xc2, 0x25, 0x53, 0x31,
-	0xda, 0x87, 0x6a, 0xf2, 0x3d, 0xd0, 0x2c, 0x99, 0x66, 0x21, 0x78, 0x77, 0xe0, 0xfd, 0x45, 0x1e,
-	0x6b, 0xa6, 0x17, 0x8a, 0x72, 0x25, 0x45, 0xa2, 0x38, 0x3a, 0x06, 0x47, 0x19, 0xc5, 0xd0, 0x1b,
-	0x01, 0x26, 0xbf, 0x43, 0x93, 0xcd, 0xcc, 0xa9, 0x98, 0x73, 0x9a, 0xbb, 0xbd, 0x29, 0xec, 0x14,
-	0x61, 0xc5, 0xf3, 0x0f, 0x6f, 0x17, 0x1c, 0x03, 0xc8, 0x78, 0xe5, 0x4e, 0x9d, 0xe6, 0x15, 0x3a,
-	0x80, 0x7f, 0x66, 0x6d, 0x93, 0xb3, 0x16, 0x6c, 0x93, 0xfc, 0x08, 0x11, 0xb9, 0xce, 0x1e, 0x74,
-	0xd3, 0x3d, 0x3c, 0x01, 0x28, 0x3e, 0x43, 0x35, 0xf8, 0x3f, 0x08, 0x6f, 0xba, 0xc3, 0x41, 0xdf,
-	0xb5, 0x90, 0x03, 0xa5, 0xd1, 0x95, 0x6b, 0xa3, 0x2d, 0xa8, 0x86, 0xa3, 0xc9, 0xec, 0x7c, 0x34,
-	0x0d, 0xfb, 0x6e, 0x09, 0xd5, 0xa1, 0x32, 0x08, 0x27, 0x67, 0x34, 0xec, 0x0e, 0xdd, 0x72, 0xaf,
-	0xf9, 0xbe, 0xc2, 0xf6, 0x72, 0x85, 0xed, 0xcf, 0x15, 0xb6, 0xdf, 0xd6, 0xd8, 0x5a, 0xae, 0xb1,
-	0xf5, 0xb1, 0xc6, 0x56, 0xe4, 0x98, 0x7b, 0x1f, 0x7d, 0x05, 0x00, 0x00, 0xff, 0xff, 0x1a, 0x53,
-	0xb4, 0x86, 0xb7, 0x01, 0x00, 0x00,
+	// 335 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x41, 0x4f, 0xc2, 0x30,
+	0x14, 0xc7, 0x37, 0xd0, 0x09, 0x8f, 0x89, 0x4b, 0x63, 0x0c, 0x11, 0xd3, 0x90, 0x25, 0x26, 0xc4,
+	0x43, 0x97, 0xcc, 0xc4, 0x3b, 0x08, 0xea, 0x22, 0x0e, 0x52, 0x86, 0xf1, 0x60, 0x42, 0x36, 0xa9,
+	0xd9, 0xc5, 0xb5, 0xae, 0x23, 0xea, 0xb7, 0xf0, 0x63, 0x79, 0xe4, 0xe8, 0xd1, 0xc0, 0x17, 0x31,
+	0x2b, 0x53, 0x0e, 0x7a, 0xeb, 0xfb, 0xbf, 0xff, 0xfb, 0xf5, 0xdf, 0x3e, 0x68, 0xc9, 0x38, 0x4c,
+	0x99, 0x23, 0x5c, 0xe1, 0xc8, 0x38, 0x65, 0xaf, 0xc9, 0xcc, 0x11, 0x91, 0xa3, 0x44, 0x22, 0x52,
+	0x9e, 0x71, 0x84, 0x8a, 0xc2, 0x15, 0x44, 0x39, 0x48, 0x32, 0x3b, 0xac, 0x8b, 0xc8, 0x11, 0x29,
+	0xe7, 0x8f, 0x6b, 0x8f, 0x7d, 0x07, 0xcd, 0x4b, 0x96, 0x8d, 0x73, 0xa3, 0xec, 0xbe, 0xf9, 0xe1,
+	0x13, 0x93, 0x22, 0x7c, 0x60, 0x94, 0x3d, 0xcf, 0x99, 0xcc, 0x50, 0x13, 0xaa, 0x29, 0xe7, 0xd9,
+	0x34, 0x0e, 0x65, 0xdc, 0xd0, 0x5b, 0x7a, 0xdb, 0xa4, 0x95, 0x5c, 0xb8, 0x0a, 0x65, 0x8c, 0x8e,
+	0xa0, 0x9a, 0xfc, 0x0c, 0x34, 0x4a, 0xaa, 0xb9, 0x11, 0xec, 0x7b, 0xb0, 0xff, 0x23, 0x8f, 0xb3,
+	0x30, 0x9b, 0x4b, 0xca, 0xa4, 0xe0, 0x89, 0x64, 0xe8, 0x0c, 0x0c, 0xa9, 0x14, 0x45, 0xaf, 0xbb,
+	0x98, 0xfc, 0x0d, 0x4d, 0xd6, 0x33, 0xe7, 0x7c, 0xc6, 0x68, 0xe1, 0xb6, 0x27, 0xb0, 0xbf, 0x09,
+	0xcb, 0x5f, 0x7e, 0x79, 0x07, 0x60, 0x28, 0x40, 0xce, 0x2b, 0xb7, 0x4d, 0x5a, 0x54, 0xe8, 0x18,
+	0xb6, 0xd5, 0xb3, 0x55, 0xce, 0x9a, 0xbb, 0x47, 0x8a, 0x4f, 0x88, 0xc8, 0x28, 0x3f, 0xd0, 0x75,
+	0xf7, 0x64, 0x04, 0xb0, 0xb9, 0x0c, 0xd5, 0x60, 0xc7, 0xf3, 0x6f, 0x3b, 0x03, 0xaf, 0x67, 0x69,
+	0xc8, 0x80, 0xd2, 0xf0, 0xda, 0xd2, 0xd1, 0x2e, 0x54, 0xfd, 0x61, 0x30, 0xbd, 0x18, 0x4e, 0xfc,
+	0x9e, 0x55, 0x42, 0x26, 0x54, 0x3c, 0x3f, 0xe8, 0x53, 0xbf, 0x33, 0xb0, 0xca, 0xc8, 0x02, 0x93,
+	0x76, 0x82, 0xfe, 0x74, 0xe0, 0xdd, 0x78, 0x41, 0xbf, 0x67, 0x6d, 0x75, 0x1b, 0x1f, 0x4b, 0xac,
+	0x2f, 0x96, 0x58, 0xff, 0x5a, 0x62, 0xfd, 0x7d, 0x85, 0xb5, 0xc5, 0x0a, 0x6b, 0x9f, 0x2b, 0xac,
+	0x45, 0x86, 0xda, 0xc0, 0xe9, 0xf7, 0x00, 0xea, 0xd0, 0x44, 0xff, 0xc9, 0x01, 0x00, 0x00,
 }
 
 func (m *GetSharesByNamespaceRequest) Marshal() (dAtA []byte, err error) {
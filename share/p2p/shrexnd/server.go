@@ -26,15 +26,17 @@ import (
 type Server struct {
 	cancel context.CancelFunc
 
-	host       host.Host
-	protocolID protocol.ID
+	host        host.Host
+	protocolIDs []protocol.ID
 
 	handler network.StreamHandler
 	store   *eds.Store
 
 	params     *Parameters
 	middleware *p2p.Middleware
-	metrics    *p2p.Metrics
+	// middlewares run around every request before the rate limiting
+	middlewares []p2p.StreamMiddleware
+	metrics     *p2p.Metrics
 }
 
 // NewServer creates new Server
@@ -44,39 +46,54 @@ func NewServer(params *Parameters, host host.Host, store *eds.Store) (*Server, e
 	}
 
 	srv := &Server{
-		store:      store,
-		host:       host,
-		params:     params,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
-		middleware: p2p.NewMiddleware(params.ConcurrencyLimit),
+		store:       store,
+		host:        host,
+		params:      params,
+		protocolIDs: p2p.ProtocolIDs(params.NetworkID(), protocolName, protocolVersions...),
 	}
+	srv.middleware = p2p.NewMiddleware(
+		params.ConcurrencyLimit,
+		p2p.WithPeerLimiter(p2p.NewPeerLimiter(params)),
+		p2p.WithRejectHandler(srv.rejectStream),
+	)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	srv.cancel = cancel
 
 	handler := srv.streamHandler(ctx)
-	withRateLimit := srv.middleware.RateLimitHandler(handler)
-	withRecovery := p2p.RecoveryMiddleware(withRateLimit)
-	srv.handler = withRecovery
+	srv.handler = srv.middleware.RateLimitHandler(handler)
 	return srv, nil
 }
 
+// WithMiddlewares adds middlewares to run around every request. Middlewares are run in the given
+// order after the panic recovery and before the rate limiting. It must be called before Start.
+func (srv *Server) WithMiddlewares(middlewares ...p2p.StreamMiddleware) {
+	srv.middlewares = append(srv.middlewares, middlewares...)
+}
+
 // Start starts the server
 func (srv *Server) Start(context.Context) error {
-	srv.host.SetStreamHandler(srv.protocolID, srv.handler)
+	middlewares := append([]p2p.StreamMiddleware{p2p.RecoveryMiddleware}, srv.middlewares...)
+	handler := p2p.Chain(srv.handler, middlewares...)
+	// all the versions are served, so that clients are not required to upgrade at once
+	for _, id := range srv.protocolIDs {
+		srv.host.SetStreamHandler(id, handler)
+	}
 	return nil
 }
 
 // Stop stops the server
 func (srv *Server) Stop(context.Context) error {
 	srv.cancel()
-	srv.host.RemoveStreamHandler(srv.protocolID)
+	for _, id := range srv.protocolIDs {
+		srv.host.RemoveStreamHandler(id)
+	}
 	return nil
 }
 
 func (srv *Server) streamHandler(ctx context.Context) network.StreamHandler {
 	return func(s network.Stream) {
-		err := srv.handleNamespacedData(ctx, s)
+		err := srv.handleNamespacedData(p2p.StreamContext(ctx, s), s)
 		if err != nil {
 			s.Reset() //nolint:errcheck
 			return
@@ -92,6 +109,36 @@ func (srv *Server) SetHandler(handler network.StreamHandler) {
 	srv.handler = handler
 }
 
+// rejectStream responds to the rate limited request with the RATE_LIMITED status, or closes the
+// stream if the negotiated version of the protocol doesn't define it.
+func (srv *Server) rejectStream(stream network.Stream) {
+	logger := log.With("source", "server", "peer", stream.Conn().RemotePeer().String())
+	if !p2p.IsVersionAtLeast(stream.Protocol(), rateLimitedVersion, protocolVersions) {
+		if err := stream.Close(); err != nil {
+			logger.Debugw("server: closing stream", "err", err)
+		}
+		return
+	}
+
+	// the request is read first, so that the client receives the status instead of a reset stream
+	_, err := srv.readRequest(logger, stream, min(srv.params.ServerReadTimeout, p2p.RejectReadTimeout))
+	if err != nil {
+		logger.Debugw("reading rate limited request", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+
+	err = srv.respondStatus(context.Background(), logger, stream, pb.StatusCode_RATE_LIMITED)
+	if err != nil {
+		logger.Debugw("sending rate limited response", "err", err)
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	if err = stream.Close(); err != nil {
+		logger.Debugw("server: closing stream", "err", err)
+	}
+}
+
 func (srv *Server) observeRateLimitedRequests() {
 	numRateLimited := srv.middleware.DrainCounter()
 	if numRateLimited > 0 {
@@ -104,7 +151,7 @@ func (srv *Server) handleNamespacedData(ctx context.Context, stream network.Stre
 	logger.Debug("handling nd request")
 
 	srv.observeRateLimitedRequests()
-	req, err := srv.readRequest(logger, stream)
+	req, err := srv.readRequest(logger, stream, srv.params.ServerReadTimeout)
 	if err != nil {
 		logger.Warnw("read request", "err", err)
 		srv.metrics.ObserveRequests(ctx, 1, p2p.StatusBadRequest)
@@ -136,7 +183,8 @@ func (srv *Server) handleNamespacedData(ctx context.Context, stream network.Stre
 		return err
 	}
 
-	err = srv.sendNamespacedShares(shares, stream)
+	n, err := srv.sendNamespacedShares(shares, stream)
+	srv.middleware.Consume(stream.Conn().RemotePeer(), n)
 	if err != nil {
 		logger.Errorw("send nd data", "err", err)
 		srv.metrics.ObserveRequests(ctx, 1, p2p.StatusSendRespErr)
@@ -148,8 +196,9 @@ func (srv *Server) handleNamespacedData(ctx context.Context, stream network.Stre
 func (srv *Server) readRequest(
 	logger *zap.SugaredLogger,
 	stream network.Stream,
+	timeout time.Duration,
 ) (*pb.GetSharesByNamespaceRequest, error) {
-	err := stream.SetReadDeadline(time.Now().Add(srv.params.ServerReadTimeout))
+	err := stream.SetReadDeadline(time.Now().Add(timeout))
 	if err != nil {
 		logger.Debugw("setting read deadline", "err", err)
 	}
@@ -213,8 +262,10 @@ func (srv *Server) respondStatus(
 	return nil
 }
 
-// sendNamespacedShares encodes shares into proto messages and sends it to client
-func (srv *Server) sendNamespacedShares(shares share.NamespacedShares, stream network.Stream) error {
+// sendNamespacedShares encodes shares into proto messages and sends it to client. It returns the
+// amount of bytes written.
+func (srv *Server) sendNamespacedShares(shares share.NamespacedShares, stream network.Stream) (int, error) {
+	var written int
 	for _, row := range shares {
 		row := &pb.NamespaceRowResponse{
 			Shares: row.Shares,
@@ -226,12 +277,13 @@ func (srv *Server) sendNamespacedShares(shares share.NamespacedShares, stream ne
 				IsMaxNamespaceIgnored: row.Proof.IsMaxNamespaceIDIgnored(),
 			},
 		}
-		_, err := serde.Write(stream, row)
+		n, err := serde.Write(stream, row)
+		written += n
 		if err != nil {
-			return fmt.Errorf("writing nd data to stream: %w", err)
+			return written, fmt.Errorf("writing nd data to stream: %w", err)
 		}
 	}
-	return nil
+	return written, nil
 }
 
 func (srv *Server) observeStatus(ctx context.Context, status pb.StatusCode) {
@@ -28,6 +28,13 @@
 //
 // where `notification` is of type [shrexsub.Notification].
 //
+// A notification may carry a [shrexsub.NamespaceFilter] of namespaces present in the block, built
+// with [shrexsub.NewNamespaceFilterFromEDS]. Subscribers following specific namespaces can use it
+// to skip blocks that do not contain them. The filter is a hint that is not backed by proofs, so it
+// should be checked against the DAH of the block with [shrexsub.NamespaceFilter.ValidateRoot].
+// The check only covers the min and max namespaces of the rows, so a single peer can still hide a
+// namespace inside a row, and filters should be trusted only once announced by several peers.
+//
 // and `DataHash` is the hash of the share that you want to broadcast, and `Height` is the height of the share.
 //
 // You can also subscribe to the pubsub topic by:
@@ -0,0 +1,140 @@
+package shrexsub
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	// namespaceFilterHashes is the amount of bits set in the filter per namespace.
+	namespaceFilterHashes = 4
+	// namespaceFilterBitsPerNamespace gives about 1% false positive rate with 4 hashes.
+	namespaceFilterBitsPerNamespace = 10
+	// minNamespaceFilterSize and maxNamespaceFilterSize bound the size of the filter in bytes. The
+	// upper bound is enough for every share of the largest square to have its own namespace.
+	minNamespaceFilterSize = 8
+	maxNamespaceFilterSize = 1 << 15
+)
+
+// NamespaceFilter is a bloom filter of namespaces present in the ODS of a block. It lets
+// subscribers following specific namespaces skip blocks that do not contain them. Nil filter is
+// unknown and may contain any namespace.
+//
+// The filter is deterministic, so honest peers announcing the same block produce equal filters.
+type NamespaceFilter []byte
+
+// NewNamespaceFilter builds a filter out of the given namespaces.
+func NewNamespaceFilter(namespaces []share.Namespace) NamespaceFilter {
+	distinct := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		distinct[string(ns)] = struct{}{}
+	}
+
+	// size is rounded up to a power of two, so that filters of the same block are always equal in
+	// size and could be merged
+	size := (len(distinct)*namespaceFilterBitsPerNamespace + 7) / 8
+	size = max(size, minNamespaceFilterSize)
+	size = min(1<<bits.Len(uint(size-1)), maxNamespaceFilterSize)
+
+	filter := make(NamespaceFilter, size)
+	for ns := range distinct {
+		for _, idx := range filter.indexes(share.Namespace(ns)) {
+			filter[idx/8] |= 1 << (idx % 8)
+		}
+	}
+	return filter
+}
+
+// NewNamespaceFilterFromEDS builds a filter out of the namespaces of the ODS shares.
+func NewNamespaceFilterFromEDS(eds *rsmt2d.ExtendedDataSquare) NamespaceFilter {
+	odsWidth := eds.Width() / 2
+	namespaces := make([]share.Namespace, 0, odsWidth*odsWidth)
+	for row := uint(0); row < odsWidth; row++ {
+		for col := uint(0); col < odsWidth; col++ {
+			namespaces = append(namespaces, share.GetNamespace(eds.GetCell(row, col)))
+		}
+	}
+	return NewNamespaceFilter(namespaces)
+}
+
+// Contains reports whether the namespace may be present in the block. False positives are
+// possible, false negatives are not.
+func (f NamespaceFilter) Contains(ns share.Namespace) bool {
+	if f == nil {
+		return true
+	}
+	for _, idx := range f.indexes(ns) {
+		if f[idx/8]&(1<<(idx%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate performs basic validation of the filter. It is cheap enough to be run on every
+// received notification.
+func (f NamespaceFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	size := len(f)
+	if size < minNamespaceFilterSize || size > maxNamespaceFilterSize || size&(size-1) != 0 {
+		return fmt.Errorf("invalid namespace filter size: %d", size)
+	}
+	return nil
+}
+
+// ValidateRoot checks the filter against the given root of the block: it must contain the
+// minimum and maximum namespaces of every ODS row root, as they are namespaces of the row's
+// shares. The parity rows only hold the parity namespace, which is not in the ODS, so they are
+// not checked. Filters failing the check can not have been built out of the block.
+func (f NamespaceFilter) ValidateRoot(root *share.Root) error {
+	if f == nil {
+		return nil
+	}
+	for i, rowRoot := range root.RowRoots[:len(root.RowRoots)/2] {
+		if len(rowRoot) < 2*share.NamespaceSize {
+			return fmt.Errorf("invalid row root %d length: %d", i, len(rowRoot))
+		}
+		minNs := share.Namespace(rowRoot[:share.NamespaceSize])
+		maxNs := share.Namespace(rowRoot[share.NamespaceSize : 2*share.NamespaceSize])
+		if !f.Contains(minNs) || !f.Contains(maxNs) {
+			return fmt.Errorf("namespace filter misses namespaces of row %d", i)
+		}
+	}
+	return nil
+}
+
+// Merge returns the union of two filters of the same block. Namespace is then only reported
+// absent if both filters agree on it, so a single peer can not hide a namespace present in the
+// block. Filters of different sizes can not be merged, and the result is an unknown filter.
+func (f NamespaceFilter) Merge(other NamespaceFilter) NamespaceFilter {
+	if f == nil || other == nil || len(f) != len(other) {
+		return nil
+	}
+	merged := make(NamespaceFilter, len(f))
+	for i := range f {
+		merged[i] = f[i] | other[i]
+	}
+	return merged
+}
+
+// indexes returns the bits of the filter set for the namespace using double hashing.
+func (f NamespaceFilter) indexes(ns share.Namespace) [namespaceFilterHashes]uint64 {
+	hash := sha256.Sum256(ns)
+	h1 := binary.BigEndian.Uint64(hash[:8])
+	h2 := binary.BigEndian.Uint64(hash[8:16])
+
+	var indexes [namespaceFilterHashes]uint64
+	size := uint64(len(f)) * 8
+	for i := range indexes {
+		indexes[i] = (h1 + uint64(i)*h2) % size
+	}
+	return indexes
+}
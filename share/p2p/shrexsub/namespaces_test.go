@@ -0,0 +1,70 @@
+package shrexsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/edstest"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
+)
+
+func TestNamespaceFilter(t *testing.T) {
+	t.Run("contains present namespaces", func(t *testing.T) {
+		namespace := sharetest.RandV0Namespace()
+		eds, _ := edstest.RandEDSWithNamespace(t, namespace, 16)
+
+		filter := NewNamespaceFilterFromEDS(eds)
+		require.NoError(t, filter.Validate())
+		require.True(t, filter.Contains(namespace))
+		require.Equal(t, filter, NewNamespaceFilterFromEDS(eds))
+
+		var falsePositives int
+		for range 1000 {
+			if filter.Contains(sharetest.RandV0Namespace()) {
+				falsePositives++
+			}
+		}
+		require.Less(t, falsePositives, 50)
+	})
+
+	t.Run("nil filter is unknown", func(t *testing.T) {
+		var filter NamespaceFilter
+		require.NoError(t, filter.Validate())
+		require.True(t, filter.Contains(sharetest.RandV0Namespace()))
+	})
+
+	t.Run("size is bounded", func(t *testing.T) {
+		require.Len(t, NewNamespaceFilter(nil), minNamespaceFilterSize)
+		require.Error(t, NamespaceFilter(make([]byte, 12)).Validate())
+		require.Error(t, NamespaceFilter(make([]byte, maxNamespaceFilterSize*2)).Validate())
+	})
+
+	t.Run("validate root", func(t *testing.T) {
+		namespace := sharetest.RandV0Namespace()
+		eds, root := edstest.RandEDSWithNamespace(t, namespace, 16)
+
+		require.NoError(t, NewNamespaceFilterFromEDS(eds).ValidateRoot(root))
+		require.NoError(t, NamespaceFilter(nil).ValidateRoot(root))
+		// a filter hiding the namespaces of the block
+		filter := NewNamespaceFilter([]share.Namespace{sharetest.RandV0Namespace()})
+		require.Error(t, filter.ValidateRoot(root))
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		ns1, ns2 := sharetest.RandV0Namespace(), sharetest.RandV0Namespace()
+		filter1 := NewNamespaceFilter([]share.Namespace{ns1})
+		filter2 := NewNamespaceFilter([]share.Namespace{ns2})
+
+		merged := filter1.Merge(filter2)
+		require.True(t, merged.Contains(ns1))
+		require.True(t, merged.Contains(ns2))
+
+		namespaces := make([]share.Namespace, 100)
+		for i := range namespaces {
+			namespaces[i] = sharetest.RandV0Namespace()
+		}
+		require.Nil(t, filter1.Merge(NewNamespaceFilter(namespaces)))
+	})
+}
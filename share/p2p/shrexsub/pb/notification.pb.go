@@ -23,8 +23,9 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type RecentEDSNotification struct {
-	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	DataHash []byte `protobuf:"bytes,2,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	Height          uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	DataHash        []byte `protobuf:"bytes,2,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	NamespaceFilter []byte `protobuf:"bytes,3,opt,name=namespace_filter,json=namespaceFilter,proto3" json:"namespace_filter,omitempty"`
 }
 
 func (m *RecentEDSNotification) Reset()         { *m = RecentEDSNotification{} }
@@ -74,6 +75,13 @@ func (m *RecentEDSNotification) GetDataHash() []byte {
 	return nil
 }
 
+func (m *RecentEDSNotification) GetNamespaceFilter() []byte {
+	if m != nil {
+		return m.NamespaceFilter
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RecentEDSNotification)(nil), "share.p2p.shrex.sub.RecentEDSNotification")
 }
@@ -83,18 +91,20 @@ func init() {
 }
 
 var fileDescriptor_1a6ade914b560e62 = []byte{
-	// 176 bytes of a gzipped FileDescriptorProto
+	// 202 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x28, 0xce, 0x48, 0x2c,
 	0x4a, 0xd5, 0x2f, 0x30, 0x2a, 0xd0, 0x2f, 0xce, 0x28, 0x4a, 0xad, 0x28, 0x2e, 0x4d, 0xd2, 0x2f,
 	0x48, 0xd2, 0xcf, 0xcb, 0x2f, 0xc9, 0x4c, 0xcb, 0x4c, 0x4e, 0x2c, 0xc9, 0xcc, 0xcf, 0xd3, 0x2b,
 	0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x06, 0xab, 0xd4, 0x2b, 0x30, 0x2a, 0xd0, 0x03, 0xab, 0xd4,
-	0x2b, 0x2e, 0x4d, 0x52, 0xf2, 0xe1, 0x12, 0x0d, 0x4a, 0x4d, 0x4e, 0xcd, 0x2b, 0x71, 0x75, 0x09,
+	0x2b, 0x2e, 0x4d, 0x52, 0x2a, 0xe7, 0x12, 0x0d, 0x4a, 0x4d, 0x4e, 0xcd, 0x2b, 0x71, 0x75, 0x09,
 	0xf6, 0x43, 0xd2, 0x23, 0x24, 0xc6, 0xc5, 0x96, 0x91, 0x9a, 0x99, 0x9e, 0x51, 0x22, 0xc1, 0xa8,
 	0xc0, 0xa8, 0xc1, 0x12, 0x04, 0xe5, 0x09, 0x49, 0x73, 0x71, 0xa6, 0x24, 0x96, 0x24, 0xc6, 0x67,
 	0x24, 0x16, 0x67, 0x48, 0x30, 0x29, 0x30, 0x6a, 0xf0, 0x04, 0x71, 0x80, 0x04, 0x3c, 0x12, 0x8b,
-	0x33, 0x9c, 0x24, 0x4e, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6,
-	0x09, 0x8f, 0xe5, 0x18, 0x2e, 0x3c, 0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e, 0x21, 0x89, 0x0d, 0xec,
-	0x06, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x99, 0x16, 0xea, 0xc6, 0xaf, 0x00, 0x00, 0x00,
+	0x33, 0x84, 0x34, 0xb9, 0x04, 0xf2, 0x12, 0x73, 0x53, 0x8b, 0x0b, 0x12, 0x93, 0x53, 0xe3, 0xd3,
+	0x32, 0x73, 0x4a, 0x52, 0x8b, 0x24, 0x98, 0xc1, 0x6a, 0xf8, 0xe1, 0xe2, 0x6e, 0x60, 0x61, 0x27,
+	0x89, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63,
+	0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0x3b, 0xd7, 0x18,
+	0x30, 0x00, 0xd7, 0xf3, 0x58, 0x7b, 0xda, 0x00, 0x00, 0x00,
 }
 
 func (m *RecentEDSNotification) Marshal() (dAtA []byte, err error) {
@@ -117,6 +127,13 @@ func (m *RecentEDSNotification) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NamespaceFilter) > 0 {
+		i -= len(m.NamespaceFilter)
+		copy(dAtA[i:], m.NamespaceFilter)
+		i = encodeVarintNotification(dAtA, i, uint64(len(m.NamespaceFilter)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.DataHash) > 0 {
 		i -= len(m.DataHash)
 		copy(dAtA[i:], m.DataHash)
@@ -156,6 +173,10 @@ func (m *RecentEDSNotification) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovNotification(uint64(l))
 	}
+	l = len(m.NamespaceFilter)
+	if l > 0 {
+		n += 1 + l + sovNotification(uint64(l))
+	}
 	return n
 }
 
@@ -247,6 +268,40 @@ func (m *RecentEDSNotification) Unmarshal(dAtA []byte) error {
 				m.DataHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceFilter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNotification
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNotification
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNotification
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceFilter = append(m.NamespaceFilter[:0], dAtA[iNdEx:postIndex]...)
+			if m.NamespaceFilter == nil {
+				m.NamespaceFilter = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNotification(dAtA[iNdEx:])
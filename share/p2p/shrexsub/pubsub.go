@@ -32,6 +32,9 @@ type BroadcastFn func(context.Context, Notification) error
 type Notification struct {
 	DataHash share.DataHash
 	Height   uint64
+	// Namespaces is an optional filter of namespaces present in the block. It is nil if the
+	// broadcaster did not provide one.
+	Namespaces NamespaceFilter
 }
 
 // PubSub manages receiving and propagating the EDS from/to the network
@@ -108,14 +111,19 @@ func (v ValidatorFn) validate(ctx context.Context, p peer.ID, msg *pubsub.Messag
 	}
 
 	n := Notification{
-		DataHash: pbmsg.DataHash,
-		Height:   pbmsg.Height,
+		DataHash:   pbmsg.DataHash,
+		Height:     pbmsg.Height,
+		Namespaces: pbmsg.NamespaceFilter,
 	}
 	if n.Height == 0 || n.DataHash.IsEmptyRoot() || n.DataHash.Validate() != nil {
 		// hard reject malicious height (height 0 does not exist) and
 		// empty/invalid datahashes
 		return pubsub.ValidationReject
 	}
+	if err := n.Namespaces.Validate(); err != nil {
+		log.Debugw("validator: invalid namespace filter", "err", err)
+		return pubsub.ValidationReject
+	}
 	return v(ctx, p, n)
 }
 
@@ -135,8 +143,9 @@ func (s *PubSub) Broadcast(ctx context.Context, notification Notification) error
 	}
 
 	msg := pb.RecentEDSNotification{
-		Height:   notification.Height,
-		DataHash: notification.DataHash,
+		Height:          notification.Height,
+		DataHash:        notification.DataHash,
+		NamespaceFilter: notification.Namespaces,
 	}
 	data, err := msg.Marshal()
 	if err != nil {
@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/rand"
 
+	"github.com/celestiaorg/celestia-node/share"
 	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexsub/pb"
+	"github.com/celestiaorg/celestia-node/share/sharetest"
 )
 
 func TestPubSub(t *testing.T) {
@@ -84,6 +86,24 @@ func TestPubSub(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "valid namespace filter",
+			notif: Notification{
+				Height:     4,
+				DataHash:   rand.Bytes(32),
+				Namespaces: NewNamespaceFilter([]share.Namespace{sharetest.RandV0Namespace()}),
+			},
+			errExpected: false,
+		},
+		{
+			name: "invalid namespace filter size",
+			notif: Notification{
+				Height:     5,
+				DataHash:   rand.Bytes(32),
+				Namespaces: rand.Bytes(12),
+			},
+			errExpected: true,
+		},
 		{
 			name: "valid height, nil hash",
 			notif: Notification{
@@ -97,8 +117,9 @@ func TestPubSub(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			msg := pb.RecentEDSNotification{
-				Height:   tt.notif.Height,
-				DataHash: tt.notif.DataHash,
+				Height:          tt.notif.Height,
+				DataHash:        tt.notif.DataHash,
+				NamespaceFilter: tt.notif.Namespaces,
 			}
 			data, err := msg.Marshal()
 			require.NoError(t, err)
@@ -40,8 +40,9 @@ func (subs *Subscription) Next(ctx context.Context) (Notification, error) {
 		return Notification{}, fmt.Errorf("shrex-sub: unmarshal notification, %w", err)
 	}
 	return Notification{
-		DataHash: pbmsg.DataHash,
-		Height:   pbmsg.Height,
+		DataHash:   pbmsg.DataHash,
+		Height:     pbmsg.Height,
+		Namespaces: pbmsg.NamespaceFilter,
 	}, nil
 }
 
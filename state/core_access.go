@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	sdkErrors "cosmossdk.io/errors"
 	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
@@ -22,10 +24,11 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/tendermint/tendermint/crypto/merkle"
-	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/app/encoding"
@@ -62,6 +65,15 @@ func WithGranter(addr AccAddress) Option {
 	}
 }
 
+// WithFailoverEndpoints is a functional option to configure gRPC endpoints of Core nodes, given as
+// host:port, to fail over to. The endpoint to use is chosen with SetActiveEndpoint, e.g. by the
+// health checks of the core.BlockFetcher.
+func WithFailoverEndpoints(endpoints ...string) Option {
+	return func(ca *CoreAccessor) {
+		ca.failoverEndpoints = endpoints
+	}
+}
+
 // CoreAccessor implements service over a gRPC connection
 // with a celestia-core node.
 type CoreAccessor struct {
@@ -78,15 +90,23 @@ type CoreAccessor struct {
 
 	getter libhead.Head[*header.ExtendedHeader]
 
-	stakingCli   stakingtypes.QueryClient
 	feeGrantCli  feegrant.QueryClient
 	abciQueryCli tmservice.ServiceClient
 
 	prt *merkle.ProofRuntime
+	cdc codec.Codec
 
 	coreConn *grpc.ClientConn
 	coreIP   string
 	grpcPort string
+	// failoverEndpoints follow the primary endpoint in the order of priority
+	failoverEndpoints []string
+	// endpointLk guards switching between the endpoints
+	endpointLk       sync.Mutex
+	activeEndpoint   atomic.Int32
+	endpointResolver *manual.Resolver
+	// endpointConnected is set once the resolver is used by the connection
+	endpointConnected bool
 
 	// these fields are mutatable and thus need to be protected by a mutex
 	lock            sync.Mutex
@@ -136,6 +156,7 @@ func NewCoreAccessor(
 		coreIP:   coreIP,
 		grpcPort: grpcPort,
 		prt:      prt,
+		cdc:      encoding.MakeConfig(app.ModuleEncodingRegisters...).Codec,
 	}
 
 	for _, opt := range options {
@@ -151,11 +172,19 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	ca.ctx, ca.cancel = context.WithCancel(context.Background())
 
 	// dial given celestia-core endpoint
-	endpoint := fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
-	client, err := grpc.NewClient(
-		endpoint,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	endpoint := ca.endpoint(0)
+	target, opts := endpoint, []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if len(ca.failoverEndpoints) > 0 {
+		// the connection is kept to the single active endpoint, which is replaced on failover
+		ca.endpointLk.Lock()
+		endpoint = ca.endpoint(int(ca.activeEndpoint.Load()))
+		ca.endpointResolver = manual.NewBuilderWithScheme("core-failover")
+		ca.endpointResolver.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: endpoint}}})
+		ca.endpointLk.Unlock()
+		target = ca.endpointResolver.Scheme() + ":///" + ca.endpoint(0)
+		opts = append(opts, grpc.WithResolvers(ca.endpointResolver))
+	}
+	client, err := grpc.NewClient(target, opts...)
 	if err != nil {
 		return err
 	}
@@ -167,9 +196,17 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	}
 
 	ca.coreConn = client
+	if ca.endpointResolver != nil {
+		ca.endpointLk.Lock()
+		ca.endpointConnected = true
+		if ca.endpoint(int(ca.activeEndpoint.Load())) != endpoint {
+			// failed over while connecting
+			ca.updateEndpoint()
+		}
+		ca.endpointLk.Unlock()
+	}
 
-	// create the staking query client
-	ca.stakingCli = stakingtypes.NewQueryClient(ca.coreConn)
+	// create the fee grant query client
 	ca.feeGrantCli = feegrant.NewQueryClient(ca.coreConn)
 
 	// create ABCI query client
@@ -207,9 +244,55 @@ func (ca *CoreAccessor) Stop(context.Context) error {
 	}
 
 	ca.coreConn = nil
+	ca.endpointLk.Lock()
+	ca.endpointResolver, ca.endpointConnected = nil, false
+	ca.endpointLk.Unlock()
 	return nil
 }
 
+// SetActiveEndpoint switches the gRPC connection to the endpoint with the given index, with 0 being
+// the primary one and the rest being the failover endpoints in the given order. In-flight requests
+// complete over the previous connection.
+func (ca *CoreAccessor) SetActiveEndpoint(endpoint int) {
+	if endpoint < 0 || endpoint > len(ca.failoverEndpoints) {
+		log.Errorw("core-access: unknown core endpoint", "endpoint", endpoint)
+		return
+	}
+
+	ca.endpointLk.Lock()
+	defer ca.endpointLk.Unlock()
+	if int(ca.activeEndpoint.Swap(int32(endpoint))) == endpoint {
+		return
+	}
+	log.Warnw("core-access: switching to another core endpoint", "endpoint", ca.endpoint(endpoint))
+	if !ca.endpointConnected {
+		// not connected yet, so the endpoint is picked up on start
+		return
+	}
+	ca.updateEndpoint()
+}
+
+// updateEndpoint points the connection to the active endpoint. It must be called with endpointLk
+// held.
+func (ca *CoreAccessor) updateEndpoint() {
+	addr := ca.endpoint(int(ca.activeEndpoint.Load()))
+	ca.endpointResolver.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: addr}}})
+}
+
+// ActiveEndpoint returns the index of the endpoint used for gRPC requests, with 0 being the
+// primary one.
+func (ca *CoreAccessor) ActiveEndpoint() int {
+	return int(ca.activeEndpoint.Load())
+}
+
+// endpoint returns the address of the endpoint with the given index.
+func (ca *CoreAccessor) endpoint(endpoint int) string {
+	if endpoint == 0 {
+		return fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
+	}
+	return ca.failoverEndpoints[endpoint-1]
+}
+
 func (ca *CoreAccessor) cancelCtx() {
 	ca.cancel()
 	ca.cancel = nil
@@ -324,35 +407,17 @@ func (ca *CoreAccessor) Balance(ctx context.Context) (*Balance, error) {
 }
 
 func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*Balance, error) {
-	head, err := ca.getter.Head(ctx)
-	if err != nil {
-		return nil, err
-	}
-	// construct an ABCI query for the height at head-1 because
-	// the AppHash contained in the head is actually the state root
-	// after applying the transactions contained in the previous block.
-	// TODO @renaynay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use this method
-	// instead
 	prefixedAccountKey := append(banktypes.CreateAccountBalancesPrefix(addr.Bytes()), []byte(app.BondDenom)...)
-	req := &tmservice.ABCIQueryRequest{
-		Data: prefixedAccountKey,
-		// TODO @renayay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use const instead
-		Path:   fmt.Sprintf("store/%s/key", banktypes.StoreKey),
-		Height: int64(head.Height() - 1),
-		Prove:  true,
-	}
-
-	result, err := ca.abciQueryCli.ABCIQuery(ctx, req)
-	if err != nil || result.GetCode() != 0 {
-		err = fmt.Errorf("failed to query for balance: %w; result log: %s", err, result.GetLog())
-		return nil, err
+	result, err := ca.QueryVerified(ctx, banktypes.StoreKey, prefixedAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query for balance: %w", err)
 	}
 
 	// unmarshal balance information
-	value := result.GetValue()
+	value := result.Value
 	// if the value returned is empty, the account balance does not yet exist
 	if len(value) == 0 {
-		log.Errorf("balance for account %s does not exist at block height %d", addr.String(), head.Height()-1)
+		log.Errorf("balance for account %s does not exist at block height %d", addr.String(), result.Height)
 		return &Balance{
 			Denom:  app.BondDenom,
 			Amount: sdktypes.NewInt(0),
@@ -363,33 +428,6 @@ func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*B
 		return nil, fmt.Errorf("cannot convert %s into sdktypes.Int", string(value))
 	}
 
-	if result.GetProofOps() == nil {
-		return nil, fmt.Errorf("failed to get proofs for balance of address %s", addr.String())
-	}
-
-	// verify balance
-	proofOps := &crypto.ProofOps{
-		Ops: make([]crypto.ProofOp, len(result.ProofOps.Ops)),
-	}
-	for i, proofOp := range result.ProofOps.Ops {
-		proofOps.Ops[i] = crypto.ProofOp{
-			Type: proofOp.Type,
-			Key:  proofOp.Key,
-			Data: proofOp.Data,
-		}
-	}
-
-	err = ca.prt.VerifyValueFromKeys(
-		proofOps,
-		head.AppHash,
-		[][]byte{
-			[]byte(banktypes.StoreKey),
-			prefixedAccountKey,
-		}, value)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Balance{
 		Denom:  app.BondDenom,
 		Amount: coin,
@@ -565,39 +603,126 @@ func (ca *CoreAccessor) Delegate(
 	return unsetTx(resp), err
 }
 
+// QueryDelegation retrieves the delegation between the node's account and the validator from the
+// state at head-1 and verifies it against the AppHash of the head.
 func (ca *CoreAccessor) QueryDelegation(
 	ctx context.Context,
 	valAddr ValAddress,
 ) (*stakingtypes.QueryDelegationResponse, error) {
+	head, err := ca.getter.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	delAddr := ca.addr
-	return ca.stakingCli.Delegation(ctx, &stakingtypes.QueryDelegationRequest{
-		DelegatorAddr: delAddr.String(),
-		ValidatorAddr: valAddr.String(),
-	})
+	result, err := ca.queryVerified(ctx, head, stakingtypes.StoreKey, stakingtypes.GetDelegationKey(delAddr, valAddr))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, fmt.Errorf("%w: delegator %s, validator %s", stakingtypes.ErrNoDelegation, delAddr, valAddr)
+	}
+	delegation, err := stakingtypes.UnmarshalDelegation(ca.cdc, result.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := ca.queryValidator(ctx, head, valAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &stakingtypes.QueryDelegationResponse{
+		DelegationResponse: &stakingtypes.DelegationResponse{
+			Delegation: delegation,
+			Balance:    sdktypes.NewCoin(app.BondDenom, val.TokensFromShares(delegation.Shares).TruncateInt()),
+		},
+	}, nil
 }
 
+// QueryUnbonding retrieves the unbonding delegation between the node's account and the validator
+// from the state at head-1 and verifies it against the AppHash of the head.
 func (ca *CoreAccessor) QueryUnbonding(
 	ctx context.Context,
 	valAddr ValAddress,
 ) (*stakingtypes.QueryUnbondingDelegationResponse, error) {
 	delAddr := ca.addr
-	return ca.stakingCli.UnbondingDelegation(ctx, &stakingtypes.QueryUnbondingDelegationRequest{
-		DelegatorAddr: delAddr.String(),
-		ValidatorAddr: valAddr.String(),
-	})
+	result, err := ca.QueryVerified(ctx, stakingtypes.StoreKey, stakingtypes.GetUBDKey(delAddr, valAddr))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, fmt.Errorf("%w: delegator %s, validator %s", stakingtypes.ErrNoUnbondingDelegation, delAddr, valAddr)
+	}
+	unbond, err := stakingtypes.UnmarshalUBD(ca.cdc, result.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &stakingtypes.QueryUnbondingDelegationResponse{Unbond: unbond}, nil
 }
 
+// QueryRedelegations retrieves the redelegation of the node's account between the validators from
+// the state at head-1 and verifies it against the AppHash of the head.
 func (ca *CoreAccessor) QueryRedelegations(
 	ctx context.Context,
 	srcValAddr,
 	dstValAddr ValAddress,
 ) (*stakingtypes.QueryRedelegationsResponse, error) {
+	head, err := ca.getter.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	delAddr := ca.addr
-	return ca.stakingCli.Redelegations(ctx, &stakingtypes.QueryRedelegationsRequest{
-		DelegatorAddr:    delAddr.String(),
-		SrcValidatorAddr: srcValAddr.String(),
-		DstValidatorAddr: dstValAddr.String(),
-	})
+	key := stakingtypes.GetREDKey(delAddr, srcValAddr, dstValAddr)
+	result, err := ca.queryVerified(ctx, head, stakingtypes.StoreKey, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, fmt.Errorf("%w: delegator %s, source validator %s, destination validator %s",
+			stakingtypes.ErrNoRedelegation, delAddr, srcValAddr, dstValAddr)
+	}
+	redelegation, err := stakingtypes.UnmarshalRED(ca.cdc, result.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	dstVal, err := ca.queryValidator(ctx, head, dstValAddr)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]stakingtypes.RedelegationEntryResponse, len(redelegation.Entries))
+	for i, entry := range redelegation.Entries {
+		entries[i] = stakingtypes.NewRedelegationEntryResponse(
+			entry.CreationHeight,
+			entry.CompletionTime,
+			entry.SharesDst,
+			entry.InitialBalance,
+			dstVal.TokensFromShares(entry.SharesDst).TruncateInt(),
+		)
+	}
+	return &stakingtypes.QueryRedelegationsResponse{
+		RedelegationResponses: stakingtypes.RedelegationResponses{
+			stakingtypes.NewRedelegationResponse(delAddr, srcValAddr, dstValAddr, entries),
+		},
+	}, nil
+}
+
+// queryValidator retrieves the validator from the state at head-1 and verifies it against the
+// AppHash of the head.
+func (ca *CoreAccessor) queryValidator(
+	ctx context.Context,
+	head *header.ExtendedHeader,
+	valAddr ValAddress,
+) (stakingtypes.Validator, error) {
+	result, err := ca.queryVerified(ctx, head, stakingtypes.StoreKey, stakingtypes.GetValidatorKey(valAddr))
+	if err != nil {
+		return stakingtypes.Validator{}, err
+	}
+	if len(result.Value) == 0 {
+		return stakingtypes.Validator{}, fmt.Errorf("%w: %s", stakingtypes.ErrNoValidatorFound, valAddr)
+	}
+	return stakingtypes.UnmarshalValidator(ca.cdc, result.Value)
 }
 
 func (ca *CoreAccessor) GrantFee(
@@ -95,3 +95,39 @@ func extractPort(addr string) string {
 	splitStr := strings.Split(addr, ":")
 	return splitStr[len(splitStr)-1]
 }
+
+func TestCoreAccessor_SetActiveEndpoint(t *testing.T) {
+	accounts := []string{"jimy"}
+	config := testnode.DefaultConfig().WithAccounts(accounts)
+	cctx, _, grpcAddr := testnode.NewNetwork(t, config)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// nothing listens on the primary endpoint
+	ca, err := NewCoreAccessor(cctx.Keyring, accounts[0], nil, "127.0.0.1", "1",
+		WithFailoverEndpoints("127.0.0.1:"+extractPort(grpcAddr)))
+	require.NoError(t, err)
+	// the endpoint chosen before start is used for the initial connection
+	ca.SetActiveEndpoint(1)
+	require.NoError(t, ca.Start(ctx))
+	t.Cleanup(func() {
+		_ = ca.Stop(ctx)
+	})
+	require.Equal(t, 1, ca.ActiveEndpoint())
+
+	_, err = ca.queryMinimumGasPrice(ctx)
+	require.NoError(t, err)
+
+	ca.SetActiveEndpoint(0)
+	reqCtx, reqCancel := context.WithTimeout(ctx, time.Second)
+	_, err = ca.queryMinimumGasPrice(reqCtx)
+	reqCancel()
+	require.Error(t, err)
+
+	// requests fail fast until the connection to the new endpoint is established
+	ca.SetActiveEndpoint(1)
+	require.Eventually(t, func() bool {
+		_, err = ca.queryMinimumGasPrice(ctx)
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+}
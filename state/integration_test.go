@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -61,10 +63,6 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 func setClients(ca *CoreAccessor, conn *grpc.ClientConn) {
 	ca.coreConn = conn
-	// create the staking query client
-	stakingCli := stakingtypes.NewQueryClient(ca.coreConn)
-	ca.stakingCli = stakingCli
-
 	ca.abciQueryCli = tmservice.NewServiceClient(ca.coreConn)
 }
 
@@ -116,6 +114,58 @@ func (s *IntegrationTestSuite) TestGetBalance() {
 	}
 }
 
+func (s *IntegrationTestSuite) TestQueryVerified() {
+	require := s.Require()
+	ctx := context.Background()
+
+	addr := s.getAddress(s.accounts[0])
+	key := append(banktypes.CreateAccountBalancesPrefix(addr.Bytes()), []byte(app.BondDenom)...)
+	val, err := s.accessor.QueryVerified(ctx, banktypes.StoreKey, key)
+	require.NoError(err)
+	require.NotEmpty(val.Value)
+	require.NotZero(val.Height)
+
+	// absent keys are proven as well
+	absent := append(banktypes.CreateAccountBalancesPrefix(addr.Bytes()), []byte("absent")...)
+	val, err = s.accessor.QueryVerified(ctx, banktypes.StoreKey, absent)
+	require.NoError(err)
+	require.Empty(val.Value)
+
+	// values not matching the AppHash are rejected
+	accessor, err := NewCoreAccessor(s.cctx.Keyring, s.accounts[0], tamperedHeader{localHeader{s.cctx.Client}}, "", "")
+	require.NoError(err)
+	setClients(accessor, s.cctx.GRPCClient)
+	_, err = accessor.QueryVerified(ctx, banktypes.StoreKey, key)
+	require.ErrorIs(err, ErrInvalidStateProof)
+}
+
+func (s *IntegrationTestSuite) TestQueryDelegation_Absent() {
+	require := s.Require()
+	valAddr := sdk.ValAddress(s.getAddress(s.accounts[1]).Bytes())
+
+	_, err := s.accessor.QueryDelegation(context.Background(), valAddr)
+	require.ErrorIs(err, stakingtypes.ErrNoDelegation)
+	_, err = s.accessor.QueryUnbonding(context.Background(), valAddr)
+	require.ErrorIs(err, stakingtypes.ErrNoUnbondingDelegation)
+}
+
+// tamperedHeader returns the head with an AppHash not matching the state.
+type tamperedHeader struct {
+	localHeader
+}
+
+func (t tamperedHeader) Head(
+	ctx context.Context,
+	opts ...libhead.HeadOption[*header.ExtendedHeader],
+) (*header.ExtendedHeader, error) {
+	h, err := t.localHeader.Head(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	h.AppHash = bytes.Repeat([]byte{0xFF}, len(h.AppHash))
+	return h, nil
+}
+
 // This test can be used to generate a json encoded block for other test data,
 // such as that in share/availability/light/testdata
 func (s *IntegrationTestSuite) TestGenerateJSONBlock() {
@@ -20,13 +20,19 @@ func WithMetrics(lc fx.Lifecycle, ca *CoreAccessor) {
 		metric.WithDescription("Timestamp of the last submitted PayForBlob transaction"),
 	)
 
+	activeEndpoint, _ := meter.Int64ObservableGauge(
+		"state_core_active_endpoint",
+		metric.WithDescription("index of the core endpoint used for state queries, with 0 being the primary one"),
+	)
+
 	callback := func(_ context.Context, observer metric.Observer) error {
 		observer.ObserveInt64(pfbCounter, ca.PayForBlobCount())
 		observer.ObserveInt64(lastPfbTimestamp, ca.LastPayForBlob())
+		observer.ObserveInt64(activeEndpoint, int64(ca.ActiveEndpoint()))
 		return nil
 	}
 
-	clientReg, err := meter.RegisterCallback(callback, pfbCounter, lastPfbTimestamp)
+	clientReg, err := meter.RegisterCallback(callback, pfbCounter, lastPfbTimestamp, activeEndpoint)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// ErrInvalidStateProof is returned when a value queried from the state can't be proven against
+// the AppHash of the corresponding header.
+var ErrInvalidStateProof = errors.New("state: invalid state proof")
+
+// VerifiedValue is the value of a key in the state, verified against the AppHash of the
+// corresponding ExtendedHeader from the local header store.
+type VerifiedValue struct {
+	// Height of the state the value is read at. The value is verified against the AppHash of the
+	// header at Height+1, as the AppHash of a header is the state root after applying the
+	// transactions of the previous block.
+	Height uint64 `json:"height"`
+	// Value of the key. It is empty if the key is absent in the state, in which case the absence
+	// is verified instead.
+	Value []byte `json:"value"`
+}
+
+// QueryVerified retrieves the value of the key from the given store (e.g. "bank" or "staking")
+// of the state at the node's current head-1 and verifies it against the AppHash of the head.
+func (ca *CoreAccessor) QueryVerified(ctx context.Context, storeKey string, key []byte) (*VerifiedValue, error) {
+	head, err := ca.getter.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ca.queryVerified(ctx, head, storeKey, key)
+}
+
+// queryVerified retrieves the value of the key with proofs from the state at head-1 and verifies
+// it against the AppHash of the head.
+func (ca *CoreAccessor) queryVerified(
+	ctx context.Context,
+	head *header.ExtendedHeader,
+	storeKey string,
+	key []byte,
+) (*VerifiedValue, error) {
+	// construct an ABCI query for the height at head-1 because
+	// the AppHash contained in the head is actually the state root
+	// after applying the transactions contained in the previous block.
+	// TODO @renaynay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use this method
+	// instead
+	height := head.Height() - 1
+	req := &tmservice.ABCIQueryRequest{
+		Data: key,
+		// TODO @renayay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use const instead
+		Path:   fmt.Sprintf("store/%s/key", storeKey),
+		Height: int64(height),
+		Prove:  true,
+	}
+
+	result, err := ca.abciQueryCli.ABCIQuery(ctx, req)
+	if err != nil || result.GetCode() != 0 {
+		return nil, fmt.Errorf("failed to query key %X from store %s: %w; result log: %s",
+			key, storeKey, err, result.GetLog())
+	}
+	if result.GetProofOps() == nil {
+		return nil, fmt.Errorf("failed to get proofs for key %X from store %s", key, storeKey)
+	}
+
+	proofOps := &crypto.ProofOps{
+		Ops: make([]crypto.ProofOp, len(result.ProofOps.Ops)),
+	}
+	for i, proofOp := range result.ProofOps.Ops {
+		proofOps.Ops[i] = crypto.ProofOp{
+			Type: proofOp.Type,
+			Key:  proofOp.Key,
+			Data: proofOp.Data,
+		}
+	}
+
+	keys := [][]byte{[]byte(storeKey), key}
+	value := result.GetValue()
+	if len(value) == 0 {
+		// no arguments make the proof operators verify the absence of the key
+		err = ca.prt.VerifyFromKeys(proofOps, head.AppHash, keys, nil)
+	} else {
+		err = ca.prt.VerifyValueFromKeys(proofOps, head.AppHash, keys, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %X from store %s at height %d: %w",
+			ErrInvalidStateProof, key, storeKey, height, err)
+	}
+
+	return &VerifiedValue{
+		Height: height,
+		Value:  value,
+	}, nil
+}